@@ -6,6 +6,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -14,7 +15,9 @@ import (
 	handler_v1 "github.com/chekist32/goipay/internal/handler/v1"
 	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
 	"github.com/chekist32/goipay/internal/processor"
+	"github.com/chekist32/goipay/internal/rate"
 	"github.com/chekist32/goipay/internal/util"
+	"github.com/chekist32/goipay/internal/webhook"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
@@ -45,6 +48,13 @@ type AppConfigDaemon struct {
 	Pass string `yaml:"pass"`
 }
 
+type AppConfigEVMToken struct {
+	Symbol   string `yaml:"symbol"`
+	Contract string `yaml:"contract"`
+	Decimals int32  `yaml:"decimals"`
+	Disabled bool   `yaml:"disabled"`
+}
+
 type AppConfigTls struct {
 	Mode string `yaml:"mode"`
 	Ca   string `yaml:"ca"`
@@ -78,12 +88,38 @@ type AppConfig struct {
 			Daemon AppConfigDaemon `yaml:"daemon"`
 		} `yaml:"ltc"`
 		Eth struct {
-			Daemon AppConfigDaemon `yaml:"daemon"`
+			Daemon AppConfigDaemon     `yaml:"daemon"`
+			Tokens []AppConfigEVMToken `yaml:"tokens"`
 		} `yaml:"eth"`
 		Bnb struct {
-			Daemon AppConfigDaemon `yaml:"daemon"`
+			Daemon AppConfigDaemon     `yaml:"daemon"`
+			Tokens []AppConfigEVMToken `yaml:"tokens"`
 		} `yaml:"bnb"`
+		Ton struct {
+			Daemon AppConfigDaemon `yaml:"daemon"`
+		} `yaml:"ton"`
+		Trx struct {
+			Daemon AppConfigDaemon `yaml:"daemon"`
+		} `yaml:"trx"`
+		Evm []struct {
+			Name     string              `yaml:"name"`
+			ChainId  uint64              `yaml:"chainId"`
+			Url      string              `yaml:"url"`
+			Decimals int32               `yaml:"decimals"`
+			Tokens   []AppConfigEVMToken `yaml:"tokens"`
+		} `yaml:"evm"`
 	} `yaml:"coin"`
+
+	Invoice struct {
+		LatePaymentGraceWindow string `yaml:"latePaymentGraceWindow"`
+	} `yaml:"invoice"`
+
+	Rate struct {
+		Http struct {
+			Url  string `yaml:"url"`
+			Path string `yaml:"path"`
+		} `yaml:"http"`
+	} `yaml:"rate"`
 }
 
 func NewAppConfig(path string) (*AppConfig, error) {
@@ -131,6 +167,23 @@ func NewAppConfig(path string) (*AppConfig, error) {
 	conf.Coin.Bnb.Daemon.User = os.ExpandEnv(conf.Coin.Bnb.Daemon.User)
 	conf.Coin.Bnb.Daemon.Pass = os.ExpandEnv(conf.Coin.Bnb.Daemon.Pass)
 
+	conf.Coin.Ton.Daemon.Url = os.ExpandEnv(conf.Coin.Ton.Daemon.Url)
+	conf.Coin.Ton.Daemon.User = os.ExpandEnv(conf.Coin.Ton.Daemon.User)
+	conf.Coin.Ton.Daemon.Pass = os.ExpandEnv(conf.Coin.Ton.Daemon.Pass)
+
+	conf.Coin.Trx.Daemon.Url = os.ExpandEnv(conf.Coin.Trx.Daemon.Url)
+	conf.Coin.Trx.Daemon.User = os.ExpandEnv(conf.Coin.Trx.Daemon.User)
+	conf.Coin.Trx.Daemon.Pass = os.ExpandEnv(conf.Coin.Trx.Daemon.Pass)
+
+	for i := 0; i < len(conf.Coin.Evm); i++ {
+		conf.Coin.Evm[i].Url = os.ExpandEnv(conf.Coin.Evm[i].Url)
+	}
+
+	conf.Invoice.LatePaymentGraceWindow = os.ExpandEnv(conf.Invoice.LatePaymentGraceWindow)
+
+	conf.Rate.Http.Url = os.ExpandEnv(conf.Rate.Http.Url)
+	conf.Rate.Http.Path = os.ExpandEnv(conf.Rate.Http.Path)
+
 	return &conf, nil
 }
 
@@ -159,8 +212,9 @@ func (a *App) Start(ctx context.Context) error {
 	}
 
 	g := grpc.NewServer(getGrpcServerOptions(a)...)
-	pb_v1.RegisterUserServiceServer(g, handler_v1.NewUserGrpc(a.dbConnPool, a.log))
+	pb_v1.RegisterUserServiceServer(g, handler_v1.NewUserGrpc(a.dbConnPool, a.paymentProcessor, a.log))
 	pb_v1.RegisterInvoiceServiceServer(g, handler_v1.NewInvoiceGrpc(a.dbConnPool, a.paymentProcessor, a.log))
+	pb_v1.RegisterWebhookServiceServer(g, handler_v1.NewWebhookGrpc(a.dbConnPool, a.log))
 
 	if a.opts.ReflectionEnabled {
 		reflection.Register(g)
@@ -291,6 +345,33 @@ func getGrpcCrednetials(log *zerolog.Logger, c *AppConfig, opts *CliOpts) (grpc.
 	return nil, false
 }
 
+func appConfigToProcessorConfig(c *AppConfig) (*dto.ProcessorConfig, error) {
+	latePaymentGraceWindow := util.DEFAULT_LATE_PAYMENT_GRACE_WINDOW
+	if c.Invoice.LatePaymentGraceWindow != "" {
+		d, err := time.ParseDuration(c.Invoice.LatePaymentGraceWindow)
+		if err != nil {
+			return nil, err
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid late payment grace window: %v. It can't be negative", d)
+		}
+		latePaymentGraceWindow = d
+	}
+
+	return &dto.ProcessorConfig{
+		Daemons:                *appConfigToDaemonsConfig(c),
+		LatePaymentGraceWindow: latePaymentGraceWindow,
+	}, nil
+}
+
+func appConfigToRateProvider(c *AppConfig) rate.RateProvider {
+	if c.Rate.Http.Url == "" {
+		return nil
+	}
+
+	return rate.NewHttpJsonRateProvider(&http.Client{Timeout: util.RATE_PROVIDER_TIMEOUT}, c.Rate.Http.Url, c.Rate.Http.Path)
+}
+
 func appConfigToDaemonsConfig(c *AppConfig) *dto.DaemonsConfig {
 	acdTodc := func(c *AppConfigDaemon) *dto.DaemonConfig {
 		return &dto.DaemonConfig{
@@ -300,12 +381,35 @@ func appConfigToDaemonsConfig(c *AppConfig) *dto.DaemonsConfig {
 		}
 	}
 
+	acetToetc := func(c []AppConfigEVMToken) []dto.EVMTokenConfig {
+		tokens := make([]dto.EVMTokenConfig, 0, len(c))
+		for i := 0; i < len(c); i++ {
+			tokens = append(tokens, dto.EVMTokenConfig(c[i]))
+		}
+		return tokens
+	}
+
+	evm := make([]dto.EVMChainConfig, 0, len(c.Coin.Evm))
+	for i := 0; i < len(c.Coin.Evm); i++ {
+		chain := dto.EVMChainConfig{
+			Name:     c.Coin.Evm[i].Name,
+			ChainId:  c.Coin.Evm[i].ChainId,
+			Url:      c.Coin.Evm[i].Url,
+			Decimals: c.Coin.Evm[i].Decimals,
+			Tokens:   acetToetc(c.Coin.Evm[i].Tokens),
+		}
+		evm = append(evm, chain)
+	}
+
 	return &dto.DaemonsConfig{
 		Xmr: dto.XMRDaemonConfig(*acdTodc(&c.Coin.Xmr.Daemon)),
 		Btc: dto.BTCDaemonConfig(*acdTodc(&c.Coin.Btc.Daemon)),
 		Ltc: dto.LTCDaemonConfig(*acdTodc(&c.Coin.Ltc.Daemon)),
-		Eth: dto.ETHDaemonConfig(*acdTodc(&c.Coin.Eth.Daemon)),
-		Bnb: dto.BNBDaemonConfig(*acdTodc(&c.Coin.Bnb.Daemon)),
+		Eth: dto.ETHDaemonConfig{DaemonConfig: *acdTodc(&c.Coin.Eth.Daemon), Tokens: acetToetc(c.Coin.Eth.Tokens)},
+		Bnb: dto.BNBDaemonConfig{DaemonConfig: *acdTodc(&c.Coin.Bnb.Daemon), Tokens: acetToetc(c.Coin.Bnb.Tokens)},
+		Ton: dto.TONDaemonConfig(*acdTodc(&c.Coin.Ton.Daemon)),
+		Trx: dto.TRXDaemonConfig(*acdTodc(&c.Coin.Trx.Daemon)),
+		Evm: evm,
 	}
 }
 
@@ -329,11 +433,18 @@ func NewApp(opts CliOpts) *App {
 		log.Fatal().Err(err).Msg("")
 	}
 
-	pp, err := processor.NewPaymentProcessor(ctx, connPool, appConfigToDaemonsConfig(conf), log)
+	processorConf, err := appConfigToProcessorConfig(conf)
 	if err != nil {
 		log.Fatal().Err(err).Msg("")
 	}
 
+	pp, err := processor.NewPaymentProcessor(ctx, connPool, processorConf, appConfigToRateProvider(conf), log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+
+	webhook.NewWorker(connPool, &http.Client{Timeout: util.WEBHOOK_DELIVERY_TIMEOUT}, log).Start(ctx)
+
 	return &App{
 		log:              log,
 		ctxCancel:        cancel,
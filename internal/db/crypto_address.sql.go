@@ -12,23 +12,31 @@ import (
 )
 
 const createCryptoAddress = `-- name: CreateCryptoAddress :one
-INSERT INTO crypto_addresses(address, coin, is_occupied, user_id) VALUES ($1, $2, $3, $4)
-RETURNING id, address, coin, is_occupied, user_id
+INSERT INTO crypto_addresses(address, coin, is_occupied, user_id, memo, derivation_path, key_version)
+VALUES ($1, $2, $3, $4, $5, $6, (
+    SELECT MAX(kv.version) FROM crypto_key_versions AS kv WHERE kv.user_id = $4 AND kv.coin = $2
+))
+RETURNING id, address, coin, is_occupied, user_id, memo, derivation_seq, key_version, derivation_path, is_retired
 `
 
 type CreateCryptoAddressParams struct {
-	Address    string
-	Coin       CoinType
-	IsOccupied bool
-	UserID     pgtype.UUID
+	Address        string
+	Coin           CoinType
+	IsOccupied     bool
+	UserID         pgtype.UUID
+	Memo           pgtype.Text
+	DerivationPath pgtype.Text
 }
 
+// The address is derived from the current key version of the coin.
 func (q *Queries) CreateCryptoAddress(ctx context.Context, arg CreateCryptoAddressParams) (CryptoAddress, error) {
 	row := q.db.QueryRow(ctx, createCryptoAddress,
 		arg.Address,
 		arg.Coin,
 		arg.IsOccupied,
 		arg.UserID,
+		arg.Memo,
+		arg.DerivationPath,
 	)
 	var i CryptoAddress
 	err := row.Scan(
@@ -37,6 +45,11 @@ func (q *Queries) CreateCryptoAddress(ctx context.Context, arg CreateCryptoAddre
 		&i.Coin,
 		&i.IsOccupied,
 		&i.UserID,
+		&i.Memo,
+		&i.DerivationSeq,
+		&i.KeyVersion,
+		&i.DerivationPath,
+		&i.IsRetired,
 	)
 	return i, err
 }
@@ -44,7 +57,7 @@ func (q *Queries) CreateCryptoAddress(ctx context.Context, arg CreateCryptoAddre
 const deleteAllCryptoAddressByUserIdAndCoin = `-- name: DeleteAllCryptoAddressByUserIdAndCoin :many
 DELETE FROM crypto_addresses 
 WHERE user_id = $1 AND coin = $2
-RETURNING id, address, coin, is_occupied, user_id
+RETURNING id, address, coin, is_occupied, user_id, memo, derivation_seq, key_version, derivation_path, is_retired
 `
 
 type DeleteAllCryptoAddressByUserIdAndCoinParams struct {
@@ -67,6 +80,11 @@ func (q *Queries) DeleteAllCryptoAddressByUserIdAndCoin(ctx context.Context, arg
 			&i.Coin,
 			&i.IsOccupied,
 			&i.UserID,
+			&i.Memo,
+			&i.DerivationSeq,
+			&i.KeyVersion,
+			&i.DerivationPath,
+			&i.IsRetired,
 		); err != nil {
 			return nil, err
 		}
@@ -78,15 +96,41 @@ func (q *Queries) DeleteAllCryptoAddressByUserIdAndCoin(ctx context.Context, arg
 	return items, nil
 }
 
+const existsRetiredCryptoAddress = `-- name: ExistsRetiredCryptoAddress :one
+SELECT EXISTS (
+    SELECT 1 FROM crypto_addresses
+    WHERE address = $1 AND memo IS NOT DISTINCT FROM $2 AND is_retired
+)
+`
+
+type ExistsRetiredCryptoAddressParams struct {
+	Address string
+	Memo    pgtype.Text
+}
+
+func (q *Queries) ExistsRetiredCryptoAddress(ctx context.Context, arg ExistsRetiredCryptoAddressParams) (bool, error) {
+	row := q.db.QueryRow(ctx, existsRetiredCryptoAddress, arg.Address, arg.Memo)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const findNonOccupiedCryptoAddressAndLockByUserIdAndCoin = `-- name: FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin :one
 UPDATE crypto_addresses SET is_occupied = true
-WHERE address = (
-    SELECT address FROM crypto_addresses AS ca
-    WHERE ca.user_id = $1 AND ca.coin = $2 AND ca.is_occupied = false 
-    FOR UPDATE SKIP LOCKED
+WHERE id = (
+    SELECT ca.id FROM crypto_addresses AS ca
+    LEFT JOIN crypto_data AS cd ON cd.user_id = ca.user_id
+    WHERE ca.user_id = $1 AND ca.coin = $2 AND ca.is_occupied = false AND NOT ca.is_retired AND (
+        COALESCE(cd.address_reuse_policy, 'REUSE') = 'REUSE' OR NOT EXISTS (
+            SELECT 1 FROM invoices AS i
+            WHERE i.crypto_address = ca.address AND i.memo IS NOT DISTINCT FROM ca.memo AND i.user_id = ca.user_id
+                AND (cd.address_reuse_policy = 'NEVER_REUSE' OR i.actual_amount > 0)
+        )
+    )
+    FOR UPDATE OF ca SKIP LOCKED
     LIMIT 1
 )
-RETURNING id, address, coin, is_occupied, user_id
+RETURNING id, address, coin, is_occupied, user_id, memo, derivation_seq, key_version, derivation_path, is_retired
 `
 
 type FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams struct {
@@ -94,6 +138,7 @@ type FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams struct {
 	Coin   CoinType
 }
 
+// The address reuse policy of the user decides which of the released addresses may take another invoice.
 func (q *Queries) FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx context.Context, arg FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams) (CryptoAddress, error) {
 	row := q.db.QueryRow(ctx, findNonOccupiedCryptoAddressAndLockByUserIdAndCoin, arg.UserID, arg.Coin)
 	var i CryptoAddress
@@ -103,6 +148,101 @@ func (q *Queries) FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx context
 		&i.Coin,
 		&i.IsOccupied,
 		&i.UserID,
+		&i.Memo,
+		&i.DerivationSeq,
+		&i.KeyVersion,
+		&i.DerivationPath,
+		&i.IsRetired,
+	)
+	return i, err
+}
+
+const retireAllCryptoAddressesByUserIdAndCoin = `-- name: RetireAllCryptoAddressesByUserIdAndCoin :many
+UPDATE crypto_addresses
+SET is_retired = true
+WHERE user_id = $1 AND coin = $2 AND NOT is_retired
+RETURNING id, address, coin, is_occupied, user_id, memo, derivation_seq, key_version, derivation_path, is_retired
+`
+
+type RetireAllCryptoAddressesByUserIdAndCoinParams struct {
+	UserID pgtype.UUID
+	Coin   CoinType
+}
+
+func (q *Queries) RetireAllCryptoAddressesByUserIdAndCoin(ctx context.Context, arg RetireAllCryptoAddressesByUserIdAndCoinParams) ([]CryptoAddress, error) {
+	rows, err := q.db.Query(ctx, retireAllCryptoAddressesByUserIdAndCoin, arg.UserID, arg.Coin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CryptoAddress
+	for rows.Next() {
+		var i CryptoAddress
+		if err := rows.Scan(
+			&i.ID,
+			&i.Address,
+			&i.Coin,
+			&i.IsOccupied,
+			&i.UserID,
+			&i.Memo,
+			&i.DerivationSeq,
+			&i.KeyVersion,
+			&i.DerivationPath,
+			&i.IsRetired,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reviveRetiredCryptoAddress = `-- name: ReviveRetiredCryptoAddress :one
+UPDATE crypto_addresses AS ca
+SET is_occupied = $1,
+    is_retired = false,
+    derivation_path = $2,
+    key_version = (SELECT MAX(kv.version) FROM crypto_key_versions AS kv WHERE kv.user_id = $3 AND kv.coin = $4),
+    derivation_seq = nextval(pg_get_serial_sequence('crypto_addresses', 'derivation_seq'))
+WHERE ca.address = $5 AND ca.memo IS NOT DISTINCT FROM $6 AND ca.user_id = $3 AND ca.coin = $4
+    AND ca.is_retired AND NOT ca.is_occupied
+RETURNING id, address, coin, is_occupied, user_id, memo, derivation_seq, key_version, derivation_path, is_retired
+`
+
+type ReviveRetiredCryptoAddressParams struct {
+	IsOccupied     bool
+	DerivationPath pgtype.Text
+	UserID         pgtype.UUID
+	Coin           CoinType
+	Address        string
+	Memo           pgtype.Text
+}
+
+// An address derived again from a re-uploaded key takes over its retired row, as the address is unique.
+func (q *Queries) ReviveRetiredCryptoAddress(ctx context.Context, arg ReviveRetiredCryptoAddressParams) (CryptoAddress, error) {
+	row := q.db.QueryRow(ctx, reviveRetiredCryptoAddress,
+		arg.IsOccupied,
+		arg.DerivationPath,
+		arg.UserID,
+		arg.Coin,
+		arg.Address,
+		arg.Memo,
+	)
+	var i CryptoAddress
+	err := row.Scan(
+		&i.ID,
+		&i.Address,
+		&i.Coin,
+		&i.IsOccupied,
+		&i.UserID,
+		&i.Memo,
+		&i.DerivationSeq,
+		&i.KeyVersion,
+		&i.DerivationPath,
+		&i.IsRetired,
 	)
 	return i, err
 }
@@ -110,17 +250,18 @@ func (q *Queries) FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx context
 const updateIsOccupiedByCryptoAddress = `-- name: UpdateIsOccupiedByCryptoAddress :one
 UPDATE crypto_addresses 
 SET is_occupied = $2
-WHERE address = $1
-RETURNING id, address, coin, is_occupied, user_id
+WHERE address = $1 AND memo IS NOT DISTINCT FROM $3
+RETURNING id, address, coin, is_occupied, user_id, memo, derivation_seq, key_version, derivation_path, is_retired
 `
 
 type UpdateIsOccupiedByCryptoAddressParams struct {
 	Address    string
 	IsOccupied bool
+	Memo       pgtype.Text
 }
 
 func (q *Queries) UpdateIsOccupiedByCryptoAddress(ctx context.Context, arg UpdateIsOccupiedByCryptoAddressParams) (CryptoAddress, error) {
-	row := q.db.QueryRow(ctx, updateIsOccupiedByCryptoAddress, arg.Address, arg.IsOccupied)
+	row := q.db.QueryRow(ctx, updateIsOccupiedByCryptoAddress, arg.Address, arg.IsOccupied, arg.Memo)
 	var i CryptoAddress
 	err := row.Scan(
 		&i.ID,
@@ -128,6 +269,11 @@ func (q *Queries) UpdateIsOccupiedByCryptoAddress(ctx context.Context, arg Updat
 		&i.Coin,
 		&i.IsOccupied,
 		&i.UserID,
+		&i.Memo,
+		&i.DerivationSeq,
+		&i.KeyVersion,
+		&i.DerivationPath,
+		&i.IsRetired,
 	)
 	return i, err
 }
@@ -11,6 +11,68 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createCryptoBlockHashes = `-- name: CreateCryptoBlockHashes :exec
+INSERT INTO crypto_block_hashes(coin, height, hash)
+SELECT $1::coin_type, unnest($2::BIGINT[]), unnest($3::TEXT[])
+`
+
+type CreateCryptoBlockHashesParams struct {
+	Coin    CoinType
+	Heights []int64
+	Hashes  []string
+}
+
+func (q *Queries) CreateCryptoBlockHashes(ctx context.Context, arg CreateCryptoBlockHashesParams) error {
+	_, err := q.db.Exec(ctx, createCryptoBlockHashes, arg.Coin, arg.Heights, arg.Hashes)
+	return err
+}
+
+const createCryptoCacheIfNotExists = `-- name: CreateCryptoCacheIfNotExists :exec
+INSERT INTO crypto_cache(coin) VALUES ($1)
+ON CONFLICT DO NOTHING
+`
+
+func (q *Queries) CreateCryptoCacheIfNotExists(ctx context.Context, coin CoinType) error {
+	_, err := q.db.Exec(ctx, createCryptoCacheIfNotExists, coin)
+	return err
+}
+
+const deleteCryptoBlockHashesByCoin = `-- name: DeleteCryptoBlockHashesByCoin :exec
+DELETE FROM crypto_block_hashes
+WHERE coin = $1
+`
+
+func (q *Queries) DeleteCryptoBlockHashesByCoin(ctx context.Context, coin CoinType) error {
+	_, err := q.db.Exec(ctx, deleteCryptoBlockHashesByCoin, coin)
+	return err
+}
+
+const findCryptoBlockHashesByCoin = `-- name: FindCryptoBlockHashesByCoin :many
+SELECT coin, height, hash FROM crypto_block_hashes
+WHERE coin = $1
+ORDER BY height
+`
+
+func (q *Queries) FindCryptoBlockHashesByCoin(ctx context.Context, coin CoinType) ([]CryptoBlockHash, error) {
+	rows, err := q.db.Query(ctx, findCryptoBlockHashesByCoin, coin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CryptoBlockHash
+	for rows.Next() {
+		var i CryptoBlockHash
+		if err := rows.Scan(&i.Coin, &i.Height, &i.Hash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findCryptoCacheByCoin = `-- name: FindCryptoCacheByCoin :one
 SELECT coin, last_synced_block_height, synced_timestamp FROM crypto_cache
 WHERE coin = $1
@@ -31,7 +31,7 @@ func (q *Queries) CreateBNBCryptoData(ctx context.Context, masterPubKey string)
 
 const createBTCCryptoData = `-- name: CreateBTCCryptoData :one
 INSERT INTO btc_crypto_data(master_pub_key) VALUES ($1)
-RETURNING id, master_pub_key, last_major_index, last_minor_index
+RETURNING id, master_pub_key, last_major_index, last_minor_index, address_type
 `
 
 // BTC
@@ -43,13 +43,14 @@ func (q *Queries) CreateBTCCryptoData(ctx context.Context, masterPubKey string)
 		&i.MasterPubKey,
 		&i.LastMajorIndex,
 		&i.LastMinorIndex,
+		&i.AddressType,
 	)
 	return i, err
 }
 
 const createCryptoData = `-- name: CreateCryptoData :one
 INSERT INTO crypto_data(xmr_id, btc_id, ltc_id, eth_id, bnb_id, user_id) VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id
+RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy
 `
 
 type CreateCryptoDataParams struct {
@@ -78,6 +79,9 @@ func (q *Queries) CreateCryptoData(ctx context.Context, arg CreateCryptoDataPara
 		&i.LtcID,
 		&i.EthID,
 		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
 	)
 	return i, err
 }
@@ -102,13 +106,45 @@ func (q *Queries) CreateETHCryptoData(ctx context.Context, masterPubKey string)
 
 const createLTCCryptoData = `-- name: CreateLTCCryptoData :one
 INSERT INTO ltc_crypto_data(master_pub_key) VALUES ($1)
-RETURNING id, master_pub_key, last_major_index, last_minor_index
+RETURNING id, master_pub_key, last_major_index, last_minor_index, address_type
 `
 
 // LTC
 func (q *Queries) CreateLTCCryptoData(ctx context.Context, masterPubKey string) (LtcCryptoDatum, error) {
 	row := q.db.QueryRow(ctx, createLTCCryptoData, masterPubKey)
 	var i LtcCryptoDatum
+	err := row.Scan(
+		&i.ID,
+		&i.MasterPubKey,
+		&i.LastMajorIndex,
+		&i.LastMinorIndex,
+		&i.AddressType,
+	)
+	return i, err
+}
+
+const createTONCryptoData = `-- name: CreateTONCryptoData :one
+INSERT INTO ton_crypto_data(wallet_address) VALUES ($1)
+RETURNING id, wallet_address, last_memo_index
+`
+
+// TON
+func (q *Queries) CreateTONCryptoData(ctx context.Context, walletAddress string) (TonCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, createTONCryptoData, walletAddress)
+	var i TonCryptoDatum
+	err := row.Scan(&i.ID, &i.WalletAddress, &i.LastMemoIndex)
+	return i, err
+}
+
+const createTRXCryptoData = `-- name: CreateTRXCryptoData :one
+INSERT INTO trx_crypto_data(master_pub_key) VALUES ($1)
+RETURNING id, master_pub_key, last_major_index, last_minor_index
+`
+
+// TRX
+func (q *Queries) CreateTRXCryptoData(ctx context.Context, masterPubKey string) (TrxCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, createTRXCryptoData, masterPubKey)
+	var i TrxCryptoDatum
 	err := row.Scan(
 		&i.ID,
 		&i.MasterPubKey,
@@ -143,7 +179,7 @@ func (q *Queries) CreateXMRCryptoData(ctx context.Context, arg CreateXMRCryptoDa
 }
 
 const findCryptoDataByUserId = `-- name: FindCryptoDataByUserId :one
-SELECT user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id FROM crypto_data 
+SELECT user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy FROM crypto_data 
 WHERE user_id = $1
 `
 
@@ -157,6 +193,9 @@ func (q *Queries) FindCryptoDataByUserId(ctx context.Context, userID pgtype.UUID
 		&i.LtcID,
 		&i.EthID,
 		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
 	)
 	return i, err
 }
@@ -218,6 +257,30 @@ func (q *Queries) FindIndicesAndLockETHCryptoDataById(ctx context.Context, id pg
 	return i, err
 }
 
+const findIndicesAndLockEVMCryptoDataByUserIdAndChain = `-- name: FindIndicesAndLockEVMCryptoDataByUserIdAndChain :one
+SELECT last_major_index, last_minor_index 
+FROM evm_crypto_data
+WHERE user_id = $1 AND chain = $2
+FOR UPDATE
+`
+
+type FindIndicesAndLockEVMCryptoDataByUserIdAndChainParams struct {
+	UserID pgtype.UUID
+	Chain  string
+}
+
+type FindIndicesAndLockEVMCryptoDataByUserIdAndChainRow struct {
+	LastMajorIndex int32
+	LastMinorIndex int32
+}
+
+func (q *Queries) FindIndicesAndLockEVMCryptoDataByUserIdAndChain(ctx context.Context, arg FindIndicesAndLockEVMCryptoDataByUserIdAndChainParams) (FindIndicesAndLockEVMCryptoDataByUserIdAndChainRow, error) {
+	row := q.db.QueryRow(ctx, findIndicesAndLockEVMCryptoDataByUserIdAndChain, arg.UserID, arg.Chain)
+	var i FindIndicesAndLockEVMCryptoDataByUserIdAndChainRow
+	err := row.Scan(&i.LastMajorIndex, &i.LastMinorIndex)
+	return i, err
+}
+
 const findIndicesAndLockLTCCryptoDataById = `-- name: FindIndicesAndLockLTCCryptoDataById :one
 SELECT last_major_index, last_minor_index 
 FROM ltc_crypto_data
@@ -237,6 +300,25 @@ func (q *Queries) FindIndicesAndLockLTCCryptoDataById(ctx context.Context, id pg
 	return i, err
 }
 
+const findIndicesAndLockTRXCryptoDataById = `-- name: FindIndicesAndLockTRXCryptoDataById :one
+SELECT last_major_index, last_minor_index 
+FROM trx_crypto_data
+WHERE id = $1
+FOR UPDATE
+`
+
+type FindIndicesAndLockTRXCryptoDataByIdRow struct {
+	LastMajorIndex int32
+	LastMinorIndex int32
+}
+
+func (q *Queries) FindIndicesAndLockTRXCryptoDataById(ctx context.Context, id pgtype.UUID) (FindIndicesAndLockTRXCryptoDataByIdRow, error) {
+	row := q.db.QueryRow(ctx, findIndicesAndLockTRXCryptoDataById, id)
+	var i FindIndicesAndLockTRXCryptoDataByIdRow
+	err := row.Scan(&i.LastMajorIndex, &i.LastMinorIndex)
+	return i, err
+}
+
 const findIndicesAndLockXMRCryptoDataById = `-- name: FindIndicesAndLockXMRCryptoDataById :one
 SELECT last_major_index, last_minor_index 
 FROM xmr_crypto_data
@@ -271,17 +353,22 @@ func (q *Queries) FindKeysAndLockBNBCryptoDataById(ctx context.Context, id pgtyp
 }
 
 const findKeysAndLockBTCCryptoDataById = `-- name: FindKeysAndLockBTCCryptoDataById :one
-SELECT master_pub_key
+SELECT master_pub_key, address_type
 FROM btc_crypto_data
 WHERE id = $1
 FOR SHARE
 `
 
-func (q *Queries) FindKeysAndLockBTCCryptoDataById(ctx context.Context, id pgtype.UUID) (string, error) {
+type FindKeysAndLockBTCCryptoDataByIdRow struct {
+	MasterPubKey string
+	AddressType  AddressType
+}
+
+func (q *Queries) FindKeysAndLockBTCCryptoDataById(ctx context.Context, id pgtype.UUID) (FindKeysAndLockBTCCryptoDataByIdRow, error) {
 	row := q.db.QueryRow(ctx, findKeysAndLockBTCCryptoDataById, id)
-	var master_pub_key string
-	err := row.Scan(&master_pub_key)
-	return master_pub_key, err
+	var i FindKeysAndLockBTCCryptoDataByIdRow
+	err := row.Scan(&i.MasterPubKey, &i.AddressType)
+	return i, err
 }
 
 const findKeysAndLockETHCryptoDataById = `-- name: FindKeysAndLockETHCryptoDataById :one
@@ -298,15 +385,53 @@ func (q *Queries) FindKeysAndLockETHCryptoDataById(ctx context.Context, id pgtyp
 	return master_pub_key, err
 }
 
-const findKeysAndLockLTCCryptoDataById = `-- name: FindKeysAndLockLTCCryptoDataById :one
+const findKeysAndLockEVMCryptoDataByUserIdAndChain = `-- name: FindKeysAndLockEVMCryptoDataByUserIdAndChain :one
 SELECT master_pub_key
+FROM evm_crypto_data
+WHERE user_id = $1 AND chain = $2
+FOR SHARE
+`
+
+type FindKeysAndLockEVMCryptoDataByUserIdAndChainParams struct {
+	UserID pgtype.UUID
+	Chain  string
+}
+
+func (q *Queries) FindKeysAndLockEVMCryptoDataByUserIdAndChain(ctx context.Context, arg FindKeysAndLockEVMCryptoDataByUserIdAndChainParams) (string, error) {
+	row := q.db.QueryRow(ctx, findKeysAndLockEVMCryptoDataByUserIdAndChain, arg.UserID, arg.Chain)
+	var master_pub_key string
+	err := row.Scan(&master_pub_key)
+	return master_pub_key, err
+}
+
+const findKeysAndLockLTCCryptoDataById = `-- name: FindKeysAndLockLTCCryptoDataById :one
+SELECT master_pub_key, address_type
 FROM ltc_crypto_data
 WHERE id = $1
 FOR SHARE
 `
 
-func (q *Queries) FindKeysAndLockLTCCryptoDataById(ctx context.Context, id pgtype.UUID) (string, error) {
+type FindKeysAndLockLTCCryptoDataByIdRow struct {
+	MasterPubKey string
+	AddressType  AddressType
+}
+
+func (q *Queries) FindKeysAndLockLTCCryptoDataById(ctx context.Context, id pgtype.UUID) (FindKeysAndLockLTCCryptoDataByIdRow, error) {
 	row := q.db.QueryRow(ctx, findKeysAndLockLTCCryptoDataById, id)
+	var i FindKeysAndLockLTCCryptoDataByIdRow
+	err := row.Scan(&i.MasterPubKey, &i.AddressType)
+	return i, err
+}
+
+const findKeysAndLockTRXCryptoDataById = `-- name: FindKeysAndLockTRXCryptoDataById :one
+SELECT master_pub_key
+FROM trx_crypto_data
+WHERE id = $1
+FOR SHARE
+`
+
+func (q *Queries) FindKeysAndLockTRXCryptoDataById(ctx context.Context, id pgtype.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, findKeysAndLockTRXCryptoDataById, id)
 	var master_pub_key string
 	err := row.Scan(&master_pub_key)
 	return master_pub_key, err
@@ -331,11 +456,30 @@ func (q *Queries) FindKeysAndLockXMRCryptoDataById(ctx context.Context, id pgtyp
 	return i, err
 }
 
+const findWalletAndLockTONCryptoDataById = `-- name: FindWalletAndLockTONCryptoDataById :one
+SELECT wallet_address, last_memo_index
+FROM ton_crypto_data
+WHERE id = $1
+FOR UPDATE
+`
+
+type FindWalletAndLockTONCryptoDataByIdRow struct {
+	WalletAddress string
+	LastMemoIndex int32
+}
+
+func (q *Queries) FindWalletAndLockTONCryptoDataById(ctx context.Context, id pgtype.UUID) (FindWalletAndLockTONCryptoDataByIdRow, error) {
+	row := q.db.QueryRow(ctx, findWalletAndLockTONCryptoDataById, id)
+	var i FindWalletAndLockTONCryptoDataByIdRow
+	err := row.Scan(&i.WalletAddress, &i.LastMemoIndex)
+	return i, err
+}
+
 const setBNBCryptoDataByUserId = `-- name: SetBNBCryptoDataByUserId :one
 UPDATE crypto_data
 SET bnb_id = $2
 WHERE user_id = $1
-RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id
+RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy
 `
 
 type SetBNBCryptoDataByUserIdParams struct {
@@ -353,6 +497,9 @@ func (q *Queries) SetBNBCryptoDataByUserId(ctx context.Context, arg SetBNBCrypto
 		&i.LtcID,
 		&i.EthID,
 		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
 	)
 	return i, err
 }
@@ -361,7 +508,7 @@ const setBTCCryptoDataByUserId = `-- name: SetBTCCryptoDataByUserId :one
 UPDATE crypto_data
 SET btc_id = $2
 WHERE user_id = $1
-RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id
+RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy
 `
 
 type SetBTCCryptoDataByUserIdParams struct {
@@ -379,6 +526,9 @@ func (q *Queries) SetBTCCryptoDataByUserId(ctx context.Context, arg SetBTCCrypto
 		&i.LtcID,
 		&i.EthID,
 		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
 	)
 	return i, err
 }
@@ -387,7 +537,7 @@ const setETHCryptoDataByUserId = `-- name: SetETHCryptoDataByUserId :one
 UPDATE crypto_data
 SET eth_id = $2
 WHERE user_id = $1
-RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id
+RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy
 `
 
 type SetETHCryptoDataByUserIdParams struct {
@@ -405,6 +555,9 @@ func (q *Queries) SetETHCryptoDataByUserId(ctx context.Context, arg SetETHCrypto
 		&i.LtcID,
 		&i.EthID,
 		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
 	)
 	return i, err
 }
@@ -413,7 +566,7 @@ const setLTCCryptoDataByUserId = `-- name: SetLTCCryptoDataByUserId :one
 UPDATE crypto_data
 SET ltc_id = $2
 WHERE user_id = $1
-RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id
+RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy
 `
 
 type SetLTCCryptoDataByUserIdParams struct {
@@ -431,6 +584,67 @@ func (q *Queries) SetLTCCryptoDataByUserId(ctx context.Context, arg SetLTCCrypto
 		&i.LtcID,
 		&i.EthID,
 		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
+	)
+	return i, err
+}
+
+const setTONCryptoDataByUserId = `-- name: SetTONCryptoDataByUserId :one
+UPDATE crypto_data
+SET ton_id = $2
+WHERE user_id = $1
+RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy
+`
+
+type SetTONCryptoDataByUserIdParams struct {
+	UserID pgtype.UUID
+	TonID  pgtype.UUID
+}
+
+func (q *Queries) SetTONCryptoDataByUserId(ctx context.Context, arg SetTONCryptoDataByUserIdParams) (CryptoDatum, error) {
+	row := q.db.QueryRow(ctx, setTONCryptoDataByUserId, arg.UserID, arg.TonID)
+	var i CryptoDatum
+	err := row.Scan(
+		&i.UserID,
+		&i.XmrID,
+		&i.BtcID,
+		&i.LtcID,
+		&i.EthID,
+		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
+	)
+	return i, err
+}
+
+const setTRXCryptoDataByUserId = `-- name: SetTRXCryptoDataByUserId :one
+UPDATE crypto_data
+SET trx_id = $2
+WHERE user_id = $1
+RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy
+`
+
+type SetTRXCryptoDataByUserIdParams struct {
+	UserID pgtype.UUID
+	TrxID  pgtype.UUID
+}
+
+func (q *Queries) SetTRXCryptoDataByUserId(ctx context.Context, arg SetTRXCryptoDataByUserIdParams) (CryptoDatum, error) {
+	row := q.db.QueryRow(ctx, setTRXCryptoDataByUserId, arg.UserID, arg.TrxID)
+	var i CryptoDatum
+	err := row.Scan(
+		&i.UserID,
+		&i.XmrID,
+		&i.BtcID,
+		&i.LtcID,
+		&i.EthID,
+		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
 	)
 	return i, err
 }
@@ -439,7 +653,7 @@ const setXMRCryptoDataByUserId = `-- name: SetXMRCryptoDataByUserId :one
 UPDATE crypto_data
 SET xmr_id = $2 
 WHERE user_id = $1
-RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id
+RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy
 `
 
 type SetXMRCryptoDataByUserIdParams struct {
@@ -457,6 +671,88 @@ func (q *Queries) SetXMRCryptoDataByUserId(ctx context.Context, arg SetXMRCrypto
 		&i.LtcID,
 		&i.EthID,
 		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
+	)
+	return i, err
+}
+
+const updateAddressReusePolicyCryptoDataByUserId = `-- name: UpdateAddressReusePolicyCryptoDataByUserId :one
+UPDATE crypto_data
+SET address_reuse_policy = $2
+WHERE user_id = $1
+RETURNING user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy
+`
+
+type UpdateAddressReusePolicyCryptoDataByUserIdParams struct {
+	UserID             pgtype.UUID
+	AddressReusePolicy AddressReusePolicy
+}
+
+func (q *Queries) UpdateAddressReusePolicyCryptoDataByUserId(ctx context.Context, arg UpdateAddressReusePolicyCryptoDataByUserIdParams) (CryptoDatum, error) {
+	row := q.db.QueryRow(ctx, updateAddressReusePolicyCryptoDataByUserId, arg.UserID, arg.AddressReusePolicy)
+	var i CryptoDatum
+	err := row.Scan(
+		&i.UserID,
+		&i.XmrID,
+		&i.BtcID,
+		&i.LtcID,
+		&i.EthID,
+		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
+	)
+	return i, err
+}
+
+const updateAddressTypeBTCCryptoDataByUserId = `-- name: UpdateAddressTypeBTCCryptoDataByUserId :one
+UPDATE btc_crypto_data
+SET address_type = $2
+WHERE id = (SELECT btc_id FROM crypto_data WHERE user_id = $1)
+RETURNING id, master_pub_key, last_major_index, last_minor_index, address_type
+`
+
+type UpdateAddressTypeBTCCryptoDataByUserIdParams struct {
+	UserID      pgtype.UUID
+	AddressType AddressType
+}
+
+func (q *Queries) UpdateAddressTypeBTCCryptoDataByUserId(ctx context.Context, arg UpdateAddressTypeBTCCryptoDataByUserIdParams) (BtcCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, updateAddressTypeBTCCryptoDataByUserId, arg.UserID, arg.AddressType)
+	var i BtcCryptoDatum
+	err := row.Scan(
+		&i.ID,
+		&i.MasterPubKey,
+		&i.LastMajorIndex,
+		&i.LastMinorIndex,
+		&i.AddressType,
+	)
+	return i, err
+}
+
+const updateAddressTypeLTCCryptoDataByUserId = `-- name: UpdateAddressTypeLTCCryptoDataByUserId :one
+UPDATE ltc_crypto_data
+SET address_type = $2
+WHERE id = (SELECT ltc_id FROM crypto_data WHERE user_id = $1)
+RETURNING id, master_pub_key, last_major_index, last_minor_index, address_type
+`
+
+type UpdateAddressTypeLTCCryptoDataByUserIdParams struct {
+	UserID      pgtype.UUID
+	AddressType AddressType
+}
+
+func (q *Queries) UpdateAddressTypeLTCCryptoDataByUserId(ctx context.Context, arg UpdateAddressTypeLTCCryptoDataByUserIdParams) (LtcCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, updateAddressTypeLTCCryptoDataByUserId, arg.UserID, arg.AddressType)
+	var i LtcCryptoDatum
+	err := row.Scan(
+		&i.ID,
+		&i.MasterPubKey,
+		&i.LastMajorIndex,
+		&i.LastMinorIndex,
+		&i.AddressType,
 	)
 	return i, err
 }
@@ -492,7 +788,7 @@ UPDATE btc_crypto_data
 SET last_major_index = $2,
     last_minor_index = $3
 WHERE id = $1
-RETURNING id, master_pub_key, last_major_index, last_minor_index
+RETURNING id, master_pub_key, last_major_index, last_minor_index, address_type
 `
 
 type UpdateIndicesBTCCryptoDataByIdParams struct {
@@ -509,6 +805,7 @@ func (q *Queries) UpdateIndicesBTCCryptoDataById(ctx context.Context, arg Update
 		&i.MasterPubKey,
 		&i.LastMajorIndex,
 		&i.LastMinorIndex,
+		&i.AddressType,
 	)
 	return i, err
 }
@@ -539,12 +836,46 @@ func (q *Queries) UpdateIndicesETHCryptoDataById(ctx context.Context, arg Update
 	return i, err
 }
 
+const updateIndicesEVMCryptoDataByUserIdAndChain = `-- name: UpdateIndicesEVMCryptoDataByUserIdAndChain :one
+UPDATE evm_crypto_data
+SET last_major_index = $3,
+    last_minor_index = $4
+WHERE user_id = $1 AND chain = $2
+RETURNING id, user_id, chain, master_pub_key, last_major_index, last_minor_index
+`
+
+type UpdateIndicesEVMCryptoDataByUserIdAndChainParams struct {
+	UserID         pgtype.UUID
+	Chain          string
+	LastMajorIndex int32
+	LastMinorIndex int32
+}
+
+func (q *Queries) UpdateIndicesEVMCryptoDataByUserIdAndChain(ctx context.Context, arg UpdateIndicesEVMCryptoDataByUserIdAndChainParams) (EvmCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, updateIndicesEVMCryptoDataByUserIdAndChain,
+		arg.UserID,
+		arg.Chain,
+		arg.LastMajorIndex,
+		arg.LastMinorIndex,
+	)
+	var i EvmCryptoDatum
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Chain,
+		&i.MasterPubKey,
+		&i.LastMajorIndex,
+		&i.LastMinorIndex,
+	)
+	return i, err
+}
+
 const updateIndicesLTCCryptoDataById = `-- name: UpdateIndicesLTCCryptoDataById :one
 UPDATE ltc_crypto_data
 SET last_major_index = $2,
     last_minor_index = $3
 WHERE id = $1
-RETURNING id, master_pub_key, last_major_index, last_minor_index
+RETURNING id, master_pub_key, last_major_index, last_minor_index, address_type
 `
 
 type UpdateIndicesLTCCryptoDataByIdParams struct {
@@ -556,6 +887,33 @@ type UpdateIndicesLTCCryptoDataByIdParams struct {
 func (q *Queries) UpdateIndicesLTCCryptoDataById(ctx context.Context, arg UpdateIndicesLTCCryptoDataByIdParams) (LtcCryptoDatum, error) {
 	row := q.db.QueryRow(ctx, updateIndicesLTCCryptoDataById, arg.ID, arg.LastMajorIndex, arg.LastMinorIndex)
 	var i LtcCryptoDatum
+	err := row.Scan(
+		&i.ID,
+		&i.MasterPubKey,
+		&i.LastMajorIndex,
+		&i.LastMinorIndex,
+		&i.AddressType,
+	)
+	return i, err
+}
+
+const updateIndicesTRXCryptoDataById = `-- name: UpdateIndicesTRXCryptoDataById :one
+UPDATE trx_crypto_data
+SET last_major_index = $2,
+    last_minor_index = $3
+WHERE id = $1
+RETURNING id, master_pub_key, last_major_index, last_minor_index
+`
+
+type UpdateIndicesTRXCryptoDataByIdParams struct {
+	ID             pgtype.UUID
+	LastMajorIndex int32
+	LastMinorIndex int32
+}
+
+func (q *Queries) UpdateIndicesTRXCryptoDataById(ctx context.Context, arg UpdateIndicesTRXCryptoDataByIdParams) (TrxCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, updateIndicesTRXCryptoDataById, arg.ID, arg.LastMajorIndex, arg.LastMinorIndex)
+	var i TrxCryptoDatum
 	err := row.Scan(
 		&i.ID,
 		&i.MasterPubKey,
@@ -624,7 +982,7 @@ SET master_pub_key = $2,
     last_major_index = 0,
     last_minor_index = 0
 WHERE id = $1
-RETURNING id, master_pub_key, last_major_index, last_minor_index
+RETURNING id, master_pub_key, last_major_index, last_minor_index, address_type
 `
 
 type UpdateKeysBTCCryptoDataByIdParams struct {
@@ -640,6 +998,7 @@ func (q *Queries) UpdateKeysBTCCryptoDataById(ctx context.Context, arg UpdateKey
 		&i.MasterPubKey,
 		&i.LastMajorIndex,
 		&i.LastMinorIndex,
+		&i.AddressType,
 	)
 	return i, err
 }
@@ -676,7 +1035,7 @@ SET master_pub_key = $2,
     last_major_index = 0,
     last_minor_index = 0
 WHERE id = $1
-RETURNING id, master_pub_key, last_major_index, last_minor_index
+RETURNING id, master_pub_key, last_major_index, last_minor_index, address_type
 `
 
 type UpdateKeysLTCCryptoDataByIdParams struct {
@@ -687,6 +1046,33 @@ type UpdateKeysLTCCryptoDataByIdParams struct {
 func (q *Queries) UpdateKeysLTCCryptoDataById(ctx context.Context, arg UpdateKeysLTCCryptoDataByIdParams) (LtcCryptoDatum, error) {
 	row := q.db.QueryRow(ctx, updateKeysLTCCryptoDataById, arg.ID, arg.MasterPubKey)
 	var i LtcCryptoDatum
+	err := row.Scan(
+		&i.ID,
+		&i.MasterPubKey,
+		&i.LastMajorIndex,
+		&i.LastMinorIndex,
+		&i.AddressType,
+	)
+	return i, err
+}
+
+const updateKeysTRXCryptoDataById = `-- name: UpdateKeysTRXCryptoDataById :one
+UPDATE trx_crypto_data
+SET master_pub_key = $2,
+    last_major_index = 0,
+    last_minor_index = 0
+WHERE id = $1
+RETURNING id, master_pub_key, last_major_index, last_minor_index
+`
+
+type UpdateKeysTRXCryptoDataByIdParams struct {
+	ID           pgtype.UUID
+	MasterPubKey string
+}
+
+func (q *Queries) UpdateKeysTRXCryptoDataById(ctx context.Context, arg UpdateKeysTRXCryptoDataByIdParams) (TrxCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, updateKeysTRXCryptoDataById, arg.ID, arg.MasterPubKey)
+	var i TrxCryptoDatum
 	err := row.Scan(
 		&i.ID,
 		&i.MasterPubKey,
@@ -724,3 +1110,72 @@ func (q *Queries) UpdateKeysXMRCryptoDataById(ctx context.Context, arg UpdateKey
 	)
 	return i, err
 }
+
+const updateMemoIndexTONCryptoDataById = `-- name: UpdateMemoIndexTONCryptoDataById :one
+UPDATE ton_crypto_data
+SET last_memo_index = $2
+WHERE id = $1
+RETURNING id, wallet_address, last_memo_index
+`
+
+type UpdateMemoIndexTONCryptoDataByIdParams struct {
+	ID            pgtype.UUID
+	LastMemoIndex int32
+}
+
+func (q *Queries) UpdateMemoIndexTONCryptoDataById(ctx context.Context, arg UpdateMemoIndexTONCryptoDataByIdParams) (TonCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, updateMemoIndexTONCryptoDataById, arg.ID, arg.LastMemoIndex)
+	var i TonCryptoDatum
+	err := row.Scan(&i.ID, &i.WalletAddress, &i.LastMemoIndex)
+	return i, err
+}
+
+const updateWalletTONCryptoDataById = `-- name: UpdateWalletTONCryptoDataById :one
+UPDATE ton_crypto_data
+SET wallet_address = $2
+WHERE id = $1
+RETURNING id, wallet_address, last_memo_index
+`
+
+type UpdateWalletTONCryptoDataByIdParams struct {
+	ID            pgtype.UUID
+	WalletAddress string
+}
+
+// The memo index isn't reset, so the memos of the invoices still pending on the previous wallet are never reused.
+func (q *Queries) UpdateWalletTONCryptoDataById(ctx context.Context, arg UpdateWalletTONCryptoDataByIdParams) (TonCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, updateWalletTONCryptoDataById, arg.ID, arg.WalletAddress)
+	var i TonCryptoDatum
+	err := row.Scan(&i.ID, &i.WalletAddress, &i.LastMemoIndex)
+	return i, err
+}
+
+const upsertKeysEVMCryptoData = `-- name: UpsertKeysEVMCryptoData :one
+INSERT INTO evm_crypto_data(user_id, chain, master_pub_key) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, chain) DO UPDATE
+SET master_pub_key = EXCLUDED.master_pub_key,
+    last_major_index = 0,
+    last_minor_index = 0
+RETURNING id, user_id, chain, master_pub_key, last_major_index, last_minor_index
+`
+
+type UpsertKeysEVMCryptoDataParams struct {
+	UserID       pgtype.UUID
+	Chain        string
+	MasterPubKey string
+}
+
+// EVM
+func (q *Queries) UpsertKeysEVMCryptoData(ctx context.Context, arg UpsertKeysEVMCryptoDataParams) (EvmCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, upsertKeysEVMCryptoData, arg.UserID, arg.Chain, arg.MasterPubKey)
+	var i EvmCryptoDatum
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Chain,
+		&i.MasterPubKey,
+		&i.LastMajorIndex,
+		&i.LastMinorIndex,
+	)
+	return i, err
+}
@@ -0,0 +1,106 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: crypto_key_version.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createCryptoKeyVersion = `-- name: CreateCryptoKeyVersion :one
+WITH retired AS (
+    UPDATE crypto_key_versions SET retired_at = timezone('UTC', now())
+    WHERE user_id = $1 AND coin = $2 AND retired_at IS NULL
+)
+INSERT INTO crypto_key_versions(user_id, coin, version, public_key, priv_view_key)
+VALUES ($1, $2, COALESCE((SELECT MAX(kv.version) FROM crypto_key_versions AS kv WHERE kv.user_id = $1 AND kv.coin = $2), 0) + 1, $3, $4)
+RETURNING id, user_id, coin, version, public_key, priv_view_key, created_at, retired_at
+`
+
+type CreateCryptoKeyVersionParams struct {
+	UserID      pgtype.UUID
+	Coin        CoinType
+	PublicKey   string
+	PrivViewKey pgtype.Text
+}
+
+// Retires the current version of the key of the coin, if any, in favour of the new one.
+func (q *Queries) CreateCryptoKeyVersion(ctx context.Context, arg CreateCryptoKeyVersionParams) (CryptoKeyVersion, error) {
+	row := q.db.QueryRow(ctx, createCryptoKeyVersion,
+		arg.UserID,
+		arg.Coin,
+		arg.PublicKey,
+		arg.PrivViewKey,
+	)
+	var i CryptoKeyVersion
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Coin,
+		&i.Version,
+		&i.PublicKey,
+		&i.PrivViewKey,
+		&i.CreatedAt,
+		&i.RetiredAt,
+	)
+	return i, err
+}
+
+const findCryptoKeyVersionsByUserIdAndCoin = `-- name: FindCryptoKeyVersionsByUserIdAndCoin :many
+SELECT id, user_id, coin, version, public_key, priv_view_key, created_at, retired_at FROM crypto_key_versions
+WHERE user_id = $1 AND coin = $2
+ORDER BY version DESC
+`
+
+type FindCryptoKeyVersionsByUserIdAndCoinParams struct {
+	UserID pgtype.UUID
+	Coin   CoinType
+}
+
+func (q *Queries) FindCryptoKeyVersionsByUserIdAndCoin(ctx context.Context, arg FindCryptoKeyVersionsByUserIdAndCoinParams) ([]CryptoKeyVersion, error) {
+	rows, err := q.db.Query(ctx, findCryptoKeyVersionsByUserIdAndCoin, arg.UserID, arg.Coin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CryptoKeyVersion
+	for rows.Next() {
+		var i CryptoKeyVersion
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Coin,
+			&i.Version,
+			&i.PublicKey,
+			&i.PrivViewKey,
+			&i.CreatedAt,
+			&i.RetiredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findXMRPrivViewKeyByCryptoAddress = `-- name: FindXMRPrivViewKeyByCryptoAddress :one
+SELECT kv.priv_view_key::TEXT
+FROM crypto_addresses AS ca
+JOIN crypto_key_versions AS kv ON kv.user_id = ca.user_id AND kv.coin = ca.coin AND kv.version = ca.key_version
+WHERE ca.address = $1 AND ca.coin = 'XMR' AND kv.priv_view_key IS NOT NULL
+`
+
+// The view key of the version the subaddress has been derived from, which might have been retired since.
+func (q *Queries) FindXMRPrivViewKeyByCryptoAddress(ctx context.Context, address string) (string, error) {
+	row := q.db.QueryRow(ctx, findXMRPrivViewKeyByCryptoAddress, address)
+	var kv_priv_view_key string
+	err := row.Scan(&kv_priv_view_key)
+	return kv_priv_view_key, err
+}
@@ -11,12 +11,130 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelGroupSiblingInvoiceById = `-- name: CancelGroupSiblingInvoiceById :one
+UPDATE invoices
+SET status = 'CANCELLED'
+WHERE id = $1 AND status IN ('PENDING', 'PARTIALLY_PAID')
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
+`
+
+// A partially paid sibling is cancelled as well, once its group has been paid through another coin.
+func (q *Queries) CancelGroupSiblingInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
+	row := q.db.QueryRow(ctx, cancelGroupSiblingInvoiceById, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
+
+const cancelInvoiceById = `-- name: CancelInvoiceById :one
+UPDATE invoices
+SET status = 'CANCELLED'
+WHERE id = $1 AND status = 'PENDING'
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
+`
+
+func (q *Queries) CancelInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
+	row := q.db.QueryRow(ctx, cancelInvoiceById, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
+
+const clearIdempotencyKeyById = `-- name: ClearIdempotencyKeyById :one
+UPDATE invoices
+SET idempotency_key = NULL, idempotency_request_hash = NULL
+WHERE id = $1
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
+`
+
+func (q *Queries) ClearIdempotencyKeyById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
+	row := q.db.QueryRow(ctx, clearIdempotencyKeyById, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
+
 const confirmInvoiceById = `-- name: ConfirmInvoiceById :one
 UPDATE invoices
 SET status = 'CONFIRMED',
     confirmed_at = timezone('UTC', now())
 WHERE id = $1
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
 `
 
 func (q *Queries) ConfirmInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
@@ -35,6 +153,18 @@ func (q *Queries) ConfirmInvoiceById(ctx context.Context, id pgtype.UUID) (Invoi
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
 	)
 	return i, err
 }
@@ -45,12 +175,12 @@ SET actual_amount = $2,
     status = 'PENDING_MEMPOOL',
     tx_id = $3
 WHERE id = $1
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
 `
 
 type ConfirmInvoiceStatusMempoolByIdParams struct {
 	ID           pgtype.UUID
-	ActualAmount pgtype.Float8
+	ActualAmount pgtype.Numeric
 	TxID         pgtype.Text
 }
 
@@ -70,6 +200,18 @@ func (q *Queries) ConfirmInvoiceStatusMempoolById(ctx context.Context, arg Confi
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
 	)
 	return i, err
 }
@@ -81,18 +223,40 @@ INSERT INTO invoices(
     required_amount, 
     confirmations_required,
     expires_at,
-    user_id) 
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+    user_id,
+    underpayment_tolerance,
+    fiat_amount,
+    fiat_currency,
+    exchange_rate,
+    idempotency_key,
+    idempotency_request_hash,
+    description,
+    external_order_id,
+    metadata,
+    group_id,
+    memo) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, COALESCE($15::jsonb, '{}'), $16, $17)
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
 `
 
 type CreateInvoiceParams struct {
-	CryptoAddress         string
-	Coin                  CoinType
-	RequiredAmount        float64
-	ConfirmationsRequired int16
-	ExpiresAt             pgtype.Timestamptz
-	UserID                pgtype.UUID
+	CryptoAddress          string
+	Coin                   CoinType
+	RequiredAmount         pgtype.Numeric
+	ConfirmationsRequired  int16
+	ExpiresAt              pgtype.Timestamptz
+	UserID                 pgtype.UUID
+	UnderpaymentTolerance  pgtype.Numeric
+	FiatAmount             pgtype.Float8
+	FiatCurrency           pgtype.Text
+	ExchangeRate           pgtype.Float8
+	IdempotencyKey         pgtype.Text
+	IdempotencyRequestHash pgtype.Text
+	Description            pgtype.Text
+	ExternalOrderID        pgtype.Text
+	Metadata               []byte
+	GroupID                pgtype.UUID
+	Memo                   pgtype.Text
 }
 
 func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error) {
@@ -103,6 +267,17 @@ func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (I
 		arg.ConfirmationsRequired,
 		arg.ExpiresAt,
 		arg.UserID,
+		arg.UnderpaymentTolerance,
+		arg.FiatAmount,
+		arg.FiatCurrency,
+		arg.ExchangeRate,
+		arg.IdempotencyKey,
+		arg.IdempotencyRequestHash,
+		arg.Description,
+		arg.ExternalOrderID,
+		arg.Metadata,
+		arg.GroupID,
+		arg.Memo,
 	)
 	var i Invoice
 	err := row.Scan(
@@ -118,6 +293,18 @@ func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (I
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
 	)
 	return i, err
 }
@@ -125,10 +312,11 @@ func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (I
 const expireInvoiceById = `-- name: ExpireInvoiceById :one
 UPDATE invoices
 SET status = 'EXPIRED'
-WHERE id = $1
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+WHERE id = $1 AND status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL')
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
 `
 
+// A cancellation or confirmation committed before the timeout fired is kept.
 func (q *Queries) ExpireInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
 	row := q.db.QueryRow(ctx, expireInvoiceById, id)
 	var i Invoice
@@ -145,13 +333,81 @@ func (q *Queries) ExpireInvoiceById(ctx context.Context, id pgtype.UUID) (Invoic
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
 	)
 	return i, err
 }
 
+const findAllExpiredInvoicesOccupyingCryptoAddress = `-- name: FindAllExpiredInvoicesOccupyingCryptoAddress :many
+SELECT i.id, i.crypto_address, i.coin, i.required_amount, i.actual_amount, i.confirmations_required, i.created_at, i.confirmed_at, i.status, i.expires_at, i.tx_id, i.user_id, i.underpayment_tolerance, i.fiat_amount, i.fiat_currency, i.exchange_rate, i.idempotency_key, i.idempotency_request_hash, i.description, i.external_order_id, i.metadata, i.group_id, i.confirmations, i.memo FROM invoices i
+JOIN crypto_addresses ca ON ca.address = i.crypto_address AND ca.memo IS NOT DISTINCT FROM i.memo
+WHERE i.status IN ('EXPIRED', 'PAID_AFTER_EXPIRY')
+    AND ca.is_occupied
+    AND NOT EXISTS (
+        SELECT 1 FROM invoices ni
+        WHERE ni.crypto_address = i.crypto_address AND ni.memo IS NOT DISTINCT FROM i.memo AND ni.created_at > i.created_at
+    )
+`
+
+func (q *Queries) FindAllExpiredInvoicesOccupyingCryptoAddress(ctx context.Context) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findAllExpiredInvoicesOccupyingCryptoAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.UnderpaymentTolerance,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.IdempotencyKey,
+			&i.IdempotencyRequestHash,
+			&i.Description,
+			&i.ExternalOrderID,
+			&i.Metadata,
+			&i.GroupID,
+			&i.Confirmations,
+			&i.Memo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findAllPendingInvoices = `-- name: FindAllPendingInvoices :many
-SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id FROM invoices
-WHERE status IN ('PENDING', 'PENDING_MEMPOOL')
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
+WHERE status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL')
 `
 
 func (q *Queries) FindAllPendingInvoices(ctx context.Context) ([]Invoice, error) {
@@ -176,6 +432,325 @@ func (q *Queries) FindAllPendingInvoices(ctx context.Context) ([]Invoice, error)
 			&i.ExpiresAt,
 			&i.TxID,
 			&i.UserID,
+			&i.UnderpaymentTolerance,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.IdempotencyKey,
+			&i.IdempotencyRequestHash,
+			&i.Description,
+			&i.ExternalOrderID,
+			&i.Metadata,
+			&i.GroupID,
+			&i.Confirmations,
+			&i.Memo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllPendingInvoicesWithPaidGroupSibling = `-- name: FindAllPendingInvoicesWithPaidGroupSibling :many
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices AS i
+WHERE i.status IN ('PENDING', 'PARTIALLY_PAID') AND i.group_id IS NOT NULL AND EXISTS (
+    SELECT 1 FROM invoices AS s
+    WHERE s.group_id = i.group_id AND s.id <> i.id AND s.status IN ('PENDING_MEMPOOL', 'CONFIRMED')
+)
+`
+
+func (q *Queries) FindAllPendingInvoicesWithPaidGroupSibling(ctx context.Context) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findAllPendingInvoicesWithPaidGroupSibling)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.UnderpaymentTolerance,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.IdempotencyKey,
+			&i.IdempotencyRequestHash,
+			&i.Description,
+			&i.ExternalOrderID,
+			&i.Metadata,
+			&i.GroupID,
+			&i.Confirmations,
+			&i.Memo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findInvoiceAndLockById = `-- name: FindInvoiceAndLockById :one
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) FindInvoiceAndLockById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
+	row := q.db.QueryRow(ctx, findInvoiceAndLockById, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
+
+const findInvoiceById = `-- name: FindInvoiceById :one
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
+WHERE id = $1
+`
+
+func (q *Queries) FindInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
+	row := q.db.QueryRow(ctx, findInvoiceById, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
+
+const findInvoiceByUserIdAndIdempotencyKey = `-- name: FindInvoiceByUserIdAndIdempotencyKey :one
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
+WHERE user_id = $1 AND idempotency_key = $2
+`
+
+type FindInvoiceByUserIdAndIdempotencyKeyParams struct {
+	UserID         pgtype.UUID
+	IdempotencyKey pgtype.Text
+}
+
+func (q *Queries) FindInvoiceByUserIdAndIdempotencyKey(ctx context.Context, arg FindInvoiceByUserIdAndIdempotencyKeyParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, findInvoiceByUserIdAndIdempotencyKey, arg.UserID, arg.IdempotencyKey)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
+
+const findInvoicesByFilter = `-- name: FindInvoicesByFilter :many
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
+WHERE ($1::uuid IS NULL OR user_id = $1)
+    AND ($2::coin_type IS NULL OR coin = $2)
+    AND ($3::invoice_status_type IS NULL OR status = $3)
+    AND ($4::timestamptz IS NULL OR created_at >= $4)
+    AND ($5::timestamptz IS NULL OR created_at <= $5)
+    AND ($6::timestamptz IS NULL OR expires_at >= $6)
+    AND ($7::timestamptz IS NULL OR expires_at <= $7)
+    AND ($8::text IS NULL OR external_order_id = $8)
+    AND ($9::uuid IS NULL OR group_id = $9)
+ORDER BY created_at DESC, id
+LIMIT $11 OFFSET $10
+`
+
+type FindInvoicesByFilterParams struct {
+	UserID          pgtype.UUID
+	Coin            NullCoinType
+	Status          NullInvoiceStatusType
+	CreatedAtFrom   pgtype.Timestamptz
+	CreatedAtTo     pgtype.Timestamptz
+	ExpiresAtFrom   pgtype.Timestamptz
+	ExpiresAtTo     pgtype.Timestamptz
+	ExternalOrderID pgtype.Text
+	GroupID         pgtype.UUID
+	Offset          int32
+	Limit           int32
+}
+
+func (q *Queries) FindInvoicesByFilter(ctx context.Context, arg FindInvoicesByFilterParams) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findInvoicesByFilter,
+		arg.UserID,
+		arg.Coin,
+		arg.Status,
+		arg.CreatedAtFrom,
+		arg.CreatedAtTo,
+		arg.ExpiresAtFrom,
+		arg.ExpiresAtTo,
+		arg.ExternalOrderID,
+		arg.GroupID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.UnderpaymentTolerance,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.IdempotencyKey,
+			&i.IdempotencyRequestHash,
+			&i.Description,
+			&i.ExternalOrderID,
+			&i.Metadata,
+			&i.GroupID,
+			&i.Confirmations,
+			&i.Memo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findInvoicesByGroupId = `-- name: FindInvoicesByGroupId :many
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
+WHERE group_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) FindInvoicesByGroupId(ctx context.Context, groupID pgtype.UUID) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findInvoicesByGroupId, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.UnderpaymentTolerance,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.IdempotencyKey,
+			&i.IdempotencyRequestHash,
+			&i.Description,
+			&i.ExternalOrderID,
+			&i.Metadata,
+			&i.GroupID,
+			&i.Confirmations,
+			&i.Memo,
 		); err != nil {
 			return nil, err
 		}
@@ -187,11 +762,219 @@ func (q *Queries) FindAllPendingInvoices(ctx context.Context) ([]Invoice, error)
 	return items, nil
 }
 
+const findReorgCandidateInvoicesByCoins = `-- name: FindReorgCandidateInvoicesByCoins :many
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
+WHERE coin::TEXT = ANY($1::TEXT[])
+    AND (status = 'PENDING_MEMPOOL' OR (status = 'CONFIRMED' AND confirmed_at >= $2))
+`
+
+type FindReorgCandidateInvoicesByCoinsParams struct {
+	Coins           []string
+	ConfirmedAtFrom pgtype.Timestamptz
+}
+
+func (q *Queries) FindReorgCandidateInvoicesByCoins(ctx context.Context, arg FindReorgCandidateInvoicesByCoinsParams) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findReorgCandidateInvoicesByCoins, arg.Coins, arg.ConfirmedAtFrom)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.UnderpaymentTolerance,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.IdempotencyKey,
+			&i.IdempotencyRequestHash,
+			&i.Description,
+			&i.ExternalOrderID,
+			&i.Metadata,
+			&i.GroupID,
+			&i.Confirmations,
+			&i.Memo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const partiallyPayInvoiceById = `-- name: PartiallyPayInvoiceById :one
+UPDATE invoices
+SET actual_amount = $2,
+    status = 'PARTIALLY_PAID',
+    tx_id = $3
+WHERE id = $1
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
+`
+
+type PartiallyPayInvoiceByIdParams struct {
+	ID           pgtype.UUID
+	ActualAmount pgtype.Numeric
+	TxID         pgtype.Text
+}
+
+func (q *Queries) PartiallyPayInvoiceById(ctx context.Context, arg PartiallyPayInvoiceByIdParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, partiallyPayInvoiceById, arg.ID, arg.ActualAmount, arg.TxID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
+
+const payInvoiceAfterExpiryById = `-- name: PayInvoiceAfterExpiryById :one
+UPDATE invoices
+SET actual_amount = $2,
+    status = 'PAID_AFTER_EXPIRY',
+    tx_id = $3
+WHERE id = $1
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
+`
+
+type PayInvoiceAfterExpiryByIdParams struct {
+	ID           pgtype.UUID
+	ActualAmount pgtype.Numeric
+	TxID         pgtype.Text
+}
+
+func (q *Queries) PayInvoiceAfterExpiryById(ctx context.Context, arg PayInvoiceAfterExpiryByIdParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, payInvoiceAfterExpiryById, arg.ID, arg.ActualAmount, arg.TxID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
+
+const rollbackInvoiceById = `-- name: RollbackInvoiceById :one
+UPDATE invoices
+SET status = $2,
+    actual_amount = $3,
+    tx_id = $4,
+    confirmed_at = NULL,
+    confirmations = 0,
+    expires_at = GREATEST(expires_at, $5::TIMESTAMPTZ)
+WHERE id = $1
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
+`
+
+type RollbackInvoiceByIdParams struct {
+	ID           pgtype.UUID
+	Status       InvoiceStatusType
+	ActualAmount pgtype.Numeric
+	TxID         pgtype.Text
+	MinExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) RollbackInvoiceById(ctx context.Context, arg RollbackInvoiceByIdParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, rollbackInvoiceById,
+		arg.ID,
+		arg.Status,
+		arg.ActualAmount,
+		arg.TxID,
+		arg.MinExpiresAt,
+	)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
+
 const shiftExpiresAtForNonConfirmedInvoices = `-- name: ShiftExpiresAtForNonConfirmedInvoices :many
 UPDATE invoices
 SET expires_at = timezone('UTC', now()) + INTERVAL '5 minute'
-WHERE status IN ('PENDING', 'PENDING_MEMPOOL') AND (expires_at - timezone('UTC', now()) < INTERVAL '5 minutes')
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+WHERE status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL') AND (expires_at - timezone('UTC', now()) < INTERVAL '5 minutes')
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
 `
 
 func (q *Queries) ShiftExpiresAtForNonConfirmedInvoices(ctx context.Context) ([]Invoice, error) {
@@ -216,6 +999,18 @@ func (q *Queries) ShiftExpiresAtForNonConfirmedInvoices(ctx context.Context) ([]
 			&i.ExpiresAt,
 			&i.TxID,
 			&i.UserID,
+			&i.UnderpaymentTolerance,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.IdempotencyKey,
+			&i.IdempotencyRequestHash,
+			&i.Description,
+			&i.ExternalOrderID,
+			&i.Metadata,
+			&i.GroupID,
+			&i.Confirmations,
+			&i.Memo,
 		); err != nil {
 			return nil, err
 		}
@@ -226,3 +1021,47 @@ func (q *Queries) ShiftExpiresAtForNonConfirmedInvoices(ctx context.Context) ([]
 	}
 	return items, nil
 }
+
+const updateInvoiceConfirmationsById = `-- name: UpdateInvoiceConfirmationsById :one
+UPDATE invoices
+SET confirmations = $2
+WHERE id = $1 AND confirmations < $2
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo
+`
+
+type UpdateInvoiceConfirmationsByIdParams struct {
+	ID            pgtype.UUID
+	Confirmations int32
+}
+
+func (q *Queries) UpdateInvoiceConfirmationsById(ctx context.Context, arg UpdateInvoiceConfirmationsByIdParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, updateInvoiceConfirmationsById, arg.ID, arg.Confirmations)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
+	)
+	return i, err
+}
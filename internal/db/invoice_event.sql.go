@@ -0,0 +1,96 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: invoice_event.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createInvoiceEvent = `-- name: CreateInvoiceEvent :one
+INSERT INTO invoice_events(
+    invoice_id,
+    old_status,
+    new_status,
+    tx_id,
+    amount,
+    confirmations,
+    reorg)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, invoice_id, old_status, new_status, tx_id, confirmations, created_at, reorg, amount
+`
+
+type CreateInvoiceEventParams struct {
+	InvoiceID     pgtype.UUID
+	OldStatus     NullInvoiceStatusType
+	NewStatus     InvoiceStatusType
+	TxID          pgtype.Text
+	Amount        pgtype.Numeric
+	Confirmations pgtype.Int8
+	Reorg         bool
+}
+
+func (q *Queries) CreateInvoiceEvent(ctx context.Context, arg CreateInvoiceEventParams) (InvoiceEvent, error) {
+	row := q.db.QueryRow(ctx, createInvoiceEvent,
+		arg.InvoiceID,
+		arg.OldStatus,
+		arg.NewStatus,
+		arg.TxID,
+		arg.Amount,
+		arg.Confirmations,
+		arg.Reorg,
+	)
+	var i InvoiceEvent
+	err := row.Scan(
+		&i.ID,
+		&i.InvoiceID,
+		&i.OldStatus,
+		&i.NewStatus,
+		&i.TxID,
+		&i.Confirmations,
+		&i.CreatedAt,
+		&i.Reorg,
+		&i.Amount,
+	)
+	return i, err
+}
+
+const findInvoiceEventsByInvoiceId = `-- name: FindInvoiceEventsByInvoiceId :many
+SELECT id, invoice_id, old_status, new_status, tx_id, confirmations, created_at, reorg, amount FROM invoice_events
+WHERE invoice_id = $1
+ORDER BY id
+`
+
+func (q *Queries) FindInvoiceEventsByInvoiceId(ctx context.Context, invoiceID pgtype.UUID) ([]InvoiceEvent, error) {
+	rows, err := q.db.Query(ctx, findInvoiceEventsByInvoiceId, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InvoiceEvent
+	for rows.Next() {
+		var i InvoiceEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.InvoiceID,
+			&i.OldStatus,
+			&i.NewStatus,
+			&i.TxID,
+			&i.Confirmations,
+			&i.CreatedAt,
+			&i.Reorg,
+			&i.Amount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,139 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: invoice_payment.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const confirmInvoicePaymentById = `-- name: ConfirmInvoicePaymentById :one
+UPDATE invoice_payments
+SET confirmed_at = timezone('UTC', now())
+WHERE id = $1
+RETURNING id, invoice_id, tx_id, created_at, confirmed_at, amount
+`
+
+func (q *Queries) ConfirmInvoicePaymentById(ctx context.Context, id pgtype.UUID) (InvoicePayment, error) {
+	row := q.db.QueryRow(ctx, confirmInvoicePaymentById, id)
+	var i InvoicePayment
+	err := row.Scan(
+		&i.ID,
+		&i.InvoiceID,
+		&i.TxID,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Amount,
+	)
+	return i, err
+}
+
+const createInvoicePayment = `-- name: CreateInvoicePayment :one
+INSERT INTO invoice_payments(
+    invoice_id,
+    tx_id,
+    amount)
+VALUES ($1, $2, $3)
+ON CONFLICT (invoice_id, tx_id) DO NOTHING
+RETURNING id, invoice_id, tx_id, created_at, confirmed_at, amount
+`
+
+type CreateInvoicePaymentParams struct {
+	InvoiceID pgtype.UUID
+	TxID      string
+	Amount    pgtype.Numeric
+}
+
+func (q *Queries) CreateInvoicePayment(ctx context.Context, arg CreateInvoicePaymentParams) (InvoicePayment, error) {
+	row := q.db.QueryRow(ctx, createInvoicePayment, arg.InvoiceID, arg.TxID, arg.Amount)
+	var i InvoicePayment
+	err := row.Scan(
+		&i.ID,
+		&i.InvoiceID,
+		&i.TxID,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Amount,
+	)
+	return i, err
+}
+
+const deleteInvoicePaymentById = `-- name: DeleteInvoicePaymentById :exec
+DELETE FROM invoice_payments
+WHERE id = $1
+`
+
+func (q *Queries) DeleteInvoicePaymentById(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteInvoicePaymentById, id)
+	return err
+}
+
+const findInvoicePaymentsByInvoiceId = `-- name: FindInvoicePaymentsByInvoiceId :many
+SELECT id, invoice_id, tx_id, created_at, confirmed_at, amount FROM invoice_payments
+WHERE invoice_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) FindInvoicePaymentsByInvoiceId(ctx context.Context, invoiceID pgtype.UUID) ([]InvoicePayment, error) {
+	rows, err := q.db.Query(ctx, findInvoicePaymentsByInvoiceId, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InvoicePayment
+	for rows.Next() {
+		var i InvoicePayment
+		if err := rows.Scan(
+			&i.ID,
+			&i.InvoiceID,
+			&i.TxID,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Amount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumInvoicePaymentsByInvoiceId = `-- name: SumInvoicePaymentsByInvoiceId :one
+SELECT COALESCE(SUM(amount), 0)::NUMERIC FROM invoice_payments
+WHERE invoice_id = $1
+`
+
+func (q *Queries) SumInvoicePaymentsByInvoiceId(ctx context.Context, invoiceID pgtype.UUID) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, sumInvoicePaymentsByInvoiceId, invoiceID)
+	var column_1 pgtype.Numeric
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const unconfirmInvoicePaymentById = `-- name: UnconfirmInvoicePaymentById :one
+UPDATE invoice_payments
+SET confirmed_at = NULL
+WHERE id = $1
+RETURNING id, invoice_id, tx_id, created_at, confirmed_at, amount
+`
+
+func (q *Queries) UnconfirmInvoicePaymentById(ctx context.Context, id pgtype.UUID) (InvoicePayment, error) {
+	row := q.db.QueryRow(ctx, unconfirmInvoicePaymentById, id)
+	var i InvoicePayment
+	err := row.Scan(
+		&i.ID,
+		&i.InvoiceID,
+		&i.TxID,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Amount,
+	)
+	return i, err
+}
@@ -0,0 +1,147 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: invoice_stream_event.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createInvoiceStreamEvent = `-- name: CreateInvoiceStreamEvent :one
+INSERT INTO invoice_stream_events(
+    invoice_id,
+    status,
+    actual_amount,
+    tx_id,
+    confirmed_at,
+    confirmations)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING seq, invoice_id, status, tx_id, confirmed_at, created_at, confirmations, actual_amount
+`
+
+type CreateInvoiceStreamEventParams struct {
+	InvoiceID     pgtype.UUID
+	Status        InvoiceStatusType
+	ActualAmount  pgtype.Numeric
+	TxID          pgtype.Text
+	ConfirmedAt   pgtype.Timestamptz
+	Confirmations int32
+}
+
+func (q *Queries) CreateInvoiceStreamEvent(ctx context.Context, arg CreateInvoiceStreamEventParams) (InvoiceStreamEvent, error) {
+	row := q.db.QueryRow(ctx, createInvoiceStreamEvent,
+		arg.InvoiceID,
+		arg.Status,
+		arg.ActualAmount,
+		arg.TxID,
+		arg.ConfirmedAt,
+		arg.Confirmations,
+	)
+	var i InvoiceStreamEvent
+	err := row.Scan(
+		&i.Seq,
+		&i.InvoiceID,
+		&i.Status,
+		&i.TxID,
+		&i.ConfirmedAt,
+		&i.CreatedAt,
+		&i.Confirmations,
+		&i.ActualAmount,
+	)
+	return i, err
+}
+
+const findInvoiceStreamEventsAfterSeq = `-- name: FindInvoiceStreamEventsAfterSeq :many
+SELECT e.seq, e.invoice_id, e.status, e.tx_id, e.confirmed_at, e.created_at, e.confirmations, e.actual_amount, i.id, i.crypto_address, i.coin, i.required_amount, i.actual_amount, i.confirmations_required, i.created_at, i.confirmed_at, i.status, i.expires_at, i.tx_id, i.user_id, i.underpayment_tolerance, i.fiat_amount, i.fiat_currency, i.exchange_rate, i.idempotency_key, i.idempotency_request_hash, i.description, i.external_order_id, i.metadata, i.group_id, i.confirmations, i.memo FROM invoice_stream_events AS e
+JOIN invoices AS i ON e.invoice_id = i.id
+WHERE e.seq > $1
+ORDER BY e.seq
+LIMIT $2
+`
+
+type FindInvoiceStreamEventsAfterSeqParams struct {
+	Seq   int64
+	Limit int32
+}
+
+type FindInvoiceStreamEventsAfterSeqRow struct {
+	InvoiceStreamEvent InvoiceStreamEvent
+	Invoice            Invoice
+}
+
+func (q *Queries) FindInvoiceStreamEventsAfterSeq(ctx context.Context, arg FindInvoiceStreamEventsAfterSeqParams) ([]FindInvoiceStreamEventsAfterSeqRow, error) {
+	rows, err := q.db.Query(ctx, findInvoiceStreamEventsAfterSeq, arg.Seq, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindInvoiceStreamEventsAfterSeqRow
+	for rows.Next() {
+		var i FindInvoiceStreamEventsAfterSeqRow
+		if err := rows.Scan(
+			&i.InvoiceStreamEvent.Seq,
+			&i.InvoiceStreamEvent.InvoiceID,
+			&i.InvoiceStreamEvent.Status,
+			&i.InvoiceStreamEvent.TxID,
+			&i.InvoiceStreamEvent.ConfirmedAt,
+			&i.InvoiceStreamEvent.CreatedAt,
+			&i.InvoiceStreamEvent.Confirmations,
+			&i.InvoiceStreamEvent.ActualAmount,
+			&i.Invoice.ID,
+			&i.Invoice.CryptoAddress,
+			&i.Invoice.Coin,
+			&i.Invoice.RequiredAmount,
+			&i.Invoice.ActualAmount,
+			&i.Invoice.ConfirmationsRequired,
+			&i.Invoice.CreatedAt,
+			&i.Invoice.ConfirmedAt,
+			&i.Invoice.Status,
+			&i.Invoice.ExpiresAt,
+			&i.Invoice.TxID,
+			&i.Invoice.UserID,
+			&i.Invoice.UnderpaymentTolerance,
+			&i.Invoice.FiatAmount,
+			&i.Invoice.FiatCurrency,
+			&i.Invoice.ExchangeRate,
+			&i.Invoice.IdempotencyKey,
+			&i.Invoice.IdempotencyRequestHash,
+			&i.Invoice.Description,
+			&i.Invoice.ExternalOrderID,
+			&i.Invoice.Metadata,
+			&i.Invoice.GroupID,
+			&i.Invoice.Confirmations,
+			&i.Invoice.Memo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findLastInvoiceStreamEventSeq = `-- name: FindLastInvoiceStreamEventSeq :one
+SELECT COALESCE(MAX(seq), 0)::BIGINT FROM invoice_stream_events
+`
+
+func (q *Queries) FindLastInvoiceStreamEventSeq(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, findLastInvoiceStreamEventSeq)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const lockInvoiceStreamEvents = `-- name: LockInvoiceStreamEvents :exec
+LOCK TABLE invoice_stream_events IN SHARE ROW EXCLUSIVE MODE
+`
+
+func (q *Queries) LockInvoiceStreamEvents(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockInvoiceStreamEvents)
+	return err
+}
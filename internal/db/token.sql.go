@@ -0,0 +1,94 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: token.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findEnabledTokensByChainAndChainId = `-- name: FindEnabledTokensByChainAndChainId :many
+SELECT id, chain, chain_id, symbol, coin, contract_address, decimals, enabled FROM tokens
+WHERE chain = $1 AND chain_id = $2 AND enabled
+ORDER BY symbol
+`
+
+type FindEnabledTokensByChainAndChainIdParams struct {
+	Chain   CoinType
+	ChainID int64
+}
+
+func (q *Queries) FindEnabledTokensByChainAndChainId(ctx context.Context, arg FindEnabledTokensByChainAndChainIdParams) ([]Token, error) {
+	rows, err := q.db.Query(ctx, findEnabledTokensByChainAndChainId, arg.Chain, arg.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Token
+	for rows.Next() {
+		var i Token
+		if err := rows.Scan(
+			&i.ID,
+			&i.Chain,
+			&i.ChainID,
+			&i.Symbol,
+			&i.Coin,
+			&i.ContractAddress,
+			&i.Decimals,
+			&i.Enabled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertToken = `-- name: UpsertToken :one
+INSERT INTO tokens(chain, chain_id, symbol, coin, contract_address, decimals, enabled) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (chain, chain_id, symbol) DO UPDATE
+SET coin = EXCLUDED.coin,
+    contract_address = EXCLUDED.contract_address,
+    decimals = EXCLUDED.decimals,
+    enabled = EXCLUDED.enabled
+RETURNING id, chain, chain_id, symbol, coin, contract_address, decimals, enabled
+`
+
+type UpsertTokenParams struct {
+	Chain           CoinType
+	ChainID         int64
+	Symbol          string
+	Coin            CoinType
+	ContractAddress string
+	Decimals        int32
+	Enabled         bool
+}
+
+func (q *Queries) UpsertToken(ctx context.Context, arg UpsertTokenParams) (Token, error) {
+	row := q.db.QueryRow(ctx, upsertToken,
+		arg.Chain,
+		arg.ChainID,
+		arg.Symbol,
+		arg.Coin,
+		arg.ContractAddress,
+		arg.Decimals,
+		arg.Enabled,
+	)
+	var i Token
+	err := row.Scan(
+		&i.ID,
+		&i.Chain,
+		&i.ChainID,
+		&i.Symbol,
+		&i.Coin,
+		&i.ContractAddress,
+		&i.Decimals,
+		&i.Enabled,
+	)
+	return i, err
+}
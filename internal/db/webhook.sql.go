@@ -0,0 +1,343 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: webhook.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimDueWebhookDeliveries = `-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = $1
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'PENDING' AND next_attempt_at <= timezone('UTC', now())
+    ORDER BY next_attempt_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id, invoice_id, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at
+`
+
+type ClaimDueWebhookDeliveriesParams struct {
+	LeaseUntil pgtype.Timestamptz
+	Limit      int32
+}
+
+// Postpones the due deliveries till lease_until, so neither another worker nor the next poll
+// picks them up while they are being delivered.
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, claimDueWebhookDeliveries, arg.LeaseUntil, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.InvoiceID,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries(
+    user_id,
+    invoice_id,
+    payload)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, invoice_id, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at
+`
+
+type CreateWebhookDeliveryParams struct {
+	UserID    pgtype.UUID
+	InvoiceID pgtype.UUID
+	Payload   []byte
+}
+
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, createWebhookDelivery, arg.UserID, arg.InvoiceID, arg.Payload)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.InvoiceID,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const deleteWebhookEndpointByUserId = `-- name: DeleteWebhookEndpointByUserId :one
+DELETE FROM webhook_endpoints
+WHERE user_id = $1
+RETURNING user_id, url, secret, created_at, updated_at
+`
+
+func (q *Queries) DeleteWebhookEndpointByUserId(ctx context.Context, userID pgtype.UUID) (WebhookEndpoint, error) {
+	row := q.db.QueryRow(ctx, deleteWebhookEndpointByUserId, userID)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.UserID,
+		&i.Url,
+		&i.Secret,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const failWebhookDeliveryById = `-- name: FailWebhookDeliveryById :one
+UPDATE webhook_deliveries
+SET status = $2,
+    attempts = attempts + 1,
+    last_error = $3,
+    next_attempt_at = $4
+WHERE id = $1
+RETURNING id, user_id, invoice_id, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at
+`
+
+type FailWebhookDeliveryByIdParams struct {
+	ID            pgtype.UUID
+	Status        WebhookDeliveryStatusType
+	LastError     pgtype.Text
+	NextAttemptAt pgtype.Timestamptz
+}
+
+func (q *Queries) FailWebhookDeliveryById(ctx context.Context, arg FailWebhookDeliveryByIdParams) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, failWebhookDeliveryById,
+		arg.ID,
+		arg.Status,
+		arg.LastError,
+		arg.NextAttemptAt,
+	)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.InvoiceID,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const findWebhookDeliveriesByFilter = `-- name: FindWebhookDeliveriesByFilter :many
+SELECT id, user_id, invoice_id, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at FROM webhook_deliveries
+WHERE user_id = $1
+    AND ($2::uuid IS NULL OR invoice_id = $2)
+    AND ($3::webhook_delivery_status_type IS NULL OR status = $3)
+ORDER BY created_at DESC, id
+LIMIT $5 OFFSET $4
+`
+
+type FindWebhookDeliveriesByFilterParams struct {
+	UserID    pgtype.UUID
+	InvoiceID pgtype.UUID
+	Status    NullWebhookDeliveryStatusType
+	Offset    int32
+	Limit     int32
+}
+
+func (q *Queries) FindWebhookDeliveriesByFilter(ctx context.Context, arg FindWebhookDeliveriesByFilterParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, findWebhookDeliveriesByFilter,
+		arg.UserID,
+		arg.InvoiceID,
+		arg.Status,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.InvoiceID,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findWebhookDeliveryById = `-- name: FindWebhookDeliveryById :one
+SELECT id, user_id, invoice_id, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at FROM webhook_deliveries
+WHERE id = $1
+`
+
+func (q *Queries) FindWebhookDeliveryById(ctx context.Context, id pgtype.UUID) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, findWebhookDeliveryById, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.InvoiceID,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const findWebhookEndpointByUserId = `-- name: FindWebhookEndpointByUserId :one
+SELECT user_id, url, secret, created_at, updated_at FROM webhook_endpoints
+WHERE user_id = $1
+`
+
+func (q *Queries) FindWebhookEndpointByUserId(ctx context.Context, userID pgtype.UUID) (WebhookEndpoint, error) {
+	row := q.db.QueryRow(ctx, findWebhookEndpointByUserId, userID)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.UserID,
+		&i.Url,
+		&i.Secret,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const markWebhookDeliveryDeliveredById = `-- name: MarkWebhookDeliveryDeliveredById :one
+UPDATE webhook_deliveries
+SET status = 'DELIVERED',
+    attempts = attempts + 1,
+    last_error = NULL,
+    delivered_at = timezone('UTC', now())
+WHERE id = $1
+RETURNING id, user_id, invoice_id, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at
+`
+
+func (q *Queries) MarkWebhookDeliveryDeliveredById(ctx context.Context, id pgtype.UUID) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, markWebhookDeliveryDeliveredById, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.InvoiceID,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const redeliverWebhookDeliveryById = `-- name: RedeliverWebhookDeliveryById :one
+UPDATE webhook_deliveries
+SET status = 'PENDING',
+    attempts = 0,
+    next_attempt_at = timezone('UTC', now())
+WHERE id = $1
+RETURNING id, user_id, invoice_id, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at
+`
+
+func (q *Queries) RedeliverWebhookDeliveryById(ctx context.Context, id pgtype.UUID) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, redeliverWebhookDeliveryById, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.InvoiceID,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const upsertWebhookEndpoint = `-- name: UpsertWebhookEndpoint :one
+INSERT INTO webhook_endpoints(
+    user_id,
+    url,
+    secret)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET url = EXCLUDED.url,
+    secret = CASE WHEN $4::BOOLEAN THEN webhook_endpoints.secret ELSE EXCLUDED.secret END,
+    updated_at = timezone('UTC', now())
+RETURNING user_id, url, secret, created_at, updated_at
+`
+
+type UpsertWebhookEndpointParams struct {
+	UserID     pgtype.UUID
+	Url        string
+	Secret     string
+	KeepSecret bool
+}
+
+func (q *Queries) UpsertWebhookEndpoint(ctx context.Context, arg UpsertWebhookEndpointParams) (WebhookEndpoint, error) {
+	row := q.db.QueryRow(ctx, upsertWebhookEndpoint,
+		arg.UserID,
+		arg.Url,
+		arg.Secret,
+		arg.KeepSecret,
+	)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.UserID,
+		&i.Url,
+		&i.Secret,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
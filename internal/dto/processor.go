@@ -1,13 +1,60 @@
 package dto
 
-import "github.com/chekist32/goipay/internal/db"
+import (
+	"math/big"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+)
 
 type NewInvoiceRequest struct {
-	UserId        string
-	Coin          db.CoinType
-	Amount        float64
+	UserId string
+	Coin   db.CoinType
+	// In the atomic units of the coin.
+	Amount        *big.Int
 	Timeout       uint64
 	Confirmations uint32
+
+	// In the atomic units of the coin.
+	UnderpaymentTolerance        *big.Int
+	UnderpaymentTolerancePercent float64
+
+	FiatAmount                float64
+	FiatCurrency              string
+	FiatUnderpaymentTolerance float64
+	ExchangeRate              float64
+
+	IdempotencyKey         string
+	IdempotencyRequestHash string
+
+	Description     string
+	ExternalOrderId string
+	Metadata        map[string]string
+
+	// If set, Coin and Amount are ignored and an invoice is created per option.
+	CoinOptions []CoinOption
+	GroupId     string
+}
+
+type CoinOption struct {
+	Coin db.CoinType
+	// In the atomic units of the coin.
+	Amount *big.Int
+}
+
+type AddressPoolStats struct {
+	MinFreeAddresses int32
+	// 0 if the gap limit is off.
+	GapLimit       int32
+	TotalAddresses int64
+	FreeAddresses  int64
+	// The addresses derived after the last one that received funds.
+	GapAddresses int64
+}
+
+type InvoiceStreamEvent struct {
+	Seq     uint64
+	Invoice db.Invoice
 }
 
 type DaemonConfig struct {
@@ -18,8 +65,33 @@ type DaemonConfig struct {
 type XMRDaemonConfig DaemonConfig
 type BTCDaemonConfig DaemonConfig
 type LTCDaemonConfig DaemonConfig
-type ETHDaemonConfig DaemonConfig
+type ETHDaemonConfig struct {
+	DaemonConfig
+	// Added to the token registry for the network of the daemon on the startup.
+	Tokens []EVMTokenConfig
+}
 type BNBDaemonConfig ETHDaemonConfig
+type TONDaemonConfig DaemonConfig
+type TRXDaemonConfig DaemonConfig
+
+type EVMTokenConfig struct {
+	// The coin of the token is SYMBOL_ERC20 on ETH, SYMBOL_BEP20 on BNB and SYMBOL_CHAIN otherwise, e.g. USDC_POLYGON.
+	Symbol   string
+	Contract string
+	Decimals int32
+	// Disables a token of the registry, e.g. a built-in one.
+	Disabled bool
+}
+
+// EVMChainConfig declares an EVM chain (e.g. Polygon, Arbitrum, Base) served by the generic processor.
+type EVMChainConfig struct {
+	// The native coin is named after the chain, e.g. POLYGON.
+	Name     string
+	ChainId  uint64
+	Url      string
+	Decimals int32
+	Tokens   []EVMTokenConfig
+}
 
 type DaemonsConfig struct {
 	Xmr XMRDaemonConfig
@@ -27,4 +99,14 @@ type DaemonsConfig struct {
 	Ltc LTCDaemonConfig
 	Eth ETHDaemonConfig
 	Bnb BNBDaemonConfig
+	Ton TONDaemonConfig
+	Trx TRXDaemonConfig
+	Evm []EVMChainConfig
+}
+
+type ProcessorConfig struct {
+	Daemons DaemonsConfig
+
+	// How long the address of an expired invoice keeps being watched for late payments before it is released.
+	LatePaymentGraceWindow time.Duration
 }
@@ -2,10 +2,18 @@ package v1
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
 
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
 	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
 	"github.com/chekist32/goipay/internal/processor"
 	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
@@ -19,6 +27,92 @@ type InvoiceGrpc struct {
 	pb_v1.UnimplementedInvoiceServiceServer
 }
 
+func validateCreateInvoiceRequest(req *pb_v1.CreateInvoiceRequest) error {
+	amount := req.Amount
+	if req.CoinCode != nil && len(req.CoinOptions) == 0 {
+		if _, err := util.PbCoinOrCodeToDbCoin(req.Coin, req.CoinCode); err != nil {
+			return status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
+		}
+	}
+	if req.ExactAmount != nil && req.Fiat == nil && len(req.CoinOptions) == 0 {
+		coin, _ := util.PbCoinOrCodeToDbCoin(req.Coin, req.CoinCode)
+		if _, err := util.ParseDecimalAmount(*req.ExactAmount, coin); err != nil {
+			return status.Error(codes.InvalidArgument, util.InvalidExactAmountMsg)
+		}
+		amount, _ = strconv.ParseFloat(*req.ExactAmount, 64)
+	}
+	if req.Fiat != nil {
+		amount = req.Fiat.Amount
+		if len(req.Fiat.Currency) != 3 || strings.IndexFunc(req.Fiat.Currency, func(r rune) bool { return !unicode.IsLetter(r) || r > unicode.MaxASCII }) != -1 {
+			return status.Error(codes.InvalidArgument, util.InvalidFiatCurrencyMsg)
+		}
+	}
+
+	if req.IdempotencyKey != nil && (len(*req.IdempotencyKey) == 0 || len(*req.IdempotencyKey) > util.IDEMPOTENCY_KEY_MAX_LENGTH) {
+		return status.Error(codes.InvalidArgument, util.InvalidIdempotencyKeyMsg)
+	}
+	if len(req.Description) > util.INVOICE_DESCRIPTION_MAX_LENGTH {
+		return status.Error(codes.InvalidArgument, util.InvalidInvoiceDescriptionMsg)
+	}
+	if len(req.ExternalOrderId) > util.INVOICE_EXTERNAL_ORDER_ID_MAX_LENGTH {
+		return status.Error(codes.InvalidArgument, util.InvalidExternalOrderIdMsg)
+	}
+	if len(req.Metadata) > util.INVOICE_METADATA_MAX_KEYS {
+		return status.Error(codes.InvalidArgument, util.InvalidInvoiceMetadataMsg)
+	}
+	for k, v := range req.Metadata {
+		if len(k) == 0 || len(k) > util.INVOICE_METADATA_KEY_MAX_LENGTH || len(v) > util.INVOICE_METADATA_VALUE_MAX_LENGTH {
+			return status.Error(codes.InvalidArgument, util.InvalidInvoiceMetadataMsg)
+		}
+	}
+	if _, ok := pb_v1.QrCodeFormat_name[int32(req.QrCodeFormat)]; !ok {
+		return status.Error(codes.InvalidArgument, util.InvalidQrCodeFormatMsg)
+	}
+
+	if len(req.CoinOptions) > 0 {
+		coins := make(map[db.CoinType]bool, len(req.CoinOptions))
+		for _, option := range req.CoinOptions {
+			coin, err := util.PbCoinOrCodeToDbCoin(option.Coin, option.CoinCode)
+			if err != nil || coins[coin] || (req.Fiat == nil && option.Amount < 0) {
+				return status.Error(codes.InvalidArgument, util.InvalidCoinOptionsMsg)
+			}
+			if option.ExactAmount != nil && req.Fiat == nil {
+				if units, err := util.ParseDecimalAmount(*option.ExactAmount, coin); err != nil || units.Sign() < 0 {
+					return status.Error(codes.InvalidArgument, util.InvalidCoinOptionsMsg)
+				}
+			}
+			coins[coin] = true
+		}
+		// An absolute tolerance can't be shared between the coins unless it's denominated in fiat.
+		if _, ok := req.UnderpaymentTolerance.(*pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount); ok && req.Fiat == nil {
+			return status.Error(codes.InvalidArgument, util.InvalidUnderpaymentToleranceMsg)
+		}
+	}
+
+	if amount < 0 {
+		return status.Error(codes.InvalidArgument, util.InvoiceAmountBelow0ErrorMsg)
+	}
+	if percent, ok := req.UnderpaymentTolerance.(*pb_v1.CreateInvoiceRequest_UnderpaymentTolerancePercent); ok && (percent.UnderpaymentTolerancePercent < 0 || percent.UnderpaymentTolerancePercent > 100) {
+		return status.Error(codes.InvalidArgument, util.InvalidUnderpaymentToleranceMsg)
+	}
+	if tolerance, ok := req.UnderpaymentTolerance.(*pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount); ok && (tolerance.UnderpaymentToleranceAmount < 0 || tolerance.UnderpaymentToleranceAmount > amount) {
+		return status.Error(codes.InvalidArgument, util.InvalidUnderpaymentToleranceMsg)
+	}
+
+	return nil
+}
+
+func qrCode(text string, format pb_v1.QrCodeFormat) ([]byte, error) {
+	switch format {
+	case pb_v1.QrCodeFormat_QR_CODE_PNG:
+		return util.QrCodePNG(text)
+	case pb_v1.QrCodeFormat_QR_CODE_SVG:
+		return util.QrCodeSVG(text)
+	}
+
+	return nil, nil
+}
+
 func (i *InvoiceGrpc) CreateInvoice(ctx context.Context, req *pb_v1.CreateInvoiceRequest) (*pb_v1.CreateInvoiceResponse, error) {
 	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
 	if err != nil {
@@ -27,34 +121,231 @@ func (i *InvoiceGrpc) CreateInvoice(ctx context.Context, req *pb_v1.CreateInvoic
 	}
 	defer tx.Rollback(ctx)
 
-	if req.Amount < 0 {
-		return nil, status.Error(codes.InvalidArgument, util.InvoiceAmountBelow0ErrorMsg)
+	if err := validateCreateInvoiceRequest(req); err != nil {
+		return nil, err
 	}
 	if err := checkIfUserExistsString(ctx, i.log, q, req.UserId); err != nil {
 		return nil, err
 	}
 
-	invoice, err := i.paymentProcessor.HandleNewInvoice(util.PbNewInvoiceToProcessorNewInvoice(req))
+	var invoices []db.Invoice
+	newInvoiceReq := util.PbNewInvoiceToProcessorNewInvoice(req)
+	if len(newInvoiceReq.CoinOptions) > 0 {
+		invoices, err = i.paymentProcessor.HandleNewMultiCoinInvoice(newInvoiceReq)
+	} else {
+		var invoice *db.Invoice
+		if invoice, err = i.paymentProcessor.HandleNewInvoice(newInvoiceReq); err == nil {
+			invoices = []db.Invoice{*invoice}
+		}
+	}
 	if err != nil {
+		if errors.Is(err, processor.FiatInvoicesUnsupportedErr) {
+			return nil, status.Error(codes.FailedPrecondition, util.FiatInvoicesUnsupportedMsg)
+		}
+		if errors.Is(err, processor.IdempotencyKeyReusedErr) {
+			return nil, status.Error(codes.AlreadyExists, util.IdempotencyKeyReusedMsg)
+		}
+		if errors.Is(err, processor.AddressGapLimitReachedErr) {
+			return nil, status.Error(codes.ResourceExhausted, util.AddressGapLimitReachedMsg)
+		}
+		if errors.Is(err, processor.RetiredCryptoAddressesOccupiedErr) {
+			return nil, status.Error(codes.ResourceExhausted, util.RetiredAddressesOccupiedMsg)
+		}
+
 		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.InvoiceErrorWhileHandlingMsg)
 		return nil, status.Error(codes.Internal, util.InvoiceErrorWhileHandlingMsg)
 	}
 
 	tx.Commit(ctx)
 
-	return &pb_v1.CreateInvoiceResponse{PaymentId: util.PgUUIDToString(invoice.ID), Address: invoice.CryptoAddress}, nil
+	if len(invoices) == 0 {
+		i.log.Error().Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.InvoiceErrorWhileHandlingMsg)
+		return nil, status.Error(codes.Internal, util.InvoiceErrorWhileHandlingMsg)
+	}
+
+	options := make([]*pb_v1.PaymentOption, 0, len(invoices))
+	for j := 0; j < len(invoices); j++ {
+		options = append(options, i.paymentOption(ctx, &invoices[j], req))
+	}
+
+	res := &pb_v1.CreateInvoiceResponse{
+		PaymentId:  options[0].PaymentId,
+		Address:    options[0].Address,
+		PaymentUri: options[0].PaymentUri,
+		QrCode:     options[0].QrCode,
+	}
+	if len(newInvoiceReq.CoinOptions) > 0 {
+		res.GroupId = util.PgUUIDToString(invoices[0].GroupID)
+		res.Options = options
+	}
+
+	return res, nil
+}
+
+// paymentOption doesn't fail on the URI and QR code errors, as the invoice has already been created at this point.
+func (i *InvoiceGrpc) paymentOption(ctx context.Context, invoice *db.Invoice, req *pb_v1.CreateInvoiceRequest) *pb_v1.PaymentOption {
+	coin, _ := util.DbCoinToPbCoin(invoice.Coin)
+	option := &pb_v1.PaymentOption{
+		Coin:      coin,
+		CoinCode:  string(invoice.Coin),
+		PaymentId: util.PgUUIDToString(invoice.ID),
+		Address:   invoice.CryptoAddress,
+	}
+	requiredAmount := util.PgNumericToBigInt(invoice.RequiredAmount)
+	option.Amount = util.AtomicUnitsToFloat64(requiredAmount, invoice.Coin)
+	option.AmountExact = util.AtomicUnitsToPbAmount(requiredAmount, invoice.Coin)
+	if invoice.Memo.Valid {
+		option.Memo = &invoice.Memo.String
+	}
+
+	var err error
+	option.PaymentUri, err = i.paymentProcessor.PaymentUri(invoice, req.GetLabel())
+	if err != nil {
+		i.log.Warn().Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.PaymentUriErrorWhileBuildingMsg)
+		return option
+	}
+	option.QrCode, err = qrCode(option.PaymentUri, req.QrCodeFormat)
+	if err != nil {
+		i.log.Warn().Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.PaymentUriErrorWhileBuildingMsg)
+	}
+
+	return option
+}
+
+type invoiceStreamFilter struct {
+	userIds    map[string]bool
+	invoiceIds map[string]bool
+	coins      map[db.CoinType]bool
+	statuses   map[db.InvoiceStatusType]bool
+}
+
+func (f *invoiceStreamFilter) matches(invoice *db.Invoice) bool {
+	return (len(f.userIds) == 0 || f.userIds[util.PgUUIDToString(invoice.UserID)]) &&
+		(len(f.invoiceIds) == 0 || f.invoiceIds[util.PgUUIDToString(invoice.ID)]) &&
+		(len(f.coins) == 0 || f.coins[invoice.Coin]) &&
+		(len(f.statuses) == 0 || f.statuses[invoice.Status])
+}
+
+func newInvoiceStreamFilter(req *pb_v1.InvoiceStatusStreamRequest) (*invoiceStreamFilter, error) {
+	f := &invoiceStreamFilter{
+		userIds:    make(map[string]bool, len(req.UserIds)),
+		invoiceIds: make(map[string]bool, len(req.InvoiceIds)),
+		coins:      make(map[db.CoinType]bool, len(req.Coins)),
+		statuses:   make(map[db.InvoiceStatusType]bool, len(req.Statuses)),
+	}
+
+	for j := 0; j < len(req.UserIds); j++ {
+		userId, err := util.StringToPgUUID(req.UserIds[j])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+		}
+		f.userIds[util.PgUUIDToString(*userId)] = true
+	}
+	for j := 0; j < len(req.InvoiceIds); j++ {
+		invoiceId, err := util.StringToPgUUID(req.InvoiceIds[j])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceIdInvalidUUIDMsg)
+		}
+		f.invoiceIds[util.PgUUIDToString(*invoiceId)] = true
+	}
+	for j := 0; j < len(req.Coins); j++ {
+		coin, err := util.PbCoinToDbCoin(req.Coins[j])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
+		}
+		f.coins[coin] = true
+	}
+	for j := 0; j < len(req.Statuses); j++ {
+		invoiceStatus, err := util.PbInvoiceStatusToDbInvoiceStatus(req.Statuses[j])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceStatusTypeMsg)
+		}
+		f.statuses[invoiceStatus] = true
+	}
+
+	return f, nil
 }
 
 func (i *InvoiceGrpc) InvoiceStatusStream(req *pb_v1.InvoiceStatusStreamRequest, stream pb_v1.InvoiceService_InvoiceStatusStreamServer) error {
-	invoiceCn := i.paymentProcessor.NewInvoicesChan()
+	filter, err := newInvoiceStreamFilter(req)
+	if err != nil {
+		return err
+	}
+
+	send := func(event *dto.InvoiceStreamEvent) error {
+		if !filter.matches(&event.Invoice) {
+			return nil
+		}
+
+		if err := stream.Send(&pb_v1.InvoiceStatusStreamResponse{Invoice: util.DbInvoiceToPbInvoice(&event.Invoice), Seq: event.Seq}); err != nil {
+			i.log.Err(err).Msg(util.InvoiceStreamSendingDataErrorMsg)
+			return status.Error(codes.Canceled, util.InvoiceStreamSendingDataErrorMsg)
+		}
+
+		return nil
+	}
+
+	// Subscribe before replaying, so that no event is lost in between.
+	invoiceCn, droppedCn := i.paymentProcessor.NewInvoicesChan()
+
+	// The live events are held back while replaying, the subscription would be dropped otherwise.
+	var heldBack []dto.InvoiceStreamEvent
+	holdBack := func() {
+		for {
+			select {
+			case event := <-invoiceCn:
+				heldBack = append(heldBack, event)
+			default:
+				return
+			}
+		}
+	}
+
+	var replayedSeq uint64
+	if req.FromSeq != nil {
+		replayedSeq = *req.FromSeq
+		for {
+			events, err := i.paymentProcessor.FindInvoiceStreamEventsAfterSeq(replayedSeq, util.INVOICE_STREAM_REPLAY_BATCH_SIZE)
+			if err != nil {
+				return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+			}
+			holdBack()
+
+			for j := 0; j < len(events); j++ {
+				if err := send(&events[j]); err != nil {
+					return err
+				}
+				replayedSeq = events[j].Seq
+				holdBack()
+			}
+
+			if len(events) < util.INVOICE_STREAM_REPLAY_BATCH_SIZE {
+				break
+			}
+		}
+	}
+
+	// The events replayed already are skipped.
+	for j := 0; j < len(heldBack); j++ {
+		if heldBack[j].Seq <= replayedSeq {
+			continue
+		}
+		if err := send(&heldBack[j]); err != nil {
+			return err
+		}
+	}
 
 	for {
 		select {
-		case invoice := <-invoiceCn:
-			if err := stream.Send(&pb_v1.InvoiceStatusStreamResponse{Invoice: util.DbInvoiceToPbInvoice(&invoice)}); err != nil {
-				i.log.Err(err).Msg(util.InvoiceStreamSendingDataErrorMsg)
-				return status.Error(codes.Canceled, util.InvoiceStreamSendingDataErrorMsg)
+		case event := <-invoiceCn:
+			if event.Seq <= replayedSeq {
+				continue
+			}
+			if err := send(&event); err != nil {
+				return err
 			}
+		case <-droppedCn:
+			return status.Error(codes.Unavailable, util.InvoiceStreamFellBehindErrorMsg)
 		case <-stream.Context().Done():
 			return status.Error(codes.Canceled, util.InvoiceStreamClosedErrorMsg)
 		}
@@ -62,6 +353,193 @@ func (i *InvoiceGrpc) InvoiceStatusStream(req *pb_v1.InvoiceStatusStreamRequest,
 
 }
 
+func (i *InvoiceGrpc) GetInvoice(ctx context.Context, req *pb_v1.GetInvoiceRequest) (*pb_v1.GetInvoiceResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := util.StringToPgUUID(req.Id)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceIdInvalidUUIDMsg)
+	}
+
+	invoice, err := q.FindInvoiceById(ctx, *id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, util.InvoiceNotFoundMsg)
+		}
+
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.GetInvoiceResponse{Invoice: util.DbInvoiceToPbInvoice(&invoice)}, nil
+}
+
+func (i *InvoiceGrpc) GetInvoiceHistory(ctx context.Context, req *pb_v1.GetInvoiceHistoryRequest) (*pb_v1.GetInvoiceHistoryResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := util.StringToPgUUID(req.Id)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceIdInvalidUUIDMsg)
+	}
+
+	invoice, err := q.FindInvoiceById(ctx, *id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, util.InvoiceNotFoundMsg)
+		}
+
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	events, err := q.FindInvoiceEventsByInvoiceId(ctx, *id)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindInvoiceEventsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	pbEvents := make([]*pb_v1.InvoiceEvent, 0, len(events))
+	for j := 0; j < len(events); j++ {
+		pbEvents = append(pbEvents, util.DbInvoiceEventToPbInvoiceEvent(&events[j], invoice.Coin))
+	}
+
+	return &pb_v1.GetInvoiceHistoryResponse{Events: pbEvents}, nil
+}
+
+func (i *InvoiceGrpc) ListInvoices(ctx context.Context, req *pb_v1.ListInvoicesRequest) (*pb_v1.ListInvoicesResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = util.LIST_INVOICES_DEFAULT_LIMIT
+	}
+	if limit > util.LIST_INVOICES_MAX_LIMIT {
+		return nil, status.Error(codes.InvalidArgument, util.InvoiceListLimitExceededMsg)
+	}
+
+	params := db.FindInvoicesByFilterParams{
+		CreatedAtFrom: util.PbTimestampToPgTimestamptz(req.CreatedAtFrom),
+		CreatedAtTo:   util.PbTimestampToPgTimestamptz(req.CreatedAtTo),
+		ExpiresAtFrom: util.PbTimestampToPgTimestamptz(req.ExpiresAtFrom),
+		ExpiresAtTo:   util.PbTimestampToPgTimestamptz(req.ExpiresAtTo),
+		Limit:         int32(limit),
+		Offset:        int32(req.Offset),
+	}
+
+	if req.UserId != nil {
+		userId, err := util.StringToPgUUID(*req.UserId)
+		if err != nil {
+			i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+			return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+		}
+		params.UserID = *userId
+	}
+	if req.Coin != nil {
+		coin, err := util.PbCoinToDbCoin(*req.Coin)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
+		}
+		params.Coin = db.NullCoinType{CoinType: coin, Valid: true}
+	}
+	if req.Status != nil {
+		invoiceStatus, err := util.PbInvoiceStatusToDbInvoiceStatus(*req.Status)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceStatusTypeMsg)
+		}
+		params.Status = db.NullInvoiceStatusType{InvoiceStatusType: invoiceStatus, Valid: true}
+	}
+	if req.ExternalOrderId != nil {
+		params.ExternalOrderID = pgtype.Text{String: *req.ExternalOrderId, Valid: true}
+	}
+	if req.GroupId != nil {
+		groupId, err := util.StringToPgUUID(*req.GroupId)
+		if err != nil {
+			i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+			return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceGroupIdInvalidUUIDMsg)
+		}
+		params.GroupID = *groupId
+	}
+
+	invoices, err := q.FindInvoicesByFilter(ctx, params)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindInvoicesByFilter").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	res := make([]*pb_v1.Invoice, 0, len(invoices))
+	for j := 0; j < len(invoices); j++ {
+		res = append(res, util.DbInvoiceToPbInvoice(&invoices[j]))
+	}
+
+	return &pb_v1.ListInvoicesResponse{Invoices: res}, nil
+}
+
+func (i *InvoiceGrpc) CancelInvoice(ctx context.Context, req *pb_v1.CancelInvoiceRequest) (*pb_v1.CancelInvoiceResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := util.StringToPgUUID(req.Id)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceIdInvalidUUIDMsg)
+	}
+
+	invoice, err := q.FindInvoiceById(ctx, *id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, util.InvoiceNotFoundMsg)
+		}
+
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	if invoice.Status != db.InvoiceStatusTypePENDING {
+		return nil, status.Error(codes.FailedPrecondition, util.InvoiceNotCancellableMsg)
+	}
+
+	cancelledInvoice, err := i.paymentProcessor.CancelInvoice(&invoice)
+	if err != nil {
+		if errors.Is(err, processor.InvoiceNotCancellableErr) {
+			return nil, status.Error(codes.FailedPrecondition, util.InvoiceNotCancellableMsg)
+		}
+
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.InvoiceErrorWhileHandlingMsg)
+		return nil, status.Error(codes.Internal, util.InvoiceErrorWhileHandlingMsg)
+	}
+
+	return &pb_v1.CancelInvoiceResponse{Invoice: util.DbInvoiceToPbInvoice(cancelledInvoice)}, nil
+}
+
 func NewInvoiceGrpc(dbConnPool *pgxpool.Pool, paymentProcessor *processor.PaymentProcessor, log *zerolog.Logger) *InvoiceGrpc {
 	return &InvoiceGrpc{dbConnPool: dbConnPool, paymentProcessor: paymentProcessor, log: log}
 }
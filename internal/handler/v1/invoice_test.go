@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestInvoiceStreamFilter(t *testing.T) {
+	t.Parallel()
+
+	userId, err := util.StringToPgUUID(uuid.NewString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	invoiceId, err := util.StringToPgUUID(uuid.NewString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	invoice := db.Invoice{ID: *invoiceId, UserID: *userId, Coin: db.CoinTypeBTC, Status: db.InvoiceStatusTypePENDING}
+
+	t.Run("Should Match Everything (empty filter)", func(t *testing.T) {
+		f, err := newInvoiceStreamFilter(&pb_v1.InvoiceStatusStreamRequest{})
+		assert.NoError(t, err)
+		assert.True(t, f.matches(&invoice))
+	})
+
+	t.Run("Should Match", func(t *testing.T) {
+		f, err := newInvoiceStreamFilter(&pb_v1.InvoiceStatusStreamRequest{
+			UserIds:    []string{util.PgUUIDToString(*userId)},
+			InvoiceIds: []string{util.PgUUIDToString(*invoiceId)},
+			Coins:      []pb_v1.CoinType{pb_v1.CoinType_XMR, pb_v1.CoinType_BTC},
+			Statuses:   []pb_v1.InvoiceStatusType{pb_v1.InvoiceStatusType_PENDING},
+		})
+		assert.NoError(t, err)
+		assert.True(t, f.matches(&invoice))
+	})
+
+	t.Run("Should Not Match", func(t *testing.T) {
+		f, err := newInvoiceStreamFilter(&pb_v1.InvoiceStatusStreamRequest{
+			UserIds: []string{uuid.NewString()},
+		})
+		assert.NoError(t, err)
+		assert.False(t, f.matches(&invoice))
+
+		f, err = newInvoiceStreamFilter(&pb_v1.InvoiceStatusStreamRequest{
+			Statuses: []pb_v1.InvoiceStatusType{pb_v1.InvoiceStatusType_CONFIRMED},
+		})
+		assert.NoError(t, err)
+		assert.False(t, f.matches(&invoice))
+	})
+
+	t.Run("Should Return Error (invalid UUID)", func(t *testing.T) {
+		_, err := newInvoiceStreamFilter(&pb_v1.InvoiceStatusStreamRequest{InvoiceIds: []string{"invalid"}})
+		assert.EqualError(t, err, status.Error(codes.InvalidArgument, util.InvalidInvoiceIdInvalidUUIDMsg).Error())
+	})
+}
+
+func TestValidateCreateInvoiceRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Pass", func(t *testing.T) {
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{Amount: 1}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{Amount: 1, QrCodeFormat: pb_v1.QrCodeFormat_QR_CODE_SVG}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{Amount: 1, IdempotencyKey: proto.String("order-42")}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{
+			Amount:          1,
+			Description:     "2x Coffee",
+			ExternalOrderId: "order-42",
+			Metadata:        map[string]string{"customerId": "7"},
+		}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{
+			Fiat:                  &pb_v1.FiatAmount{Currency: "usd", Amount: 10},
+			UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount{UnderpaymentToleranceAmount: 5},
+		}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{
+			CoinOptions:           []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_XMR, Amount: 1}, {Coin: pb_v1.CoinType_BTC, Amount: 0.01}},
+			UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentTolerancePercent{UnderpaymentTolerancePercent: 1},
+		}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{
+			Fiat:                  &pb_v1.FiatAmount{Currency: "USD", Amount: 10},
+			CoinOptions:           []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_XMR}, {Coin: pb_v1.CoinType_USDT_ERC20}},
+			UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount{UnderpaymentToleranceAmount: 1},
+		}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{Coin: pb_v1.CoinType_BTC, ExactAmount: proto.String("0.00000001")}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{
+			Coin:                  pb_v1.CoinType_ETH,
+			ExactAmount:           proto.String("445540.848667137766512794"),
+			UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount{UnderpaymentToleranceAmount: 0.1},
+		}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{
+			CoinOptions: []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_XMR, ExactAmount: proto.String("0.123456789012")}, {Coin: pb_v1.CoinType_BTC, Amount: 0.01}},
+		}))
+		assert.NoError(t, validateCreateInvoiceRequest(&pb_v1.CreateInvoiceRequest{CoinCode: proto.String("usdt_erc20"), ExactAmount: proto.String("1.5")}))
+	})
+
+	t.Run("Should Return InvalidArgument", func(t *testing.T) {
+		reqs := []*pb_v1.CreateInvoiceRequest{
+			{Amount: -1},
+			{Fiat: &pb_v1.FiatAmount{Currency: "US", Amount: 10}},
+			{Fiat: &pb_v1.FiatAmount{Currency: "U$D", Amount: 10}},
+			{Fiat: &pb_v1.FiatAmount{Currency: "USD", Amount: -10}},
+			{Amount: 1, UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentTolerancePercent{UnderpaymentTolerancePercent: 101}},
+			{Amount: 1, UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount{UnderpaymentToleranceAmount: 2}},
+			{Amount: 1, QrCodeFormat: pb_v1.QrCodeFormat(10)},
+			{Amount: 1, IdempotencyKey: proto.String("")},
+			{Amount: 1, Description: strings.Repeat("d", util.INVOICE_DESCRIPTION_MAX_LENGTH+1)},
+			{Amount: 1, ExternalOrderId: strings.Repeat("o", util.INVOICE_EXTERNAL_ORDER_ID_MAX_LENGTH+1)},
+			{Amount: 1, Metadata: map[string]string{"": "v"}},
+			{Amount: 1, Metadata: map[string]string{"k": strings.Repeat("v", util.INVOICE_METADATA_VALUE_MAX_LENGTH+1)}},
+			{Amount: 1, IdempotencyKey: proto.String(strings.Repeat("k", util.IDEMPOTENCY_KEY_MAX_LENGTH+1))},
+			{CoinOptions: []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_XMR, Amount: 1}, {Coin: pb_v1.CoinType_XMR, Amount: 2}}},
+			{CoinOptions: []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_XMR, Amount: -1}}},
+			{CoinOptions: []*pb_v1.CoinOption{{Coin: pb_v1.CoinType(100), Amount: 1}}},
+			{Coin: pb_v1.CoinType_BTC, ExactAmount: proto.String("0.000000001")},
+			{Coin: pb_v1.CoinType_BTC, ExactAmount: proto.String("-1")},
+			{Coin: pb_v1.CoinType_BTC, ExactAmount: proto.String("1e-8")},
+			{Coin: pb_v1.CoinType_BTC, ExactAmount: proto.String("1"), UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount{UnderpaymentToleranceAmount: 2}},
+			{CoinOptions: []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_USDT_ERC20, ExactAmount: proto.String("1.0000001")}}},
+			{CoinOptions: []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_XMR, ExactAmount: proto.String("-1")}}},
+			{Amount: 1, CoinCode: proto.String("USDC_NOWHERE")},
+			{CoinOptions: []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_XMR, Amount: 1}, {CoinCode: proto.String("XMR"), Amount: 2}}},
+			{
+				CoinOptions:           []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_XMR, Amount: 1}, {Coin: pb_v1.CoinType_BTC, Amount: 0.01}},
+				UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount{UnderpaymentToleranceAmount: 0.001},
+			},
+		}
+
+		for i := 0; i < len(reqs); i++ {
+			err := validateCreateInvoiceRequest(reqs[i])
+			assert.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
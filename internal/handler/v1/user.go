@@ -4,11 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
-	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/chekist32/go-monero/utils"
 	"github.com/chekist32/goipay/internal/db"
 	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
+	"github.com/chekist32/goipay/internal/processor"
 	"github.com/chekist32/goipay/internal/util"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -18,8 +19,9 @@ import (
 )
 
 type UserGrpc struct {
-	dbConnPool *pgxpool.Pool
-	log        *zerolog.Logger
+	dbConnPool       *pgxpool.Pool
+	paymentProcessor *processor.PaymentProcessor
+	log              *zerolog.Logger
 	pb_v1.UnimplementedUserServiceServer
 }
 
@@ -79,6 +81,24 @@ func (u *UserGrpc) RegisterUser(ctx context.Context, in *pb_v1.RegisterUserReque
 	return &pb_v1.RegisterUserResponse{UserId: util.PgUUIDToString(*userId)}, nil
 }
 
+// rotateCryptoKey retires the addresses of the previous key of the coin instead of deleting them, so the invoices pending on them can still be paid,
+// and records the new key as the current version.
+// rotateCryptoKey retires the addresses and the key version of the coin in favour of the new key.
+// privViewKey is set for XMR only.
+func (u *UserGrpc) rotateCryptoKey(ctx context.Context, q *db.Queries, userId pgtype.UUID, coin db.CoinType, publicKey string, privViewKey pgtype.Text) error {
+	if _, err := q.RetireAllCryptoAddressesByUserIdAndCoin(ctx, db.RetireAllCryptoAddressesByUserIdAndCoinParams{UserID: userId, Coin: coin}); err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "RetireAllCryptoAddressesByUserIdAndCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	if _, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: coin, PublicKey: publicKey, PrivViewKey: privViewKey}); err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "CreateCryptoKeyVersion").Msg(util.DefaultFailedSqlQueryMsg)
+		return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	return nil
+}
+
 func (u *UserGrpc) handleXmrCryptoDataUpdate(ctx context.Context, q *db.Queries, in *pb_v1.XmrKeysUpdateRequest, cryptData *db.CryptoDatum) error {
 	if _, err := utils.NewPrivateKey(in.PrivViewKey); err != nil {
 		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("An error occurred while creating the XMR private view key.")
@@ -88,10 +108,16 @@ func (u *UserGrpc) handleXmrCryptoDataUpdate(ctx context.Context, q *db.Queries,
 		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("An error occurred while creating the XMR public spend key.")
 		return status.Error(codes.InvalidArgument, "Invalid XMR public spend key.")
 	}
+	if err := u.paymentProcessor.ValidateXMRKeys(in.PrivViewKey, in.PubSpendKey); err != nil {
+		u.log.Debug().Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("An error occurred while validating the XMR keys.")
+		if errors.Is(err, processor.XMRPrivViewKeyErr) {
+			return status.Error(codes.InvalidArgument, util.XMRPrivViewKeyMsg)
+		}
+		return status.Error(codes.InvalidArgument, util.XMRPubSpendKeyMsg)
+	}
 
-	if _, err := q.DeleteAllCryptoAddressByUserIdAndCoin(ctx, db.DeleteAllCryptoAddressByUserIdAndCoinParams{Coin: db.CoinTypeXMR, UserID: cryptData.UserID}); err != nil {
-		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "DeleteAllCryptoAddressByUserIdAndCoin").Msg(util.DefaultFailedSqlQueryMsg)
-		return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	if err := u.rotateCryptoKey(ctx, q, cryptData.UserID, db.CoinTypeXMR, in.PubSpendKey, pgtype.Text{String: in.PrivViewKey, Valid: true}); err != nil {
+		return err
 	}
 
 	if !cryptData.XmrID.Valid {
@@ -115,6 +141,80 @@ func (u *UserGrpc) handleXmrCryptoDataUpdate(ctx context.Context, q *db.Queries,
 	return nil
 }
 
+func (u *UserGrpc) handleTonCryptoDataUpdate(ctx context.Context, q *db.Queries, in *pb_v1.TonKeysUpdateRequest, cryptData *db.CryptoDatum) error {
+	if _, err := util.ParseTONAddress(in.WalletAddress); err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("An error occurred while parsing the TON wallet address.")
+		return status.Error(codes.InvalidArgument, util.InvalidTONWalletAddressMsg)
+	}
+
+	if err := u.rotateCryptoKey(ctx, q, cryptData.UserID, db.CoinTypeTON, in.WalletAddress, pgtype.Text{}); err != nil {
+		return err
+	}
+
+	if !cryptData.TonID.Valid {
+		tonData, err := q.CreateTONCryptoData(ctx, in.WalletAddress)
+		if err != nil {
+			u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "CreateTONCryptoData").Msg(util.DefaultFailedSqlQueryMsg)
+			return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+		}
+
+		if _, err := q.SetTONCryptoDataByUserId(ctx, db.SetTONCryptoDataByUserIdParams{UserID: cryptData.UserID, TonID: tonData.ID}); err != nil {
+			u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "SetTONCryptoDataByUserId").Msg(util.DefaultFailedSqlQueryMsg)
+			return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+		}
+		return nil
+	}
+
+	if _, err := q.UpdateWalletTONCryptoDataById(ctx, db.UpdateWalletTONCryptoDataByIdParams{ID: cryptData.TonID, WalletAddress: in.WalletAddress}); err != nil {
+		return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	return nil
+}
+
+func (u *UserGrpc) handleEvmCryptoDataUpdate(ctx context.Context, q *db.Queries, in *pb_v1.EvmKeysUpdateRequest, cryptData *db.CryptoDatum) error {
+	chain := db.CoinType(strings.ToUpper(in.Chain))
+	if !util.IsEVMChain(chain) {
+		return status.Error(codes.InvalidArgument, util.InvalidEVMChainMsg)
+	}
+
+	if err := u.validateMasterPubKey(ctx, chain, in.MasterPubKey); err != nil {
+		return err
+	}
+
+	if err := u.rotateCryptoKey(ctx, q, cryptData.UserID, chain, in.MasterPubKey, pgtype.Text{}); err != nil {
+		return err
+	}
+
+	if _, err := q.UpsertKeysEVMCryptoData(ctx, db.UpsertKeysEVMCryptoDataParams{UserID: cryptData.UserID, Chain: string(chain), MasterPubKey: in.MasterPubKey}); err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "UpsertKeysEVMCryptoData").Msg(util.DefaultFailedSqlQueryMsg)
+		return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	return nil
+}
+
+func (u *UserGrpc) validateMasterPubKey(ctx context.Context, coin db.CoinType, masterPubKey string) error {
+	err := u.paymentProcessor.ValidateMasterPubKey(coin, masterPubKey)
+	if err == nil {
+		return nil
+	}
+	u.log.Debug().Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(fmt.Sprintf("An error occurred while validating the %v master public key.", coin))
+
+	switch {
+	case errors.Is(err, processor.PrivateMasterKeyErr):
+		return status.Error(codes.InvalidArgument, fmt.Sprintf(util.PrivateMasterKeyMsg, coin))
+	case errors.Is(err, processor.MasterPubKeyDepthErr):
+		return status.Error(codes.InvalidArgument, fmt.Sprintf(util.MasterPubKeyDepthMsg, coin))
+	case errors.Is(err, processor.MasterPubKeyNetworkErr):
+		return status.Error(codes.InvalidArgument, fmt.Sprintf(util.MasterPubKeyNetworkMsg, coin))
+	case errors.Is(err, util.UnknownExtendedKeyVersionErr):
+		return status.Error(codes.InvalidArgument, fmt.Sprintf(util.UnknownMasterPubKeyVersionMsg, coin))
+	default:
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid %v master public key.", coin))
+	}
+}
+
 func (u *UserGrpc) handleHDKeysCryptoDataUpdate(ctx context.Context, q *db.Queries, masterPubKey string, coin db.CoinType, cryptData *db.CryptoDatum) error {
 	cryptoId, createCryptoCryptoData, setCryptoCryptoDataByUserId, updateKeysCryptoCryptoDataById, err := func() (
 		pgtype.UUID,
@@ -184,6 +284,21 @@ func (u *UserGrpc) handleHDKeysCryptoDataUpdate(ctx context.Context, q *db.Queri
 					return err
 				},
 				nil
+		case db.CoinTypeTRX:
+			return cryptData.TrxID,
+				func(masterPubKey string) (pgtype.UUID, error) {
+					data, err := q.CreateTRXCryptoData(ctx, masterPubKey)
+					return data.ID, err
+				},
+				func(userId, cryptoId pgtype.UUID) error {
+					_, err := q.SetTRXCryptoDataByUserId(ctx, db.SetTRXCryptoDataByUserIdParams{UserID: userId, TrxID: cryptoId})
+					return err
+				},
+				func(cryptoId pgtype.UUID, masterPubKey string) error {
+					_, err := q.UpdateKeysTRXCryptoDataById(ctx, db.UpdateKeysTRXCryptoDataByIdParams{ID: cryptoId, MasterPubKey: masterPubKey})
+					return err
+				},
+				nil
 		default:
 			return pgtype.UUID{}, nil, nil, nil, errors.New("unsupported coin type")
 		}
@@ -192,14 +307,12 @@ func (u *UserGrpc) handleHDKeysCryptoDataUpdate(ctx context.Context, q *db.Queri
 		return err
 	}
 
-	if _, err := hdkeychain.NewKeyFromString(masterPubKey); err != nil {
-		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(fmt.Sprintf("An error occurred while creating the %v master public key.", coin))
-		return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid %v master public key.", coin))
+	if err := u.validateMasterPubKey(ctx, coin, masterPubKey); err != nil {
+		return err
 	}
 
-	if _, err = q.DeleteAllCryptoAddressByUserIdAndCoin(ctx, db.DeleteAllCryptoAddressByUserIdAndCoinParams{Coin: coin, UserID: cryptData.UserID}); err != nil {
-		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "DeleteAllCryptoAddressByUserIdAndCoin").Msg(util.DefaultFailedSqlQueryMsg)
-		return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	if err := u.rotateCryptoKey(ctx, q, cryptData.UserID, coin, masterPubKey, pgtype.Text{}); err != nil {
+		return err
 	}
 
 	if !cryptoId.Valid {
@@ -222,6 +335,35 @@ func (u *UserGrpc) handleHDKeysCryptoDataUpdate(ctx context.Context, q *db.Queri
 	return nil
 }
 
+// handleBitcoinBasedCryptoDataUpdate updates the keys of BTC or LTC along with the script type of their addresses.
+func (u *UserGrpc) handleBitcoinBasedCryptoDataUpdate(ctx context.Context, q *db.Queries, masterPubKey string, addressType pb_v1.AddressType, coin db.CoinType, cryptData *db.CryptoDatum) error {
+	addrType, err := util.PbAddressTypeToDbAddressType(addressType, masterPubKey)
+	if err != nil {
+		u.log.Debug().Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(fmt.Sprintf("An error occurred while mapping the %v address type.", coin))
+		return status.Error(codes.InvalidArgument, util.InvalidAddressTypeMsg)
+	}
+
+	if err := u.handleHDKeysCryptoDataUpdate(ctx, q, masterPubKey, coin, cryptData); err != nil {
+		return err
+	}
+
+	queryName := fmt.Sprintf("UpdateAddressType%vCryptoDataByUserId", coin)
+	switch coin {
+	case db.CoinTypeBTC:
+		_, err = q.UpdateAddressTypeBTCCryptoDataByUserId(ctx, db.UpdateAddressTypeBTCCryptoDataByUserIdParams{UserID: cryptData.UserID, AddressType: addrType})
+	case db.CoinTypeLTC:
+		_, err = q.UpdateAddressTypeLTCCryptoDataByUserId(ctx, db.UpdateAddressTypeLTCCryptoDataByUserIdParams{UserID: cryptData.UserID, AddressType: addrType})
+	default:
+		return errors.New("unsupported coin type")
+	}
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", queryName).Msg(util.DefaultFailedSqlQueryMsg)
+		return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	return nil
+}
+
 func (u *UserGrpc) UpdateCryptoKeys(ctx context.Context, in *pb_v1.UpdateCryptoKeysRequest) (*pb_v1.UpdateCryptoKeysResponse, error) {
 	q, tx, err := util.InitDbQueriesWithTx(ctx, u.dbConnPool)
 	if err != nil {
@@ -253,13 +395,13 @@ func (u *UserGrpc) UpdateCryptoKeys(ctx context.Context, in *pb_v1.UpdateCryptoK
 		}
 	}
 	if in.BtcReq != nil {
-		if err := u.handleHDKeysCryptoDataUpdate(ctx, q, in.BtcReq.MasterPubKey, db.CoinTypeBTC, &cryptData); err != nil {
+		if err := u.handleBitcoinBasedCryptoDataUpdate(ctx, q, in.BtcReq.MasterPubKey, in.BtcReq.AddressType, db.CoinTypeBTC, &cryptData); err != nil {
 			u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("")
 			return nil, err
 		}
 	}
 	if in.LtcReq != nil {
-		if err := u.handleHDKeysCryptoDataUpdate(ctx, q, in.LtcReq.MasterPubKey, db.CoinTypeLTC, &cryptData); err != nil {
+		if err := u.handleBitcoinBasedCryptoDataUpdate(ctx, q, in.LtcReq.MasterPubKey, in.LtcReq.AddressType, db.CoinTypeLTC, &cryptData); err != nil {
 			u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("")
 			return nil, err
 		}
@@ -276,12 +418,109 @@ func (u *UserGrpc) UpdateCryptoKeys(ctx context.Context, in *pb_v1.UpdateCryptoK
 			return nil, err
 		}
 	}
+	if in.TonReq != nil {
+		if err := u.handleTonCryptoDataUpdate(ctx, q, in.TonReq, &cryptData); err != nil {
+			u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("")
+			return nil, err
+		}
+	}
+	if in.TrxReq != nil {
+		if err := u.handleHDKeysCryptoDataUpdate(ctx, q, in.TrxReq.MasterPubKey, db.CoinTypeTRX, &cryptData); err != nil {
+			u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("")
+			return nil, err
+		}
+	}
+	if in.AddressReusePolicy != nil {
+		policy, err := util.PbAddressReusePolicyToDbAddressReusePolicy(*in.AddressReusePolicy)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidAddressReusePolicyMsg)
+		}
+		if _, err := q.UpdateAddressReusePolicyCryptoDataByUserId(ctx, db.UpdateAddressReusePolicyCryptoDataByUserIdParams{UserID: *userId, AddressReusePolicy: policy}); err != nil {
+			u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "UpdateAddressReusePolicyCryptoDataByUserId").Msg(util.DefaultFailedSqlQueryMsg)
+			return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+		}
+	}
+	for i := 0; i < len(in.EvmReqs); i++ {
+		if err := u.handleEvmCryptoDataUpdate(ctx, q, in.EvmReqs[i], &cryptData); err != nil {
+			u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("")
+			return nil, err
+		}
+	}
 
 	tx.Commit(ctx)
 
 	return &pb_v1.UpdateCryptoKeysResponse{}, nil
 }
 
-func NewUserGrpc(dbConnPool *pgxpool.Pool, log *zerolog.Logger) *UserGrpc {
-	return &UserGrpc{dbConnPool: dbConnPool, log: log}
+func validateAddressPoolConfig(minFreeAddresses uint32, gapLimit uint32) error {
+	if minFreeAddresses > util.ADDRESS_POOL_MAX_SIZE || gapLimit > util.ADDRESS_POOL_MAX_SIZE || (gapLimit > 0 && minFreeAddresses > gapLimit) {
+		return status.Error(codes.InvalidArgument, util.InvalidAddressPoolConfigMsg)
+	}
+
+	return nil
+}
+
+func (u *UserGrpc) UpdateAddressPoolConfig(ctx context.Context, in *pb_v1.UpdateAddressPoolConfigRequest) (*pb_v1.UpdateAddressPoolConfigResponse, error) {
+	if err := validateAddressPoolConfig(in.MinFreeAddresses, in.GapLimit); err != nil {
+		return nil, err
+	}
+
+	coin, err := util.PbCoinOrCodeToDbCoin(in.Coin, in.CoinCode)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
+	}
+
+	userId, err := util.StringToPgUUID(in.UserId)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+	}
+	if err := checkIfUserExistsUUID(ctx, u.log, db.New(u.dbConnPool), *userId); err != nil {
+		return nil, err
+	}
+
+	if err := u.paymentProcessor.UpdateAddressPoolConfig(*userId, coin, int32(in.MinFreeAddresses), int32(in.GapLimit)); err != nil {
+		if errors.Is(err, processor.AddressPoolUnsupportedCoinErr) {
+			return nil, status.Error(codes.InvalidArgument, util.AddressPoolUnsupportedCoinMsg)
+		}
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	return &pb_v1.UpdateAddressPoolConfigResponse{}, nil
+}
+
+func (u *UserGrpc) GetAddressPoolStats(ctx context.Context, in *pb_v1.GetAddressPoolStatsRequest) (*pb_v1.GetAddressPoolStatsResponse, error) {
+	coin, err := util.PbCoinOrCodeToDbCoin(in.Coin, in.CoinCode)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
+	}
+
+	userId, err := util.StringToPgUUID(in.UserId)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+	}
+	if err := checkIfUserExistsUUID(ctx, u.log, db.New(u.dbConnPool), *userId); err != nil {
+		return nil, err
+	}
+
+	stats, err := u.paymentProcessor.GetAddressPoolStats(*userId, coin)
+	if err != nil {
+		if errors.Is(err, processor.AddressPoolUnsupportedCoinErr) {
+			return nil, status.Error(codes.InvalidArgument, util.AddressPoolUnsupportedCoinMsg)
+		}
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	return &pb_v1.GetAddressPoolStatsResponse{
+		MinFreeAddresses: uint32(stats.MinFreeAddresses),
+		GapLimit:         uint32(stats.GapLimit),
+		TotalAddresses:   uint64(stats.TotalAddresses),
+		FreeAddresses:    uint64(stats.FreeAddresses),
+		GapAddresses:     uint64(stats.GapAddresses),
+	}, nil
+}
+
+func NewUserGrpc(dbConnPool *pgxpool.Pool, paymentProcessor *processor.PaymentProcessor, log *zerolog.Logger) *UserGrpc {
+	return &UserGrpc{dbConnPool: dbConnPool, paymentProcessor: paymentProcessor, log: log}
 }
@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateAddressPoolConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Pass", func(t *testing.T) {
+		assert.NoError(t, validateAddressPoolConfig(0, 0))
+		assert.NoError(t, validateAddressPoolConfig(5, 20))
+		assert.NoError(t, validateAddressPoolConfig(20, 20))
+		// The gap limit is off.
+		assert.NoError(t, validateAddressPoolConfig(100, 0))
+	})
+
+	t.Run("Should Return InvalidArgument", func(t *testing.T) {
+		cases := [][2]uint32{{21, 20}, {util.ADDRESS_POOL_MAX_SIZE + 1, 0}, {0, util.ADDRESS_POOL_MAX_SIZE + 1}}
+
+		for i := 0; i < len(cases); i++ {
+			err := validateAddressPoolConfig(cases[i][0], cases[i][1])
+			assert.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
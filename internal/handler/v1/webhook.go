@@ -0,0 +1,197 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/chekist32/goipay/internal/db"
+	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/chekist32/goipay/internal/webhook"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type WebhookGrpc struct {
+	dbConnPool *pgxpool.Pool
+	log        *zerolog.Logger
+	pb_v1.UnimplementedWebhookServiceServer
+}
+
+func validateWebhookUrl(rawUrl string) error {
+	u, err := url.ParseRequestURI(rawUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return status.Error(codes.InvalidArgument, util.InvalidWebhookUrlMsg)
+	}
+
+	return nil
+}
+
+func (w *WebhookGrpc) UpdateWebhookEndpoint(ctx context.Context, req *pb_v1.UpdateWebhookEndpointRequest) (*pb_v1.UpdateWebhookEndpointResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, w.dbConnPool)
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := validateWebhookUrl(req.Url); err != nil {
+		return nil, err
+	}
+	if req.Secret != nil && (*req.Secret == "" || req.RotateSecret) {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidWebhookSecretMsg)
+	}
+
+	userId, err := util.StringToPgUUID(req.UserId)
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+	}
+	if err := checkIfUserExistsUUID(ctx, w.log, q, *userId); err != nil {
+		return nil, err
+	}
+
+	// The generated one is used only by a new endpoint or a rotation, an update leaves the stored secret otherwise.
+	secret := req.GetSecret()
+	if secret == "" {
+		secret, err = webhook.GenerateSecret()
+		if err != nil {
+			w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedWebhookSecretGenerationMsg)
+			return nil, status.Error(codes.Internal, util.FailedWebhookSecretGenerationMsg)
+		}
+	}
+
+	endpoint, err := q.UpsertWebhookEndpoint(ctx, db.UpsertWebhookEndpointParams{UserID: *userId, Url: req.Url, Secret: secret, KeepSecret: req.Secret == nil && !req.RotateSecret})
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "UpsertWebhookEndpoint").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.UpdateWebhookEndpointResponse{Secret: endpoint.Secret}, nil
+}
+
+func (w *WebhookGrpc) DeleteWebhookEndpoint(ctx context.Context, req *pb_v1.DeleteWebhookEndpointRequest) (*pb_v1.DeleteWebhookEndpointResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, w.dbConnPool)
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	userId, err := util.StringToPgUUID(req.UserId)
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+	}
+
+	if _, err := q.DeleteWebhookEndpointByUserId(ctx, *userId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, util.WebhookEndpointNotFoundMsg)
+		}
+
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "DeleteWebhookEndpointByUserId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.DeleteWebhookEndpointResponse{}, nil
+}
+
+func (w *WebhookGrpc) ListWebhookDeliveries(ctx context.Context, req *pb_v1.ListWebhookDeliveriesRequest) (*pb_v1.ListWebhookDeliveriesResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, w.dbConnPool)
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = util.LIST_WEBHOOK_DELIVERIES_DEFAULT_LIMIT
+	}
+	if limit > util.LIST_WEBHOOK_DELIVERIES_MAX_LIMIT {
+		return nil, status.Error(codes.InvalidArgument, util.WebhookDeliveryListLimitExceededMsg)
+	}
+
+	userId, err := util.StringToPgUUID(req.UserId)
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+	}
+
+	params := db.FindWebhookDeliveriesByFilterParams{
+		UserID: *userId,
+		Limit:  int32(limit),
+		Offset: int32(req.Offset),
+	}
+	if req.InvoiceId != nil {
+		invoiceId, err := util.StringToPgUUID(*req.InvoiceId)
+		if err != nil {
+			w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+			return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceIdInvalidUUIDMsg)
+		}
+		params.InvoiceID = *invoiceId
+	}
+	if req.Status != nil {
+		deliveryStatus, err := util.PbWebhookDeliveryStatusToDbWebhookDeliveryStatus(*req.Status)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidWebhookDeliveryStatusTypeMsg)
+		}
+		params.Status = db.NullWebhookDeliveryStatusType{WebhookDeliveryStatusType: deliveryStatus, Valid: true}
+	}
+
+	deliveries, err := q.FindWebhookDeliveriesByFilter(ctx, params)
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindWebhookDeliveriesByFilter").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	res := make([]*pb_v1.WebhookDelivery, 0, len(deliveries))
+	for i := 0; i < len(deliveries); i++ {
+		res = append(res, util.DbWebhookDeliveryToPbWebhookDelivery(&deliveries[i]))
+	}
+
+	return &pb_v1.ListWebhookDeliveriesResponse{Deliveries: res}, nil
+}
+
+func (w *WebhookGrpc) RedeliverWebhook(ctx context.Context, req *pb_v1.RedeliverWebhookRequest) (*pb_v1.RedeliverWebhookResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, w.dbConnPool)
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := util.StringToPgUUID(req.Id)
+	if err != nil {
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidWebhookDeliveryIdInvalidUUIDMsg)
+	}
+
+	delivery, err := q.RedeliverWebhookDeliveryById(ctx, *id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, util.WebhookDeliveryNotFoundMsg)
+		}
+
+		w.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "RedeliverWebhookDeliveryById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.RedeliverWebhookResponse{Delivery: util.DbWebhookDeliveryToPbWebhookDelivery(&delivery)}, nil
+}
+
+func NewWebhookGrpc(dbConnPool *pgxpool.Pool, log *zerolog.Logger) *WebhookGrpc {
+	return &WebhookGrpc{dbConnPool: dbConnPool, log: log}
+}
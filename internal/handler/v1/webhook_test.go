@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateWebhookUrl(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Pass", func(t *testing.T) {
+		assert.NoError(t, validateWebhookUrl("https://example.com/webhooks/goipay"))
+		assert.NoError(t, validateWebhookUrl("http://localhost:8080"))
+	})
+
+	t.Run("Should Return InvalidArgument", func(t *testing.T) {
+		urls := []string{"", "example.com/webhook", "ftp://example.com", "https://", "/webhook"}
+
+		for i := 0; i < len(urls); i++ {
+			err := validateWebhookUrl(urls[i])
+			assert.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
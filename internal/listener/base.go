@@ -2,6 +2,7 @@ package listener
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -36,14 +37,91 @@ const (
 	MainnetBNB
 	TestnetBNB
 	PrivateBNB
+
+	MainnetTON
+	TestnetTON
+
+	MainnetTRX
+	ShastaTRX
+	NileTRX
+
+	// Any EVM chain declared in the config, the chain ID of which is checked against the configured one.
+	ConfiguredEVM
 )
 
 type transactionPoolSync struct {
 	txs map[string]bool
 }
 
+type BlockHash struct {
+	Height uint64
+	Hash   string
+}
+
+// ChainReorg is broadcasted once the synced chain turns out to be replaced.
+// The blocks above ForkHeight (the last common one) are rescanned right after.
+type ChainReorg struct {
+	ForkHeight uint64
+	TipHeight  uint64
+}
+
 type blockSync struct {
 	lastBlockHeight atomic.Uint64
+
+	mu sync.Mutex
+	// Ascending by height, at most REORG_DETECTION_WINDOW of the most recent blocks.
+	hashes []BlockHash
+}
+
+func (s *blockSync) hashAt(height uint64) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.hashes) - 1; i >= 0; i-- {
+		if s.hashes[i].Height == height {
+			return s.hashes[i].Hash, true
+		}
+		if s.hashes[i].Height < height {
+			break
+		}
+	}
+
+	return "", false
+}
+
+// truncate drops the hashes of the blocks above the height.
+func (s *blockSync) truncate(height uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := len(s.hashes)
+	for i > 0 && s.hashes[i-1].Height > height {
+		i--
+	}
+	s.hashes = s.hashes[:i]
+}
+
+func (s *blockSync) push(hash BlockHash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The hashes at the height and above are replaced, e.g. on a rescan.
+	i := len(s.hashes)
+	for i > 0 && s.hashes[i-1].Height >= hash.Height {
+		i--
+	}
+	s.hashes = append(s.hashes[:i], hash)
+
+	if len(s.hashes) > util.REORG_DETECTION_WINDOW {
+		s.hashes = s.hashes[len(s.hashes)-util.REORG_DETECTION_WINDOW:]
+	}
+}
+
+func (s *blockSync) snapshot() []BlockHash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]BlockHash(nil), s.hashes...)
 }
 
 type SharedTx interface {
@@ -54,6 +132,8 @@ type SharedTx interface {
 
 type SharedBlock interface {
 	GetTxHashes() []string
+	GetHash() string
+	GetParentHash() string
 }
 
 type SharedDaemonRpcClient[T SharedTx, B SharedBlock] interface {
@@ -70,7 +150,11 @@ type DaemonRpcClientExecutor[T SharedTx, B SharedBlock] interface {
 	Stop()
 	NewBlockChan() <-chan B
 	NewTxPoolChan() <-chan T
+	NewChainReorgChan() <-chan ChainReorg
 	LastSyncedBlockHeight() uint64
+	// LoadBlockHashes restores the persisted hashes of the recent blocks, so a reorg happened while offline is detected as well.
+	LoadBlockHashes(hashes []BlockHash)
+	RecentBlockHashes() []BlockHash
 }
 
 type BaseDaemonRpcClientExecutor[T SharedTx, B SharedBlock] struct {
@@ -83,6 +167,7 @@ type BaseDaemonRpcClientExecutor[T SharedTx, B SharedBlock] struct {
 
 	txPoolChns   *util.SyncMapTypeSafe[string, chan T]
 	newBlockChns *util.SyncMapTypeSafe[string, chan B]
+	reorgChns    *util.SyncMapTypeSafe[string, chan ChainReorg]
 
 	blockSync           blockSync
 	transactionPoolSync transactionPoolSync
@@ -120,6 +205,42 @@ func (d *BaseDaemonRpcClientExecutor[T, B]) broadcastNewTx(tx *T) {
 	})
 }
 
+func (d *BaseDaemonRpcClientExecutor[T, B]) broadcastChainReorg(reorg *ChainReorg) {
+	d.reorgChns.Range(func(key string, cn chan ChainReorg) bool {
+		go func() {
+			select {
+			case cn <- *reorg:
+				return
+			case <-time.After(util.MIN_SYNC_TIMEOUT):
+				d.reorgChns.Delete(key)
+				return
+			}
+		}()
+		return true
+	})
+}
+
+// findForkHeight walks back from the height until the daemon's block matches the synced one.
+// If the fork is deeper than the window, the lowest known height is returned.
+func (d *BaseDaemonRpcClientExecutor[T, B]) findForkHeight(height uint64) (uint64, error) {
+	for {
+		hash, ok := d.blockSync.hashAt(height)
+		if !ok || height == 0 {
+			return height, nil
+		}
+
+		block, err := d.client.GetBlockByHeight(height)
+		if err != nil {
+			return 0, err
+		}
+		if block.GetHash() == hash {
+			return height, nil
+		}
+
+		height--
+	}
+}
+
 func (d *BaseDaemonRpcClientExecutor[T, B]) syncBlock() {
 	height, err := d.client.GetLastBlockHeight()
 	if err != nil {
@@ -136,13 +257,31 @@ func (d *BaseDaemonRpcClientExecutor[T, B]) syncBlock() {
 				return
 			}
 
-			block, err := d.client.GetBlockByHeight(d.blockSync.lastBlockHeight.Load())
+			blockHeight := d.blockSync.lastBlockHeight.Load()
+			block, err := d.client.GetBlockByHeight(blockHeight)
 			if err != nil {
 				d.log.Err(err).Str("method", "GetBlockByHeight").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
 				return
 			}
+
+			if blockHeight > 0 {
+				if parentHash, ok := d.blockSync.hashAt(blockHeight - 1); ok && parentHash != block.GetParentHash() {
+					forkHeight, err := d.findForkHeight(blockHeight - 1)
+					if err != nil {
+						d.log.Err(err).Str("method", "GetBlockByHeight").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
+						return
+					}
+					d.log.Warn().Str("coin", string(d.coin)).Msgf("Chain reorganization detected, rescanning from blockheight: %v", forkHeight+1)
+
+					d.blockSync.truncate(forkHeight)
+					d.blockSync.lastBlockHeight.Store(forkHeight + 1)
+					d.broadcastChainReorg(&ChainReorg{ForkHeight: forkHeight, TipHeight: blockHeight - 1})
+					continue
+				}
+			}
 			d.log.Info().Str("coin", string(d.coin)).Msgf("Synced blockheight: %v", height)
 
+			d.blockSync.push(BlockHash{Height: blockHeight, Hash: block.GetHash()})
 			d.broadcastNewBlock(&block)
 
 			d.blockSync.lastBlockHeight.Add(1)
@@ -232,10 +371,26 @@ func (d *BaseDaemonRpcClientExecutor[T, B]) NewTxPoolChan() <-chan T {
 	return cn
 }
 
+func (d *BaseDaemonRpcClientExecutor[T, B]) NewChainReorgChan() <-chan ChainReorg {
+	cn := make(chan ChainReorg)
+	d.reorgChns.Store(uuid.NewString(), cn)
+	return cn
+}
+
 func (d *BaseDaemonRpcClientExecutor[T, B]) LastSyncedBlockHeight() uint64 {
 	return d.blockSync.lastBlockHeight.Load()
 }
 
+func (d *BaseDaemonRpcClientExecutor[T, B]) LoadBlockHashes(hashes []BlockHash) {
+	for i := 0; i < len(hashes); i++ {
+		d.blockSync.push(hashes[i])
+	}
+}
+
+func (d *BaseDaemonRpcClientExecutor[T, B]) RecentBlockHashes() []BlockHash {
+	return d.blockSync.snapshot()
+}
+
 func NewBaseDaemonRpcClientExecutor[T SharedTx, B SharedBlock](log *zerolog.Logger, client SharedDaemonRpcClient[T, B]) *BaseDaemonRpcClientExecutor[T, B] {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -249,5 +404,6 @@ func NewBaseDaemonRpcClientExecutor[T SharedTx, B SharedBlock](log *zerolog.Logg
 		transactionPoolSync: transactionPoolSync{txs: make(map[string]bool)},
 		txPoolChns:          &util.SyncMapTypeSafe[string, chan T]{},
 		newBlockChns:        &util.SyncMapTypeSafe[string, chan B]{},
+		reorgChns:           &util.SyncMapTypeSafe[string, chan ChainReorg]{},
 	}
 }
@@ -32,12 +32,20 @@ func (t TestTx) IsDoubleSpendSeen() bool {
 }
 
 type TestBlock struct {
-	Height uint64
+	Height     uint64
+	Hash       string
+	ParentHash string
 }
 
 func (b TestBlock) GetTxHashes() []string {
 	return nil
 }
+func (b TestBlock) GetHash() string {
+	return b.Hash
+}
+func (b TestBlock) GetParentHash() string {
+	return b.ParentHash
+}
 
 func TestBlockChan(t *testing.T) {
 	t.Parallel()
@@ -193,6 +201,78 @@ func TestSyncBlock(t *testing.T) {
 
 }
 
+func TestSyncBlockChainReorg(t *testing.T) {
+	t.Parallel()
+
+	// Given
+	d := NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	d.On("GetLastBlockHeight").Return(uint64(14), error(nil))
+
+	block11 := TestBlock{Height: 11, Hash: "a11", ParentHash: "a10"}
+	block12 := TestBlock{Height: 12, Hash: "b12", ParentHash: "a11"}
+	block13 := TestBlock{Height: 13, Hash: "b13", ParentHash: "b12"}
+	d.On("GetBlockByHeight", uint64(11)).Return(block11, error(nil))
+	d.On("GetBlockByHeight", uint64(12)).Return(block12, error(nil))
+	d.On("GetBlockByHeight", uint64(13)).Return(block13, error(nil))
+
+	bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, d)
+	bdrce.LoadBlockHashes([]BlockHash{{Height: 10, Hash: "a10"}, {Height: 11, Hash: "a11"}, {Height: 12, Hash: "a12"}})
+	bdrce.blockSync.lastBlockHeight.Store(13)
+	reorgCn := bdrce.NewChainReorgChan()
+	blockCn := bdrce.NewBlockChan()
+
+	// When
+	bdrce.ctx = context.Background()
+	bdrce.syncBlock()
+
+	// Assert
+	reorg := test.GetValueFromCnOrLogFatalWithTimeout(reorgCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+	assert.Equal(t, ChainReorg{ForkHeight: 11, TipHeight: 12}, reorg)
+
+	blocks := map[uint64]TestBlock{}
+	for i := 0; i < 2; i++ {
+		block := test.GetValueFromCnOrLogFatalWithTimeout(blockCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		blocks[block.Height] = block
+	}
+	assert.Equal(t, map[uint64]TestBlock{12: block12, 13: block13}, blocks)
+
+	assert.Equal(t, uint64(14), bdrce.LastSyncedBlockHeight())
+	assert.Equal(t, []BlockHash{{Height: 10, Hash: "a10"}, {Height: 11, Hash: "a11"}, {Height: 12, Hash: "b12"}, {Height: 13, Hash: "b13"}}, bdrce.RecentBlockHashes())
+}
+
+func TestBlockSyncHashes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Keep Only The Window", func(t *testing.T) {
+		s := blockSync{}
+		for i := 0; i < util.REORG_DETECTION_WINDOW+10; i++ {
+			s.push(BlockHash{Height: uint64(i), Hash: uuid.NewString()})
+		}
+
+		hashes := s.snapshot()
+		assert.Len(t, hashes, util.REORG_DETECTION_WINDOW)
+		assert.Equal(t, uint64(10), hashes[0].Height)
+
+		_, ok := s.hashAt(9)
+		assert.False(t, ok)
+	})
+
+	t.Run("Should Replace The Hashes Above On Push", func(t *testing.T) {
+		s := blockSync{}
+		s.push(BlockHash{Height: 1, Hash: "a1"})
+		s.push(BlockHash{Height: 2, Hash: "a2"})
+		s.push(BlockHash{Height: 3, Hash: "a3"})
+		s.push(BlockHash{Height: 2, Hash: "b2"})
+
+		assert.Equal(t, []BlockHash{{Height: 1, Hash: "a1"}, {Height: 2, Hash: "b2"}}, s.snapshot())
+
+		hash, ok := s.hashAt(2)
+		assert.True(t, ok)
+		assert.Equal(t, "b2", hash)
+	})
+}
+
 func TestSyncTransactionPool(t *testing.T) {
 	t.Parallel()
 
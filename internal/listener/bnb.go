@@ -15,6 +15,12 @@ type BNBBlock ETHBlock
 func (b BNBBlock) GetTxHashes() []string {
 	return ETHBlock(b).GetTxHashes()
 }
+func (b BNBBlock) GetHash() string {
+	return ETHBlock(b).GetHash()
+}
+func (b BNBBlock) GetParentHash() string {
+	return ETHBlock(b).GetParentHash()
+}
 
 type BNBTx ETHTx
 
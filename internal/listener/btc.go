@@ -20,6 +20,12 @@ func (b BTCBlock) GetTxHashes() []string {
 
 	return txHashes
 }
+func (b BTCBlock) GetHash() string {
+	return b.Header.BlockHash().String()
+}
+func (b BTCBlock) GetParentHash() string {
+	return b.Header.PrevBlock.String()
+}
 
 type BTCTx btcjson.TxRawResult
 
@@ -24,6 +24,12 @@ func (b ETHBlock) GetTxHashes() []string {
 
 	return txHashes
 }
+func (b ETHBlock) GetHash() string {
+	return b.block.Hash().Hex()
+}
+func (b ETHBlock) GetParentHash() string {
+	return b.block.ParentHash().Hex()
+}
 
 type ETHTx struct {
 	Tx            *types.Transaction
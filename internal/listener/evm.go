@@ -0,0 +1,40 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// SharedEVMDaemonRpcClient serves an EVM chain declared in the config, the native coin of which is named after the chain.
+type SharedEVMDaemonRpcClient struct {
+	SharedETHDaemonRpcClient
+
+	coin    db.CoinType
+	chainId uint64
+}
+
+func (c *SharedEVMDaemonRpcClient) GetNetworkType() (NetworkType, error) {
+	chainId, err := c.client.ChainID(context.Background())
+	if err != nil {
+		return 255, err
+	}
+	if chainId.Uint64() != c.chainId {
+		return 255, fmt.Errorf("unexpected chain id of the %v daemon: %v (expected %v)", c.coin, chainId, c.chainId)
+	}
+
+	return ConfiguredEVM, nil
+}
+func (c *SharedEVMDaemonRpcClient) GetCoinType() db.CoinType {
+	return c.coin
+}
+
+func NewSharedEVMDaemonRpcClient(client *ethclient.Client, coin db.CoinType, chainId uint64) *SharedEVMDaemonRpcClient {
+	return &SharedEVMDaemonRpcClient{
+		SharedETHDaemonRpcClient: *NewSharedETHDaemonRpcClient(client),
+		coin:                     coin,
+		chainId:                  chainId,
+	}
+}
@@ -15,6 +15,12 @@ type LTCBlock BTCBlock
 func (b LTCBlock) GetTxHashes() []string {
 	return BTCBlock(b).GetTxHashes()
 }
+func (b LTCBlock) GetHash() string {
+	return BTCBlock(b).GetHash()
+}
+func (b LTCBlock) GetParentHash() string {
+	return BTCBlock(b).GetParentHash()
+}
 
 type LTCTx BTCTx
 
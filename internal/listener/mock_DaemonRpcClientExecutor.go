@@ -27,6 +27,11 @@ func (_m *MockDaemonRpcClientExecutor[T, B]) LastSyncedBlockHeight() uint64 {
 	return r0
 }
 
+// LoadBlockHashes provides a mock function with given fields: hashes
+func (_m *MockDaemonRpcClientExecutor[T, B]) LoadBlockHashes(hashes []BlockHash) {
+	_m.Called(hashes)
+}
+
 // NewBlockChan provides a mock function with no fields
 func (_m *MockDaemonRpcClientExecutor[T, B]) NewBlockChan() <-chan B {
 	ret := _m.Called()
@@ -47,6 +52,26 @@ func (_m *MockDaemonRpcClientExecutor[T, B]) NewBlockChan() <-chan B {
 	return r0
 }
 
+// NewChainReorgChan provides a mock function with no fields
+func (_m *MockDaemonRpcClientExecutor[T, B]) NewChainReorgChan() <-chan ChainReorg {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewChainReorgChan")
+	}
+
+	var r0 <-chan ChainReorg
+	if rf, ok := ret.Get(0).(func() <-chan ChainReorg); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan ChainReorg)
+		}
+	}
+
+	return r0
+}
+
 // NewTxPoolChan provides a mock function with no fields
 func (_m *MockDaemonRpcClientExecutor[T, B]) NewTxPoolChan() <-chan T {
 	ret := _m.Called()
@@ -67,6 +92,26 @@ func (_m *MockDaemonRpcClientExecutor[T, B]) NewTxPoolChan() <-chan T {
 	return r0
 }
 
+// RecentBlockHashes provides a mock function with no fields
+func (_m *MockDaemonRpcClientExecutor[T, B]) RecentBlockHashes() []BlockHash {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecentBlockHashes")
+	}
+
+	var r0 []BlockHash
+	if rf, ok := ret.Get(0).(func() []BlockHash); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BlockHash)
+		}
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields: startBlock
 func (_m *MockDaemonRpcClientExecutor[T, B]) Start(startBlock uint64) {
 	_m.Called(startBlock)
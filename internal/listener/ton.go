@@ -0,0 +1,316 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/rs/zerolog"
+)
+
+const (
+	tonMainnetGlobalId int32 = -239
+	tonTestnetGlobalId int32 = -3
+
+	tonMasterchain int32 = -1
+)
+
+type TONBlock struct {
+	Seqno      uint64
+	Hash       string
+	ParentHash string
+	Txs        []TONTx
+}
+
+func (b TONBlock) GetTxHashes() []string {
+	txHashes := make([]string, 0, len(b.Txs))
+	for i := 0; i < len(b.Txs); i++ {
+		txHashes = append(txHashes, b.Txs[i].Hash)
+	}
+
+	return txHashes
+}
+func (b TONBlock) GetHash() string {
+	return b.Hash
+}
+func (b TONBlock) GetParentHash() string {
+	return b.ParentHash
+}
+
+type TONMessage struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	// In nanotons.
+	Value          string `json:"value"`
+	MessageContent struct {
+		// Base64 encoded bag of cells.
+		Body string `json:"body"`
+	} `json:"message_content"`
+}
+
+type TONTx struct {
+	Account      string `json:"account"`
+	Hash         string `json:"hash"`
+	McBlockSeqno uint64 `json:"mc_block_seqno"`
+	Description  struct {
+		Aborted bool `json:"aborted"`
+	} `json:"description"`
+	InMsg *TONMessage `json:"in_msg"`
+
+	Confirmations uint64 `json:"-"`
+}
+
+func (t TONTx) GetTxId() string {
+	return t.Hash
+}
+func (t TONTx) GetConfirmations() uint64 {
+	return t.Confirmations
+}
+
+// IsDoubleSpendSeen reports the aborted txs, as TON has no mempool double spends, while the aborted txs don't credit the account.
+func (t TONTx) IsDoubleSpendSeen() bool {
+	return t.Description.Aborted
+}
+
+type TONJettonWallet struct {
+	Address string `json:"address"`
+	Owner   string `json:"owner"`
+	Jetton  string `json:"jetton"`
+}
+
+type tonBlockId struct {
+	Seqno    uint64 `json:"seqno"`
+	RootHash string `json:"root_hash"`
+	GlobalId int32  `json:"global_id"`
+}
+
+// SharedTONDaemonRpcClient talks to a toncenter v3 compatible HTTP API.
+// Only the masterchain blocks are synced, each of them along with the txs of its shard blocks.
+type SharedTONDaemonRpcClient struct {
+	client *http.Client
+	url    string
+	apiKey string
+
+	mu sync.Mutex
+	// The txs of the last fetched block, as they are requested right after the block.
+	lastBlockTxs map[string]TONTx
+
+	jettonWallets *util.SyncMapTypeSafe[string, TONJettonWallet]
+}
+
+func (c *SharedTONDaemonRpcClient) get(path string, query url.Values, res any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), util.TON_DAEMON_TIMEOUT)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	httpRes, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code of the TON daemon response: %v", httpRes.StatusCode)
+	}
+
+	return json.NewDecoder(httpRes.Body).Decode(res)
+}
+
+func (c *SharedTONDaemonRpcClient) getLastBlock() (tonBlockId, error) {
+	var res struct {
+		Last tonBlockId `json:"last"`
+	}
+	if err := c.get("/api/v3/masterchainInfo", url.Values{}, &res); err != nil {
+		return tonBlockId{}, err
+	}
+
+	return res.Last, nil
+}
+
+func (c *SharedTONDaemonRpcClient) getTransactions(path string, query url.Values) ([]TONTx, error) {
+	txs := make([]TONTx, 0)
+
+	query.Set("limit", strconv.Itoa(util.TON_DAEMON_PAGE_LIMIT))
+	for offset := 0; ; offset += util.TON_DAEMON_PAGE_LIMIT {
+		query.Set("offset", strconv.Itoa(offset))
+
+		var res struct {
+			Transactions []TONTx `json:"transactions"`
+		}
+		if err := c.get(path, query, &res); err != nil {
+			return nil, err
+		}
+		txs = append(txs, res.Transactions...)
+
+		if len(res.Transactions) < util.TON_DAEMON_PAGE_LIMIT {
+			return txs, nil
+		}
+	}
+}
+
+func (c *SharedTONDaemonRpcClient) GetLastBlockHeight() (uint64, error) {
+	block, err := c.getLastBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	return block.Seqno, nil
+}
+func (c *SharedTONDaemonRpcClient) GetBlockByHeight(height uint64) (TONBlock, error) {
+	startSeqno := height
+	if startSeqno > 0 {
+		startSeqno--
+	}
+
+	var res struct {
+		Blocks []tonBlockId `json:"blocks"`
+	}
+	query := url.Values{
+		"workchain":   {strconv.Itoa(int(tonMasterchain))},
+		"start_seqno": {strconv.FormatUint(startSeqno, 10)},
+		"end_seqno":   {strconv.FormatUint(height, 10)},
+	}
+	if err := c.get("/api/v3/blocks", query, &res); err != nil {
+		return TONBlock{}, err
+	}
+
+	block := TONBlock{Seqno: height}
+	found := false
+	for i := 0; i < len(res.Blocks); i++ {
+		switch res.Blocks[i].Seqno {
+		case height:
+			block.Hash = res.Blocks[i].RootHash
+			found = true
+		case height - 1:
+			block.ParentHash = res.Blocks[i].RootHash
+		}
+	}
+	if !found {
+		return TONBlock{}, fmt.Errorf("TON masterchain block %v not found", height)
+	}
+
+	txs, err := c.getTransactions("/api/v3/transactionsByMasterchainBlock", url.Values{"seqno": {strconv.FormatUint(height, 10)}})
+	if err != nil {
+		return TONBlock{}, err
+	}
+	block.Txs = txs
+
+	lastBlockTxs := make(map[string]TONTx, len(block.Txs))
+	for i := 0; i < len(block.Txs); i++ {
+		lastBlockTxs[block.Txs[i].Hash] = block.Txs[i]
+	}
+	c.mu.Lock()
+	c.lastBlockTxs = lastBlockTxs
+	c.mu.Unlock()
+
+	return block, nil
+}
+func (c *SharedTONDaemonRpcClient) GetTransactionPool() ([]string, error) {
+	return []string{}, nil
+}
+func (c *SharedTONDaemonRpcClient) GetTransactions(txHashes []string) ([]TONTx, error) {
+	lastBlockHeight, err := c.GetLastBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	lastBlockTxs := c.lastBlockTxs
+	c.mu.Unlock()
+
+	txs := make([]TONTx, 0, len(txHashes))
+	for i := 0; i < len(txHashes); i++ {
+		tx, ok := lastBlockTxs[txHashes[i]]
+		if !ok {
+			res, err := c.getTransactions("/api/v3/transactions", url.Values{"hash": {txHashes[i]}})
+			if err != nil {
+				return nil, err
+			}
+			if len(res) < 1 {
+				return nil, fmt.Errorf("TON transaction %v not found", txHashes[i])
+			}
+			tx = res[0]
+		}
+
+		if lastBlockHeight > tx.McBlockSeqno {
+			tx.Confirmations = lastBlockHeight - tx.McBlockSeqno
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+func (c *SharedTONDaemonRpcClient) GetNetworkType() (NetworkType, error) {
+	block, err := c.getLastBlock()
+	if err != nil {
+		return 255, err
+	}
+
+	switch block.GlobalId {
+	case tonMainnetGlobalId:
+		return MainnetTON, nil
+	case tonTestnetGlobalId:
+		return TestnetTON, nil
+	default:
+		return 255, util.InvalidNetworkTypeErr
+	}
+}
+func (c *SharedTONDaemonRpcClient) GetCoinType() db.CoinType {
+	return db.CoinTypeTON
+}
+
+// GetJettonWallet returns the owner and the jetton master of the jetton wallet. Both never change, so they are cached.
+func (c *SharedTONDaemonRpcClient) GetJettonWallet(address string) (TONJettonWallet, error) {
+	if wallet, ok := c.jettonWallets.Load(address); ok {
+		return wallet, nil
+	}
+
+	var res struct {
+		JettonWallets []TONJettonWallet `json:"jetton_wallets"`
+	}
+	if err := c.get("/api/v3/jetton/wallets", url.Values{"address": {address}, "limit": {"1"}}, &res); err != nil {
+		return TONJettonWallet{}, err
+	}
+	if len(res.JettonWallets) < 1 {
+		return TONJettonWallet{}, fmt.Errorf("TON jetton wallet %v not found", address)
+	}
+
+	c.jettonWallets.Store(address, res.JettonWallets[0])
+
+	return res.JettonWallets[0], nil
+}
+
+func NewSharedTONDaemonRpcClient(client *http.Client, url string, apiKey string) *SharedTONDaemonRpcClient {
+	return &SharedTONDaemonRpcClient{
+		client:        client,
+		url:           strings.TrimRight(url, "/"),
+		apiKey:        apiKey,
+		lastBlockTxs:  make(map[string]TONTx),
+		jettonWallets: &util.SyncMapTypeSafe[string, TONJettonWallet]{},
+	}
+}
+
+type TONDaemonRpcClientExecutor struct {
+	BaseDaemonRpcClientExecutor[TONTx, TONBlock]
+}
+
+func NewTONDaemonRpcClientExecutor(log *zerolog.Logger, client *SharedTONDaemonRpcClient) *TONDaemonRpcClientExecutor {
+	return &TONDaemonRpcClientExecutor{
+		BaseDaemonRpcClientExecutor: *NewBaseDaemonRpcClientExecutor(log, client),
+	}
+}
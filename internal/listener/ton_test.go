@@ -0,0 +1,115 @@
+package listener
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTONDaemonStub(t *testing.T, globalId string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v3/masterchainInfo":
+			w.Write([]byte(`{"last":{"workchain":-1,"seqno":105,"root_hash":"h105","global_id":` + globalId + `}}`))
+		case "/api/v3/blocks":
+			assert.Equal(t, "-1", q.Get("workchain"))
+			assert.Equal(t, "99", q.Get("start_seqno"))
+			assert.Equal(t, "100", q.Get("end_seqno"))
+			w.Write([]byte(`{"blocks":[{"seqno":99,"root_hash":"h99"},{"seqno":100,"root_hash":"h100"}]}`))
+		case "/api/v3/transactionsByMasterchainBlock":
+			assert.Equal(t, "100", q.Get("seqno"))
+			if q.Get("offset") != "0" {
+				w.Write([]byte(`{"transactions":[]}`))
+				return
+			}
+			w.Write([]byte(`{"transactions":[
+				{"account":"0:AA","hash":"tx1","mc_block_seqno":100,"description":{"aborted":false},"in_msg":{"source":"0:BB","destination":"0:AA","value":"1500000000","message_content":{"body":"te6cckEBAQEABgAACAAAAAA="}}},
+				{"account":"0:CC","hash":"tx2","mc_block_seqno":100,"description":{"aborted":true},"in_msg":null}
+			]}`))
+		case "/api/v3/transactions":
+			if q.Get("hash") != "tx3" {
+				w.Write([]byte(`{"transactions":[]}`))
+				return
+			}
+			w.Write([]byte(`{"transactions":[{"account":"0:AA","hash":"tx3","mc_block_seqno":90,"description":{"aborted":false}}]}`))
+		case "/api/v3/jetton/wallets":
+			w.Write([]byte(`{"jetton_wallets":[{"address":"` + q.Get("address") + `","owner":"0:AA","jetton":"0:DD"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSharedTONDaemonRpcClient(t *testing.T) {
+	server := newTONDaemonStub(t, "-239")
+	defer server.Close()
+
+	client := NewSharedTONDaemonRpcClient(server.Client(), server.URL+"/", "secret")
+
+	t.Run("Should Return Network Type", func(t *testing.T) {
+		net, err := client.GetNetworkType()
+		assert.NoError(t, err)
+		assert.Equal(t, MainnetTON, net)
+
+		testnet := newTONDaemonStub(t, "-3")
+		defer testnet.Close()
+		net, err = NewSharedTONDaemonRpcClient(testnet.Client(), testnet.URL, "secret").GetNetworkType()
+		assert.NoError(t, err)
+		assert.Equal(t, TestnetTON, net)
+
+		unknown := newTONDaemonStub(t, "42")
+		defer unknown.Close()
+		_, err = NewSharedTONDaemonRpcClient(unknown.Client(), unknown.URL, "secret").GetNetworkType()
+		assert.Error(t, err)
+	})
+
+	t.Run("Should Return Last Block Height", func(t *testing.T) {
+		height, err := client.GetLastBlockHeight()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(105), height)
+	})
+
+	t.Run("Should Return Block With Txs", func(t *testing.T) {
+		block, err := client.GetBlockByHeight(100)
+		assert.NoError(t, err)
+		assert.Equal(t, "h100", block.GetHash())
+		assert.Equal(t, "h99", block.GetParentHash())
+		assert.Equal(t, []string{"tx1", "tx2"}, block.GetTxHashes())
+		assert.Equal(t, "1500000000", block.Txs[0].InMsg.Value)
+		assert.Nil(t, block.Txs[1].InMsg)
+	})
+
+	t.Run("Should Return Txs With Confirmations", func(t *testing.T) {
+		txs, err := client.GetTransactions([]string{"tx1", "tx2", "tx3"})
+		assert.NoError(t, err)
+		assert.Len(t, txs, 3)
+
+		assert.Equal(t, uint64(5), txs[0].GetConfirmations())
+		assert.False(t, txs[0].IsDoubleSpendSeen())
+		assert.True(t, txs[1].IsDoubleSpendSeen())
+		assert.Equal(t, "tx3", txs[2].GetTxId())
+		assert.Equal(t, uint64(15), txs[2].GetConfirmations())
+	})
+
+	t.Run("Should Return Error (tx not found)", func(t *testing.T) {
+		_, err := client.GetTransactions([]string{"tx4"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Should Return Jetton Wallet", func(t *testing.T) {
+		wallet, err := client.GetJettonWallet("0:EE")
+		assert.NoError(t, err)
+		assert.Equal(t, TONJettonWallet{Address: "0:EE", Owner: "0:AA", Jetton: "0:DD"}, wallet)
+	})
+
+	t.Run("Should Return Empty Transaction Pool", func(t *testing.T) {
+		txs, err := client.GetTransactionPool()
+		assert.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+}
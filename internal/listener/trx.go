@@ -0,0 +1,289 @@
+package listener
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/rs/zerolog"
+)
+
+const (
+	trxMainnetGenesisBlockId string = "00000000000000001ebf88508a03865c71d452e25f4d51194196a1d22b6653dc"
+	trxShastaGenesisBlockId  string = "0000000000000000de1aa88295e1fcf982742f773e0419c5a9c134c994a9059e"
+	trxNileGenesisBlockId    string = "0000000000000000d698d4192c56cb6be724a558448e2684802de4d6cd8690dc"
+
+	trxSuccessResult string = "SUCCESS"
+)
+
+type TRXBlock struct {
+	BlockID     string `json:"blockID"`
+	BlockHeader struct {
+		RawData struct {
+			Number     uint64 `json:"number"`
+			ParentHash string `json:"parentHash"`
+		} `json:"raw_data"`
+	} `json:"block_header"`
+	Transactions []TRXTx `json:"transactions"`
+}
+
+func (b TRXBlock) GetTxHashes() []string {
+	txHashes := make([]string, 0, len(b.Transactions))
+	for i := 0; i < len(b.Transactions); i++ {
+		txHashes = append(txHashes, b.Transactions[i].TxID)
+	}
+
+	return txHashes
+}
+func (b TRXBlock) GetHash() string {
+	return b.BlockID
+}
+func (b TRXBlock) GetParentHash() string {
+	return b.BlockHeader.RawData.ParentHash
+}
+
+type TRXContract struct {
+	Type      string `json:"type"`
+	Parameter struct {
+		Value struct {
+			// In sun, set for the TransferContract.
+			Amount       int64  `json:"amount"`
+			OwnerAddress string `json:"owner_address"`
+			ToAddress    string `json:"to_address"`
+		} `json:"value"`
+	} `json:"parameter"`
+}
+
+type TRXLog struct {
+	// The hex of the contract address without the "41" prefix.
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+type TRXTxInfo struct {
+	Id          string `json:"id"`
+	BlockNumber uint64 `json:"blockNumber"`
+	Receipt     struct {
+		// Set for the smart contract calls only.
+		Result string `json:"result"`
+	} `json:"receipt"`
+	Log []TRXLog `json:"log"`
+}
+
+type TRXTx struct {
+	TxID string `json:"txID"`
+	Ret  []struct {
+		ContractRet string `json:"contractRet"`
+	} `json:"ret"`
+	RawData struct {
+		Contract []TRXContract `json:"contract"`
+	} `json:"raw_data"`
+
+	Info          TRXTxInfo `json:"-"`
+	Confirmations uint64    `json:"-"`
+}
+
+func (t TRXTx) GetTxId() string {
+	return t.TxID
+}
+func (t TRXTx) GetConfirmations() uint64 {
+	return t.Confirmations
+}
+func (t TRXTx) IsDoubleSpendSeen() bool {
+	return (len(t.Ret) > 0 && t.Ret[0].ContractRet != trxSuccessResult) ||
+		(t.Info.Receipt.Result != "" && t.Info.Receipt.Result != trxSuccessResult)
+}
+
+// SharedTRXDaemonRpcClient talks to the HTTP API of a TRON full node (or TronGrid).
+type SharedTRXDaemonRpcClient struct {
+	client *http.Client
+	url    string
+	apiKey string
+
+	mu sync.Mutex
+	// The txs of the last fetched block, as they are requested right after the block.
+	lastBlockTxs map[string]TRXTx
+}
+
+func (c *SharedTRXDaemonRpcClient) post(path string, body any, res any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), util.TRX_DAEMON_TIMEOUT)
+	defer cancel()
+
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("TRON-PRO-API-KEY", c.apiKey)
+	}
+
+	httpRes, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code of the TRX daemon response: %v", httpRes.StatusCode)
+	}
+
+	return json.NewDecoder(httpRes.Body).Decode(res)
+}
+
+func (c *SharedTRXDaemonRpcClient) getBlockByNum(num uint64) (TRXBlock, error) {
+	var block TRXBlock
+	if err := c.post("/wallet/getblockbynum", map[string]any{"num": num, "visible": true}, &block); err != nil {
+		return block, err
+	}
+	if block.BlockID == "" {
+		return block, fmt.Errorf("TRX block %v not found", num)
+	}
+
+	return block, nil
+}
+
+// getTxInfosByBlockNum returns the infos (i.e. the receipts and the event logs) of all the txs of the block at once.
+func (c *SharedTRXDaemonRpcClient) getTxInfosByBlockNum(num uint64) ([]TRXTxInfo, error) {
+	var res json.RawMessage
+	if err := c.post("/wallet/gettransactioninfobyblocknum", map[string]any{"num": num}, &res); err != nil {
+		return nil, err
+	}
+
+	// An empty block comes as an empty object.
+	infos := make([]TRXTxInfo, 0)
+	if !strings.HasPrefix(strings.TrimSpace(string(res)), "[") {
+		return infos, nil
+	}
+	if err := json.Unmarshal(res, &infos); err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+func (c *SharedTRXDaemonRpcClient) GetLastBlockHeight() (uint64, error) {
+	var block TRXBlock
+	if err := c.post("/wallet/getnowblock", map[string]any{"visible": true}, &block); err != nil {
+		return 0, err
+	}
+
+	return block.BlockHeader.RawData.Number, nil
+}
+func (c *SharedTRXDaemonRpcClient) GetBlockByHeight(height uint64) (TRXBlock, error) {
+	block, err := c.getBlockByNum(height)
+	if err != nil {
+		return block, err
+	}
+
+	infos, err := c.getTxInfosByBlockNum(height)
+	if err != nil {
+		return block, err
+	}
+	infosById := make(map[string]TRXTxInfo, len(infos))
+	for i := 0; i < len(infos); i++ {
+		infosById[infos[i].Id] = infos[i]
+	}
+
+	lastBlockTxs := make(map[string]TRXTx, len(block.Transactions))
+	for i := 0; i < len(block.Transactions); i++ {
+		tx := &block.Transactions[i]
+		tx.Info = infosById[tx.TxID]
+		tx.Info.BlockNumber = height
+		lastBlockTxs[tx.TxID] = *tx
+	}
+	c.mu.Lock()
+	c.lastBlockTxs = lastBlockTxs
+	c.mu.Unlock()
+
+	return block, nil
+}
+func (c *SharedTRXDaemonRpcClient) GetTransactionPool() ([]string, error) {
+	return []string{}, nil
+}
+func (c *SharedTRXDaemonRpcClient) GetTransactions(txHashes []string) ([]TRXTx, error) {
+	lastBlockHeight, err := c.GetLastBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	lastBlockTxs := c.lastBlockTxs
+	c.mu.Unlock()
+
+	txs := make([]TRXTx, 0, len(txHashes))
+	for i := 0; i < len(txHashes); i++ {
+		tx, ok := lastBlockTxs[txHashes[i]]
+		if !ok {
+			if err := c.post("/wallet/gettransactionbyid", map[string]any{"value": txHashes[i], "visible": true}, &tx); err != nil {
+				return nil, err
+			}
+			if tx.TxID == "" {
+				return nil, fmt.Errorf("TRX transaction %v not found", txHashes[i])
+			}
+			if err := c.post("/wallet/gettransactioninfobyid", map[string]any{"value": txHashes[i]}, &tx.Info); err != nil {
+				return nil, err
+			}
+		}
+
+		// The info of a tx not in a block yet is empty.
+		if tx.Info.BlockNumber > 0 && lastBlockHeight > tx.Info.BlockNumber {
+			tx.Confirmations = lastBlockHeight - tx.Info.BlockNumber
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+func (c *SharedTRXDaemonRpcClient) GetNetworkType() (NetworkType, error) {
+	genesis, err := c.getBlockByNum(0)
+	if err != nil {
+		return 255, err
+	}
+
+	switch genesis.BlockID {
+	case trxMainnetGenesisBlockId:
+		return MainnetTRX, nil
+	case trxShastaGenesisBlockId:
+		return ShastaTRX, nil
+	case trxNileGenesisBlockId:
+		return NileTRX, nil
+	default:
+		return 255, util.InvalidNetworkTypeErr
+	}
+}
+func (c *SharedTRXDaemonRpcClient) GetCoinType() db.CoinType {
+	return db.CoinTypeTRX
+}
+
+func NewSharedTRXDaemonRpcClient(client *http.Client, url string, apiKey string) *SharedTRXDaemonRpcClient {
+	return &SharedTRXDaemonRpcClient{
+		client:       client,
+		url:          strings.TrimRight(url, "/"),
+		apiKey:       apiKey,
+		lastBlockTxs: make(map[string]TRXTx),
+	}
+}
+
+type TRXDaemonRpcClientExecutor struct {
+	BaseDaemonRpcClientExecutor[TRXTx, TRXBlock]
+}
+
+func NewTRXDaemonRpcClientExecutor(log *zerolog.Logger, client *SharedTRXDaemonRpcClient) *TRXDaemonRpcClientExecutor {
+	return &TRXDaemonRpcClientExecutor{
+		BaseDaemonRpcClientExecutor: *NewBaseDaemonRpcClientExecutor(log, client),
+	}
+}
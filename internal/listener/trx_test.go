@@ -0,0 +1,133 @@
+package listener
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTRXDaemonStub(t *testing.T, genesisBlockId string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "secret", r.Header.Get("TRON-PRO-API-KEY"))
+
+		var body struct {
+			Num   uint64 `json:"num"`
+			Value string `json:"value"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/wallet/getnowblock":
+			w.Write([]byte(`{"blockID":"b110","block_header":{"raw_data":{"number":110,"parentHash":"b109"}}}`))
+		case "/wallet/getblockbynum":
+			switch body.Num {
+			case 0:
+				w.Write([]byte(`{"blockID":"` + genesisBlockId + `","block_header":{"raw_data":{}}}`))
+			case 100:
+				w.Write([]byte(`{"blockID":"b100","block_header":{"raw_data":{"number":100,"parentHash":"b99"}},"transactions":[
+					{"txID":"tx1","ret":[{"contractRet":"SUCCESS"}],"raw_data":{"contract":[{"type":"TransferContract","parameter":{"value":{"amount":1500000,"owner_address":"TA","to_address":"TB"}}}]}},
+					{"txID":"tx2","ret":[{"contractRet":"SUCCESS"}],"raw_data":{"contract":[{"type":"TriggerSmartContract","parameter":{"value":{"owner_address":"TA"}}}]}}
+				]}`))
+			case 101:
+				w.Write([]byte(`{"blockID":"b101","block_header":{"raw_data":{"number":101,"parentHash":"b100"}}}`))
+			default:
+				w.Write([]byte(`{}`))
+			}
+		case "/wallet/gettransactioninfobyblocknum":
+			if body.Num != 100 {
+				w.Write([]byte(`{}`))
+				return
+			}
+			w.Write([]byte(`[
+				{"id":"tx1","blockNumber":100},
+				{"id":"tx2","blockNumber":100,"receipt":{"result":"REVERT"},"log":[{"address":"a614f803b6fd780986a42c78ec9c7f77e6ded13c","topics":["ddf252ad"],"data":"01"}]}
+			]`))
+		case "/wallet/gettransactionbyid":
+			if body.Value != "tx3" {
+				w.Write([]byte(`{}`))
+				return
+			}
+			w.Write([]byte(`{"txID":"tx3","ret":[{"contractRet":"SUCCESS"}],"raw_data":{"contract":[]}}`))
+		case "/wallet/gettransactioninfobyid":
+			w.Write([]byte(`{"id":"tx3","blockNumber":90}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSharedTRXDaemonRpcClient(t *testing.T) {
+	server := newTRXDaemonStub(t, trxMainnetGenesisBlockId)
+	defer server.Close()
+
+	client := NewSharedTRXDaemonRpcClient(server.Client(), server.URL+"/", "secret")
+
+	t.Run("Should Return Network Type", func(t *testing.T) {
+		net, err := client.GetNetworkType()
+		assert.NoError(t, err)
+		assert.Equal(t, MainnetTRX, net)
+
+		nile := newTRXDaemonStub(t, trxNileGenesisBlockId)
+		defer nile.Close()
+		net, err = NewSharedTRXDaemonRpcClient(nile.Client(), nile.URL, "secret").GetNetworkType()
+		assert.NoError(t, err)
+		assert.Equal(t, NileTRX, net)
+
+		unknown := newTRXDaemonStub(t, "00")
+		defer unknown.Close()
+		_, err = NewSharedTRXDaemonRpcClient(unknown.Client(), unknown.URL, "secret").GetNetworkType()
+		assert.Error(t, err)
+	})
+
+	t.Run("Should Return Last Block Height", func(t *testing.T) {
+		height, err := client.GetLastBlockHeight()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(110), height)
+	})
+
+	t.Run("Should Return Block With Tx Infos", func(t *testing.T) {
+		block, err := client.GetBlockByHeight(100)
+		assert.NoError(t, err)
+		assert.Equal(t, "b100", block.GetHash())
+		assert.Equal(t, "b99", block.GetParentHash())
+		assert.Equal(t, []string{"tx1", "tx2"}, block.GetTxHashes())
+		assert.Equal(t, int64(1500000), block.Transactions[0].RawData.Contract[0].Parameter.Value.Amount)
+		assert.Equal(t, "TB", block.Transactions[0].RawData.Contract[0].Parameter.Value.ToAddress)
+		assert.Len(t, block.Transactions[1].Info.Log, 1)
+	})
+
+	t.Run("Should Return Empty Block", func(t *testing.T) {
+		block, err := client.GetBlockByHeight(101)
+		assert.NoError(t, err)
+		assert.Empty(t, block.GetTxHashes())
+	})
+
+	t.Run("Should Return Error (block not found)", func(t *testing.T) {
+		_, err := client.GetBlockByHeight(102)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should Return Txs With Confirmations", func(t *testing.T) {
+		_, err := client.GetBlockByHeight(100)
+		assert.NoError(t, err)
+
+		txs, err := client.GetTransactions([]string{"tx1", "tx2", "tx3"})
+		assert.NoError(t, err)
+		assert.Len(t, txs, 3)
+
+		assert.Equal(t, uint64(10), txs[0].GetConfirmations())
+		assert.False(t, txs[0].IsDoubleSpendSeen())
+		assert.True(t, txs[1].IsDoubleSpendSeen())
+		assert.Equal(t, "tx3", txs[2].GetTxId())
+		assert.Equal(t, uint64(20), txs[2].GetConfirmations())
+	})
+
+	t.Run("Should Return Error (tx not found)", func(t *testing.T) {
+		_, err := client.GetTransactions([]string{"tx4"})
+		assert.Error(t, err)
+	})
+}
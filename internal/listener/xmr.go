@@ -23,6 +23,12 @@ type XMRBlock daemon.GetBlockResult
 func (b XMRBlock) GetTxHashes() []string {
 	return b.BlockDetails.TxHashes
 }
+func (b XMRBlock) GetHash() string {
+	return b.BlockHeader.Hash
+}
+func (b XMRBlock) GetParentHash() string {
+	return b.BlockHeader.PrevHash
+}
 
 type SharedXMRDaemonRpcClient struct {
 	client daemon.IDaemonRpcClient
@@ -67,6 +67,12 @@ const (
 	CoinType_TWT_BEP20    CoinType = 39
 	CoinType_AVAX_BEP20   CoinType = 40
 	CoinType_CAKE_BEP20   CoinType = 41
+	// Jettons
+	CoinType_USDT_TON CoinType = 42
+	CoinType_TRX      CoinType = 43
+	// TRC20
+	CoinType_USDT_TRC20 CoinType = 44
+	CoinType_USDC_TRC20 CoinType = 45
 )
 
 // Enum value maps for CoinType.
@@ -114,6 +120,10 @@ var (
 		39: "TWT_BEP20",
 		40: "AVAX_BEP20",
 		41: "CAKE_BEP20",
+		42: "USDT_TON",
+		43: "TRX",
+		44: "USDT_TRC20",
+		45: "USDC_TRC20",
 	}
 	CoinType_value = map[string]int32{
 		"XMR":          0,
@@ -158,6 +168,10 @@ var (
 		"TWT_BEP20":    39,
 		"AVAX_BEP20":   40,
 		"CAKE_BEP20":   41,
+		"USDT_TON":     42,
+		"TRX":          43,
+		"USDT_TRC20":   44,
+		"USDC_TRC20":   45,
 	}
 )
 
@@ -188,6 +202,117 @@ func (CoinType) EnumDescriptor() ([]byte, []int) {
 	return file_crypto_proto_rawDescGZIP(), []int{0}
 }
 
+// The script type of the BTC/LTC addresses derived from a master public key.
+type AddressType int32
+
+const (
+	// Taken from the SLIP-132 prefix of the key: xpub (Ltub) is P2PKH, ypub (Mtub) is P2SH_P2WPKH and zpub is P2WPKH.
+	AddressType_ADDRESS_TYPE_AUTO  AddressType = 0
+	AddressType_ADDRESS_TYPE_P2PKH AddressType = 1
+	// P2WPKH nested in P2SH (BIP49).
+	AddressType_ADDRESS_TYPE_P2SH_P2WPKH AddressType = 2
+	AddressType_ADDRESS_TYPE_P2WPKH      AddressType = 3
+	// Key path only Taproot (BIP86).
+	AddressType_ADDRESS_TYPE_P2TR AddressType = 4
+)
+
+// Enum value maps for AddressType.
+var (
+	AddressType_name = map[int32]string{
+		0: "ADDRESS_TYPE_AUTO",
+		1: "ADDRESS_TYPE_P2PKH",
+		2: "ADDRESS_TYPE_P2SH_P2WPKH",
+		3: "ADDRESS_TYPE_P2WPKH",
+		4: "ADDRESS_TYPE_P2TR",
+	}
+	AddressType_value = map[string]int32{
+		"ADDRESS_TYPE_AUTO":        0,
+		"ADDRESS_TYPE_P2PKH":       1,
+		"ADDRESS_TYPE_P2SH_P2WPKH": 2,
+		"ADDRESS_TYPE_P2WPKH":      3,
+		"ADDRESS_TYPE_P2TR":        4,
+	}
+)
+
+func (x AddressType) Enum() *AddressType {
+	p := new(AddressType)
+	*p = x
+	return p
+}
+
+func (x AddressType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AddressType) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[1].Descriptor()
+}
+
+func (AddressType) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[1]
+}
+
+func (x AddressType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AddressType.Descriptor instead.
+func (AddressType) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{1}
+}
+
+// Which of the addresses released by the expired or cancelled invoices may take another invoice.
+type AddressReusePolicy int32
+
+const (
+	AddressReusePolicy_ADDRESS_REUSE_POLICY_REUSE AddressReusePolicy = 0
+	// An address takes one invoice only.
+	AddressReusePolicy_ADDRESS_REUSE_POLICY_NEVER_REUSE AddressReusePolicy = 1
+	// The addresses that have received funds are never reused.
+	AddressReusePolicy_ADDRESS_REUSE_POLICY_REUSE_UNFUNDED AddressReusePolicy = 2
+)
+
+// Enum value maps for AddressReusePolicy.
+var (
+	AddressReusePolicy_name = map[int32]string{
+		0: "ADDRESS_REUSE_POLICY_REUSE",
+		1: "ADDRESS_REUSE_POLICY_NEVER_REUSE",
+		2: "ADDRESS_REUSE_POLICY_REUSE_UNFUNDED",
+	}
+	AddressReusePolicy_value = map[string]int32{
+		"ADDRESS_REUSE_POLICY_REUSE":          0,
+		"ADDRESS_REUSE_POLICY_NEVER_REUSE":    1,
+		"ADDRESS_REUSE_POLICY_REUSE_UNFUNDED": 2,
+	}
+)
+
+func (x AddressReusePolicy) Enum() *AddressReusePolicy {
+	p := new(AddressReusePolicy)
+	*p = x
+	return p
+}
+
+func (x AddressReusePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AddressReusePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[2].Descriptor()
+}
+
+func (AddressReusePolicy) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[2]
+}
+
+func (x AddressReusePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AddressReusePolicy.Descriptor instead.
+func (AddressReusePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{2}
+}
+
 type XmrKeysUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -248,7 +373,8 @@ type BtcKeysUpdateRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MasterPubKey string `protobuf:"bytes,1,opt,name=masterPubKey,proto3" json:"masterPubKey,omitempty"`
+	MasterPubKey string      `protobuf:"bytes,1,opt,name=masterPubKey,proto3" json:"masterPubKey,omitempty"`
+	AddressType  AddressType `protobuf:"varint,2,opt,name=addressType,proto3,enum=crypto.v1.AddressType" json:"addressType,omitempty"`
 }
 
 func (x *BtcKeysUpdateRequest) Reset() {
@@ -290,12 +416,20 @@ func (x *BtcKeysUpdateRequest) GetMasterPubKey() string {
 	return ""
 }
 
+func (x *BtcKeysUpdateRequest) GetAddressType() AddressType {
+	if x != nil {
+		return x.AddressType
+	}
+	return AddressType_ADDRESS_TYPE_AUTO
+}
+
 type LtcKeysUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MasterPubKey string `protobuf:"bytes,1,opt,name=masterPubKey,proto3" json:"masterPubKey,omitempty"`
+	MasterPubKey string      `protobuf:"bytes,1,opt,name=masterPubKey,proto3" json:"masterPubKey,omitempty"`
+	AddressType  AddressType `protobuf:"varint,2,opt,name=addressType,proto3,enum=crypto.v1.AddressType" json:"addressType,omitempty"`
 }
 
 func (x *LtcKeysUpdateRequest) Reset() {
@@ -337,6 +471,13 @@ func (x *LtcKeysUpdateRequest) GetMasterPubKey() string {
 	return ""
 }
 
+func (x *LtcKeysUpdateRequest) GetAddressType() AddressType {
+	if x != nil {
+		return x.AddressType
+	}
+	return AddressType_ADDRESS_TYPE_AUTO
+}
+
 type EthKeysUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -431,6 +572,157 @@ func (x *BnbKeysUpdateRequest) GetMasterPubKey() string {
 	return ""
 }
 
+type TrxKeysUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MasterPubKey string `protobuf:"bytes,1,opt,name=masterPubKey,proto3" json:"masterPubKey,omitempty"`
+}
+
+func (x *TrxKeysUpdateRequest) Reset() {
+	*x = TrxKeysUpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrxKeysUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrxKeysUpdateRequest) ProtoMessage() {}
+
+func (x *TrxKeysUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrxKeysUpdateRequest.ProtoReflect.Descriptor instead.
+func (*TrxKeysUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TrxKeysUpdateRequest) GetMasterPubKey() string {
+	if x != nil {
+		return x.MasterPubKey
+	}
+	return ""
+}
+
+type EvmKeysUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of an EVM chain declared in the config, e.g. POLYGON.
+	Chain        string `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	MasterPubKey string `protobuf:"bytes,2,opt,name=masterPubKey,proto3" json:"masterPubKey,omitempty"`
+}
+
+func (x *EvmKeysUpdateRequest) Reset() {
+	*x = EvmKeysUpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvmKeysUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvmKeysUpdateRequest) ProtoMessage() {}
+
+func (x *EvmKeysUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvmKeysUpdateRequest.ProtoReflect.Descriptor instead.
+func (*EvmKeysUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EvmKeysUpdateRequest) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *EvmKeysUpdateRequest) GetMasterPubKey() string {
+	if x != nil {
+		return x.MasterPubKey
+	}
+	return ""
+}
+
+type TonKeysUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The invoices are paid to this wallet, each with its own memo.
+	WalletAddress string `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+}
+
+func (x *TonKeysUpdateRequest) Reset() {
+	*x = TonKeysUpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TonKeysUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TonKeysUpdateRequest) ProtoMessage() {}
+
+func (x *TonKeysUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TonKeysUpdateRequest.ProtoReflect.Descriptor instead.
+func (*TonKeysUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TonKeysUpdateRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
 var File_crypto_proto protoreflect.FileDescriptor
 
 var file_crypto_proto_rawDesc = []byte{
@@ -441,22 +733,42 @@ var file_crypto_proto_rawDesc = []byte{
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x56, 0x69, 0x65, 0x77,
 	0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x75, 0x62, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x4b,
 	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x75, 0x62, 0x53, 0x70, 0x65,
-	0x6e, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a, 0x14, 0x42, 0x74, 0x63, 0x4b, 0x65, 0x79, 0x73,
+	0x6e, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x74, 0x0a, 0x14, 0x42, 0x74, 0x63, 0x4b, 0x65, 0x79, 0x73,
 	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a,
 	0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65,
-	0x79, 0x22, 0x3a, 0x0a, 0x14, 0x4c, 0x74, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61,
+	0x79, 0x12, 0x38, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x22, 0x74, 0x0a, 0x14, 0x4c,
+	0x74, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62,
+	0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65,
+	0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x38, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70,
+	0x65, 0x22, 0x3a, 0x0a, 0x14, 0x45, 0x74, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61,
 	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73,
 	0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a,
-	0x14, 0x45, 0x74, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x14, 0x42, 0x6e, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50,
 	0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73,
-	0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a, 0x14, 0x42, 0x6e, 0x62,
+	0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a, 0x14, 0x54, 0x72, 0x78,
 	0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65,
 	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50,
-	0x75, 0x62, 0x4b, 0x65, 0x79, 0x2a, 0xf5, 0x04, 0x0a, 0x08, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79,
+	0x75, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x50, 0x0a, 0x14, 0x45, 0x76, 0x6d, 0x4b, 0x65, 0x79, 0x73,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62,
+	0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65,
+	0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x3c, 0x0a, 0x14, 0x54, 0x6f, 0x6e, 0x4b, 0x65,
+	0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x24, 0x0a, 0x0d, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x2a, 0xac, 0x05, 0x0a, 0x08, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79,
 	0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x58, 0x4d, 0x52, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x42,
 	0x54, 0x43, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x43, 0x10, 0x02, 0x12, 0x07, 0x0a,
 	0x03, 0x45, 0x54, 0x48, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x4f, 0x4e, 0x10, 0x04, 0x12,
@@ -495,8 +807,28 @@ var file_crypto_proto_rawDesc = []byte{
 	0x0d, 0x0a, 0x09, 0x42, 0x43, 0x48, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x26, 0x12, 0x0d,
 	0x0a, 0x09, 0x54, 0x57, 0x54, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x27, 0x12, 0x0e, 0x0a,
 	0x0a, 0x41, 0x56, 0x41, 0x58, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x28, 0x12, 0x0e, 0x0a,
-	0x0a, 0x43, 0x41, 0x4b, 0x45, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x29, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0a, 0x43, 0x41, 0x4b, 0x45, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x29, 0x12, 0x0c, 0x0a,
+	0x08, 0x55, 0x53, 0x44, 0x54, 0x5f, 0x54, 0x4f, 0x4e, 0x10, 0x2a, 0x12, 0x07, 0x0a, 0x03, 0x54,
+	0x52, 0x58, 0x10, 0x2b, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x53, 0x44, 0x54, 0x5f, 0x54, 0x52, 0x43,
+	0x32, 0x30, 0x10, 0x2c, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x53, 0x44, 0x43, 0x5f, 0x54, 0x52, 0x43,
+	0x32, 0x30, 0x10, 0x2d, 0x2a, 0x8a, 0x01, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x44, 0x44, 0x52, 0x45, 0x53, 0x53, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x41,
+	0x44, 0x44, 0x52, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x32, 0x50, 0x4b,
+	0x48, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x44, 0x44, 0x52, 0x45, 0x53, 0x53, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x50, 0x32, 0x53, 0x48, 0x5f, 0x50, 0x32, 0x57, 0x50, 0x4b, 0x48, 0x10,
+	0x02, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x44, 0x44, 0x52, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x50, 0x32, 0x57, 0x50, 0x4b, 0x48, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x44,
+	0x44, 0x52, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x32, 0x54, 0x52, 0x10,
+	0x04, 0x2a, 0x83, 0x01, 0x0a, 0x12, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x75,
+	0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x44, 0x44, 0x52,
+	0x45, 0x53, 0x53, 0x5f, 0x52, 0x45, 0x55, 0x53, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
+	0x5f, 0x52, 0x45, 0x55, 0x53, 0x45, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20, 0x41, 0x44, 0x44, 0x52,
+	0x45, 0x53, 0x53, 0x5f, 0x52, 0x45, 0x55, 0x53, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
+	0x5f, 0x4e, 0x45, 0x56, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x55, 0x53, 0x45, 0x10, 0x01, 0x12, 0x27,
+	0x0a, 0x23, 0x41, 0x44, 0x44, 0x52, 0x45, 0x53, 0x53, 0x5f, 0x52, 0x45, 0x55, 0x53, 0x45, 0x5f,
+	0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x52, 0x45, 0x55, 0x53, 0x45, 0x5f, 0x55, 0x4e, 0x46,
+	0x55, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x02, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -511,22 +843,29 @@ func file_crypto_proto_rawDescGZIP() []byte {
 	return file_crypto_proto_rawDescData
 }
 
-var file_crypto_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_crypto_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_crypto_proto_goTypes = []any{
 	(CoinType)(0),                // 0: crypto.v1.CoinType
-	(*XmrKeysUpdateRequest)(nil), // 1: crypto.v1.XmrKeysUpdateRequest
-	(*BtcKeysUpdateRequest)(nil), // 2: crypto.v1.BtcKeysUpdateRequest
-	(*LtcKeysUpdateRequest)(nil), // 3: crypto.v1.LtcKeysUpdateRequest
-	(*EthKeysUpdateRequest)(nil), // 4: crypto.v1.EthKeysUpdateRequest
-	(*BnbKeysUpdateRequest)(nil), // 5: crypto.v1.BnbKeysUpdateRequest
+	(AddressType)(0),             // 1: crypto.v1.AddressType
+	(AddressReusePolicy)(0),      // 2: crypto.v1.AddressReusePolicy
+	(*XmrKeysUpdateRequest)(nil), // 3: crypto.v1.XmrKeysUpdateRequest
+	(*BtcKeysUpdateRequest)(nil), // 4: crypto.v1.BtcKeysUpdateRequest
+	(*LtcKeysUpdateRequest)(nil), // 5: crypto.v1.LtcKeysUpdateRequest
+	(*EthKeysUpdateRequest)(nil), // 6: crypto.v1.EthKeysUpdateRequest
+	(*BnbKeysUpdateRequest)(nil), // 7: crypto.v1.BnbKeysUpdateRequest
+	(*TrxKeysUpdateRequest)(nil), // 8: crypto.v1.TrxKeysUpdateRequest
+	(*EvmKeysUpdateRequest)(nil), // 9: crypto.v1.EvmKeysUpdateRequest
+	(*TonKeysUpdateRequest)(nil), // 10: crypto.v1.TonKeysUpdateRequest
 }
 var file_crypto_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: crypto.v1.BtcKeysUpdateRequest.addressType:type_name -> crypto.v1.AddressType
+	1, // 1: crypto.v1.LtcKeysUpdateRequest.addressType:type_name -> crypto.v1.AddressType
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_crypto_proto_init() }
@@ -595,14 +934,50 @@ func file_crypto_proto_init() {
 				return nil
 			}
 		}
+		file_crypto_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*TrxKeysUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*EvmKeysUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*TonKeysUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_crypto_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      3,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
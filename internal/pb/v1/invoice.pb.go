@@ -24,10 +24,13 @@ const (
 type InvoiceStatusType int32
 
 const (
-	InvoiceStatusType_PENDING         InvoiceStatusType = 0
-	InvoiceStatusType_PENDING_MEMPOOL InvoiceStatusType = 1
-	InvoiceStatusType_EXPIRED         InvoiceStatusType = 2
-	InvoiceStatusType_CONFIRMED       InvoiceStatusType = 3
+	InvoiceStatusType_PENDING           InvoiceStatusType = 0
+	InvoiceStatusType_PENDING_MEMPOOL   InvoiceStatusType = 1
+	InvoiceStatusType_EXPIRED           InvoiceStatusType = 2
+	InvoiceStatusType_CONFIRMED         InvoiceStatusType = 3
+	InvoiceStatusType_CANCELLED         InvoiceStatusType = 4
+	InvoiceStatusType_PARTIALLY_PAID    InvoiceStatusType = 5
+	InvoiceStatusType_PAID_AFTER_EXPIRY InvoiceStatusType = 6
 )
 
 // Enum value maps for InvoiceStatusType.
@@ -37,12 +40,18 @@ var (
 		1: "PENDING_MEMPOOL",
 		2: "EXPIRED",
 		3: "CONFIRMED",
+		4: "CANCELLED",
+		5: "PARTIALLY_PAID",
+		6: "PAID_AFTER_EXPIRY",
 	}
 	InvoiceStatusType_value = map[string]int32{
-		"PENDING":         0,
-		"PENDING_MEMPOOL": 1,
-		"EXPIRED":         2,
-		"CONFIRMED":       3,
+		"PENDING":           0,
+		"PENDING_MEMPOOL":   1,
+		"EXPIRED":           2,
+		"CONFIRMED":         3,
+		"CANCELLED":         4,
+		"PARTIALLY_PAID":    5,
+		"PAID_AFTER_EXPIRY": 6,
 	}
 )
 
@@ -73,15 +82,128 @@ func (InvoiceStatusType) EnumDescriptor() ([]byte, []int) {
 	return file_invoice_proto_rawDescGZIP(), []int{0}
 }
 
+type QrCodeFormat int32
+
+const (
+	QrCodeFormat_QR_CODE_NONE QrCodeFormat = 0
+	QrCodeFormat_QR_CODE_PNG  QrCodeFormat = 1
+	QrCodeFormat_QR_CODE_SVG  QrCodeFormat = 2
+)
+
+// Enum value maps for QrCodeFormat.
+var (
+	QrCodeFormat_name = map[int32]string{
+		0: "QR_CODE_NONE",
+		1: "QR_CODE_PNG",
+		2: "QR_CODE_SVG",
+	}
+	QrCodeFormat_value = map[string]int32{
+		"QR_CODE_NONE": 0,
+		"QR_CODE_PNG":  1,
+		"QR_CODE_SVG":  2,
+	}
+)
+
+func (x QrCodeFormat) Enum() *QrCodeFormat {
+	p := new(QrCodeFormat)
+	*p = x
+	return p
+}
+
+func (x QrCodeFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QrCodeFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_invoice_proto_enumTypes[1].Descriptor()
+}
+
+func (QrCodeFormat) Type() protoreflect.EnumType {
+	return &file_invoice_proto_enumTypes[1]
+}
+
+func (x QrCodeFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QrCodeFormat.Descriptor instead.
+func (QrCodeFormat) EnumDescriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{1}
+}
+
+// An exact amount of a coin.
+type Amount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The amount in coins, e.g. "0.1".
+	Decimal string `protobuf:"bytes,1,opt,name=decimal,proto3" json:"decimal,omitempty"`
+	// The amount in the smallest units of the coin (satoshis, piconeros, wei...), e.g. "10000000".
+	AtomicUnits string `protobuf:"bytes,2,opt,name=atomicUnits,proto3" json:"atomicUnits,omitempty"`
+}
+
+func (x *Amount) Reset() {
+	*x = Amount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Amount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Amount) ProtoMessage() {}
+
+func (x *Amount) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Amount.ProtoReflect.Descriptor instead.
+func (*Amount) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Amount) GetDecimal() string {
+	if x != nil {
+		return x.Decimal
+	}
+	return ""
+}
+
+func (x *Amount) GetAtomicUnits() string {
+	if x != nil {
+		return x.AtomicUnits
+	}
+	return ""
+}
+
 type Invoice struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CryptoAddress         string                 `protobuf:"bytes,2,opt,name=cryptoAddress,proto3" json:"cryptoAddress,omitempty"`
-	Coin                  CoinType               `protobuf:"varint,3,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
-	RequiredAmount        float64                `protobuf:"fixed64,4,opt,name=requiredAmount,proto3" json:"requiredAmount,omitempty"`
+	Id            string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CryptoAddress string   `protobuf:"bytes,2,opt,name=cryptoAddress,proto3" json:"cryptoAddress,omitempty"`
+	Coin          CoinType `protobuf:"varint,3,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
+	// Deprecated: lossy, use requiredAmountExact.
+	//
+	// Deprecated: Marked as deprecated in invoice.proto.
+	RequiredAmount float64 `protobuf:"fixed64,4,opt,name=requiredAmount,proto3" json:"requiredAmount,omitempty"`
+	// Deprecated: lossy, use actualAmountExact.
+	//
+	// Deprecated: Marked as deprecated in invoice.proto.
 	ActualAmount          float64                `protobuf:"fixed64,5,opt,name=actualAmount,proto3" json:"actualAmount,omitempty"`
 	ConfirmationsRequired uint32                 `protobuf:"varint,6,opt,name=confirmationsRequired,proto3" json:"confirmationsRequired,omitempty"`
 	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
@@ -90,12 +212,39 @@ type Invoice struct {
 	ExpiresAt             *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
 	TxId                  string                 `protobuf:"bytes,11,opt,name=txId,proto3" json:"txId,omitempty"`
 	UserId                string                 `protobuf:"bytes,12,opt,name=userId,proto3" json:"userId,omitempty"`
+	// Deprecated: lossy, use underpaymentToleranceExact.
+	//
+	// Deprecated: Marked as deprecated in invoice.proto.
+	UnderpaymentTolerance float64 `protobuf:"fixed64,13,opt,name=underpaymentTolerance,proto3" json:"underpaymentTolerance,omitempty"`
+	// Deprecated: lossy, use overpaidAmountExact.
+	//
+	// Deprecated: Marked as deprecated in invoice.proto.
+	OverpaidAmount float64  `protobuf:"fixed64,14,opt,name=overpaidAmount,proto3" json:"overpaidAmount,omitempty"`
+	FiatAmount     *float64 `protobuf:"fixed64,15,opt,name=fiatAmount,proto3,oneof" json:"fiatAmount,omitempty"`
+	FiatCurrency   *string  `protobuf:"bytes,16,opt,name=fiatCurrency,proto3,oneof" json:"fiatCurrency,omitempty"`
+	// The price of one coin in fiatCurrency locked at the invoice creation.
+	ExchangeRate    *float64          `protobuf:"fixed64,17,opt,name=exchangeRate,proto3,oneof" json:"exchangeRate,omitempty"`
+	Description     string            `protobuf:"bytes,18,opt,name=description,proto3" json:"description,omitempty"`
+	ExternalOrderId string            `protobuf:"bytes,19,opt,name=externalOrderId,proto3" json:"externalOrderId,omitempty"`
+	Metadata        map[string]string `protobuf:"bytes,20,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Set for the invoices created together via coinOptions.
+	GroupId string `protobuf:"bytes,21,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	// The confirmations of the least confirmed payment tx, updated while the invoice is PENDING_MEMPOOL.
+	Confirmations              uint32  `protobuf:"varint,22,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	RequiredAmountExact        *Amount `protobuf:"bytes,23,opt,name=requiredAmountExact,proto3" json:"requiredAmountExact,omitempty"`
+	ActualAmountExact          *Amount `protobuf:"bytes,24,opt,name=actualAmountExact,proto3" json:"actualAmountExact,omitempty"`
+	UnderpaymentToleranceExact *Amount `protobuf:"bytes,25,opt,name=underpaymentToleranceExact,proto3" json:"underpaymentToleranceExact,omitempty"`
+	OverpaidAmountExact        *Amount `protobuf:"bytes,26,opt,name=overpaidAmountExact,proto3" json:"overpaidAmountExact,omitempty"`
+	// The comment the transfer must carry, set for the coins whose invoices share one address (TON).
+	Memo *string `protobuf:"bytes,27,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	// The coin as a string, e.g. BTC. The coins of the EVM chains declared in the config (e.g. USDC_POLYGON) have it only.
+	CoinCode string `protobuf:"bytes,28,opt,name=coinCode,proto3" json:"coinCode,omitempty"`
 }
 
 func (x *Invoice) Reset() {
 	*x = Invoice{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_invoice_proto_msgTypes[0]
+		mi := &file_invoice_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -108,7 +257,7 @@ func (x *Invoice) String() string {
 func (*Invoice) ProtoMessage() {}
 
 func (x *Invoice) ProtoReflect() protoreflect.Message {
-	mi := &file_invoice_proto_msgTypes[0]
+	mi := &file_invoice_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -121,7 +270,7 @@ func (x *Invoice) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
 func (*Invoice) Descriptor() ([]byte, []int) {
-	return file_invoice_proto_rawDescGZIP(), []int{0}
+	return file_invoice_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Invoice) GetId() string {
@@ -145,6 +294,7 @@ func (x *Invoice) GetCoin() CoinType {
 	return CoinType_XMR
 }
 
+// Deprecated: Marked as deprecated in invoice.proto.
 func (x *Invoice) GetRequiredAmount() float64 {
 	if x != nil {
 		return x.RequiredAmount
@@ -152,6 +302,7 @@ func (x *Invoice) GetRequiredAmount() float64 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in invoice.proto.
 func (x *Invoice) GetActualAmount() float64 {
 	if x != nil {
 		return x.ActualAmount
@@ -208,96 +359,136 @@ func (x *Invoice) GetUserId() string {
 	return ""
 }
 
-type CreateInvoiceRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	UserId        string   `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	Coin          CoinType `protobuf:"varint,2,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
-	Amount        float64  `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Timeout       uint64   `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Confirmations uint32   `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+// Deprecated: Marked as deprecated in invoice.proto.
+func (x *Invoice) GetUnderpaymentTolerance() float64 {
+	if x != nil {
+		return x.UnderpaymentTolerance
+	}
+	return 0
 }
 
-func (x *CreateInvoiceRequest) Reset() {
-	*x = CreateInvoiceRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_invoice_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Deprecated: Marked as deprecated in invoice.proto.
+func (x *Invoice) GetOverpaidAmount() float64 {
+	if x != nil {
+		return x.OverpaidAmount
 	}
+	return 0
 }
 
-func (x *CreateInvoiceRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Invoice) GetFiatAmount() float64 {
+	if x != nil && x.FiatAmount != nil {
+		return *x.FiatAmount
+	}
+	return 0
 }
 
-func (*CreateInvoiceRequest) ProtoMessage() {}
-
-func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_invoice_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Invoice) GetFiatCurrency() string {
+	if x != nil && x.FiatCurrency != nil {
+		return *x.FiatCurrency
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
-func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
-	return file_invoice_proto_rawDescGZIP(), []int{1}
+func (x *Invoice) GetExchangeRate() float64 {
+	if x != nil && x.ExchangeRate != nil {
+		return *x.ExchangeRate
+	}
+	return 0
 }
 
-func (x *CreateInvoiceRequest) GetUserId() string {
+func (x *Invoice) GetDescription() string {
 	if x != nil {
-		return x.UserId
+		return x.Description
 	}
 	return ""
 }
 
-func (x *CreateInvoiceRequest) GetCoin() CoinType {
+func (x *Invoice) GetExternalOrderId() string {
 	if x != nil {
-		return x.Coin
+		return x.ExternalOrderId
 	}
-	return CoinType_XMR
+	return ""
 }
 
-func (x *CreateInvoiceRequest) GetAmount() float64 {
+func (x *Invoice) GetMetadata() map[string]string {
 	if x != nil {
-		return x.Amount
+		return x.Metadata
 	}
-	return 0
+	return nil
 }
 
-func (x *CreateInvoiceRequest) GetTimeout() uint64 {
+func (x *Invoice) GetGroupId() string {
 	if x != nil {
-		return x.Timeout
+		return x.GroupId
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateInvoiceRequest) GetConfirmations() uint32 {
+func (x *Invoice) GetConfirmations() uint32 {
 	if x != nil {
 		return x.Confirmations
 	}
 	return 0
 }
 
-type CreateInvoiceResponse struct {
+func (x *Invoice) GetRequiredAmountExact() *Amount {
+	if x != nil {
+		return x.RequiredAmountExact
+	}
+	return nil
+}
+
+func (x *Invoice) GetActualAmountExact() *Amount {
+	if x != nil {
+		return x.ActualAmountExact
+	}
+	return nil
+}
+
+func (x *Invoice) GetUnderpaymentToleranceExact() *Amount {
+	if x != nil {
+		return x.UnderpaymentToleranceExact
+	}
+	return nil
+}
+
+func (x *Invoice) GetOverpaidAmountExact() *Amount {
+	if x != nil {
+		return x.OverpaidAmountExact
+	}
+	return nil
+}
+
+func (x *Invoice) GetMemo() string {
+	if x != nil && x.Memo != nil {
+		return *x.Memo
+	}
+	return ""
+}
+
+func (x *Invoice) GetCoinCode() string {
+	if x != nil {
+		return x.CoinCode
+	}
+	return ""
+}
+
+type CoinOption struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PaymentId string `protobuf:"bytes,1,opt,name=paymentId,proto3" json:"paymentId,omitempty"`
-	Address   string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Coin CoinType `protobuf:"varint,1,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
+	// Ignored if the fiat amount is set.
+	Amount float64 `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// A decimal string, e.g. "0.1". If set, amount is ignored. It can't be more precise than the coin.
+	ExactAmount *string `protobuf:"bytes,3,opt,name=exactAmount,proto3,oneof" json:"exactAmount,omitempty"`
+	// If set, coin is ignored. See Invoice.coinCode.
+	CoinCode *string `protobuf:"bytes,4,opt,name=coinCode,proto3,oneof" json:"coinCode,omitempty"`
 }
 
-func (x *CreateInvoiceResponse) Reset() {
-	*x = CreateInvoiceResponse{}
+func (x *CoinOption) Reset() {
+	*x = CoinOption{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_invoice_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -305,13 +496,13 @@ func (x *CreateInvoiceResponse) Reset() {
 	}
 }
 
-func (x *CreateInvoiceResponse) String() string {
+func (x *CoinOption) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateInvoiceResponse) ProtoMessage() {}
+func (*CoinOption) ProtoMessage() {}
 
-func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
+func (x *CoinOption) ProtoReflect() protoreflect.Message {
 	mi := &file_invoice_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -323,33 +514,60 @@ func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateInvoiceResponse.ProtoReflect.Descriptor instead.
-func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CoinOption.ProtoReflect.Descriptor instead.
+func (*CoinOption) Descriptor() ([]byte, []int) {
 	return file_invoice_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CreateInvoiceResponse) GetPaymentId() string {
+func (x *CoinOption) GetCoin() CoinType {
 	if x != nil {
-		return x.PaymentId
+		return x.Coin
 	}
-	return ""
+	return CoinType_XMR
 }
 
-func (x *CreateInvoiceResponse) GetAddress() string {
+func (x *CoinOption) GetAmount() float64 {
 	if x != nil {
-		return x.Address
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CoinOption) GetExactAmount() string {
+	if x != nil && x.ExactAmount != nil {
+		return *x.ExactAmount
 	}
 	return ""
 }
 
-type InvoiceStatusStreamRequest struct {
+func (x *CoinOption) GetCoinCode() string {
+	if x != nil && x.CoinCode != nil {
+		return *x.CoinCode
+	}
+	return ""
+}
+
+type PaymentOption struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Coin      CoinType `protobuf:"varint,1,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
+	PaymentId string   `protobuf:"bytes,2,opt,name=paymentId,proto3" json:"paymentId,omitempty"`
+	Address   string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// Deprecated: lossy, use amountExact.
+	//
+	// Deprecated: Marked as deprecated in invoice.proto.
+	Amount      float64 `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	PaymentUri  string  `protobuf:"bytes,5,opt,name=paymentUri,proto3" json:"paymentUri,omitempty"`
+	QrCode      []byte  `protobuf:"bytes,6,opt,name=qrCode,proto3" json:"qrCode,omitempty"`
+	AmountExact *Amount `protobuf:"bytes,7,opt,name=amountExact,proto3" json:"amountExact,omitempty"`
+	Memo        *string `protobuf:"bytes,8,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	CoinCode    string  `protobuf:"bytes,9,opt,name=coinCode,proto3" json:"coinCode,omitempty"`
 }
 
-func (x *InvoiceStatusStreamRequest) Reset() {
-	*x = InvoiceStatusStreamRequest{}
+func (x *PaymentOption) Reset() {
+	*x = PaymentOption{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_invoice_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -357,13 +575,13 @@ func (x *InvoiceStatusStreamRequest) Reset() {
 	}
 }
 
-func (x *InvoiceStatusStreamRequest) String() string {
+func (x *PaymentOption) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InvoiceStatusStreamRequest) ProtoMessage() {}
+func (*PaymentOption) ProtoMessage() {}
 
-func (x *InvoiceStatusStreamRequest) ProtoReflect() protoreflect.Message {
+func (x *PaymentOption) ProtoReflect() protoreflect.Message {
 	mi := &file_invoice_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -375,21 +593,87 @@ func (x *InvoiceStatusStreamRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InvoiceStatusStreamRequest.ProtoReflect.Descriptor instead.
-func (*InvoiceStatusStreamRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PaymentOption.ProtoReflect.Descriptor instead.
+func (*PaymentOption) Descriptor() ([]byte, []int) {
 	return file_invoice_proto_rawDescGZIP(), []int{3}
 }
 
-type InvoiceStatusStreamResponse struct {
+func (x *PaymentOption) GetCoin() CoinType {
+	if x != nil {
+		return x.Coin
+	}
+	return CoinType_XMR
+}
+
+func (x *PaymentOption) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *PaymentOption) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in invoice.proto.
+func (x *PaymentOption) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentOption) GetPaymentUri() string {
+	if x != nil {
+		return x.PaymentUri
+	}
+	return ""
+}
+
+func (x *PaymentOption) GetQrCode() []byte {
+	if x != nil {
+		return x.QrCode
+	}
+	return nil
+}
+
+func (x *PaymentOption) GetAmountExact() *Amount {
+	if x != nil {
+		return x.AmountExact
+	}
+	return nil
+}
+
+func (x *PaymentOption) GetMemo() string {
+	if x != nil && x.Memo != nil {
+		return *x.Memo
+	}
+	return ""
+}
+
+func (x *PaymentOption) GetCoinCode() string {
+	if x != nil {
+		return x.CoinCode
+	}
+	return ""
+}
+
+type FiatAmount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Invoice *Invoice `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	// ISO 4217 currency code, e.g. USD.
+	Currency string  `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount   float64 `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
 }
 
-func (x *InvoiceStatusStreamResponse) Reset() {
-	*x = InvoiceStatusStreamResponse{}
+func (x *FiatAmount) Reset() {
+	*x = FiatAmount{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_invoice_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -397,13 +681,13 @@ func (x *InvoiceStatusStreamResponse) Reset() {
 	}
 }
 
-func (x *InvoiceStatusStreamResponse) String() string {
+func (x *FiatAmount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InvoiceStatusStreamResponse) ProtoMessage() {}
+func (*FiatAmount) ProtoMessage() {}
 
-func (x *InvoiceStatusStreamResponse) ProtoReflect() protoreflect.Message {
+func (x *FiatAmount) ProtoReflect() protoreflect.Message {
 	mi := &file_invoice_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -415,100 +699,1391 @@ func (x *InvoiceStatusStreamResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InvoiceStatusStreamResponse.ProtoReflect.Descriptor instead.
-func (*InvoiceStatusStreamResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use FiatAmount.ProtoReflect.Descriptor instead.
+func (*FiatAmount) Descriptor() ([]byte, []int) {
 	return file_invoice_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *InvoiceStatusStreamResponse) GetInvoice() *Invoice {
+func (x *FiatAmount) GetCurrency() string {
 	if x != nil {
-		return x.Invoice
+		return x.Currency
 	}
-	return nil
+	return ""
 }
 
-var File_invoice_proto protoreflect.FileDescriptor
+func (x *FiatAmount) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type InvoiceEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OldStatus *InvoiceStatusType `protobuf:"varint,1,opt,name=oldStatus,proto3,enum=invoice.v1.InvoiceStatusType,oneof" json:"oldStatus,omitempty"`
+	NewStatus InvoiceStatusType  `protobuf:"varint,2,opt,name=newStatus,proto3,enum=invoice.v1.InvoiceStatusType" json:"newStatus,omitempty"`
+	TxId      string             `protobuf:"bytes,3,opt,name=txId,proto3" json:"txId,omitempty"`
+	// Deprecated: lossy, use amountExact.
+	//
+	// Deprecated: Marked as deprecated in invoice.proto.
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Confirmations *uint64                `protobuf:"varint,5,opt,name=confirmations,proto3,oneof" json:"confirmations,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// Set for the transitions caused by a chain reorganization.
+	Reorg       bool    `protobuf:"varint,7,opt,name=reorg,proto3" json:"reorg,omitempty"`
+	AmountExact *Amount `protobuf:"bytes,8,opt,name=amountExact,proto3" json:"amountExact,omitempty"`
+}
+
+func (x *InvoiceEvent) Reset() {
+	*x = InvoiceEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvoiceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceEvent) ProtoMessage() {}
+
+func (x *InvoiceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceEvent.ProtoReflect.Descriptor instead.
+func (*InvoiceEvent) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InvoiceEvent) GetOldStatus() InvoiceStatusType {
+	if x != nil && x.OldStatus != nil {
+		return *x.OldStatus
+	}
+	return InvoiceStatusType_PENDING
+}
+
+func (x *InvoiceEvent) GetNewStatus() InvoiceStatusType {
+	if x != nil {
+		return x.NewStatus
+	}
+	return InvoiceStatusType_PENDING
+}
+
+func (x *InvoiceEvent) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in invoice.proto.
+func (x *InvoiceEvent) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *InvoiceEvent) GetConfirmations() uint64 {
+	if x != nil && x.Confirmations != nil {
+		return *x.Confirmations
+	}
+	return 0
+}
+
+func (x *InvoiceEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *InvoiceEvent) GetReorg() bool {
+	if x != nil {
+		return x.Reorg
+	}
+	return false
+}
+
+func (x *InvoiceEvent) GetAmountExact() *Amount {
+	if x != nil {
+		return x.AmountExact
+	}
+	return nil
+}
+
+type CreateInvoiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId        string   `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Coin          CoinType `protobuf:"varint,2,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
+	Amount        float64  `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Timeout       uint64   `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Confirmations uint32   `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	// The invoice is considered paid once the received amount reaches amount minus the tolerance.
+	//
+	// Types that are assignable to UnderpaymentTolerance:
+	//	*CreateInvoiceRequest_UnderpaymentTolerancePercent
+	//	*CreateInvoiceRequest_UnderpaymentToleranceAmount
+	UnderpaymentTolerance isCreateInvoiceRequest_UnderpaymentTolerance `protobuf_oneof:"underpaymentTolerance"`
+	// If set, amount is ignored and the required amount is converted from the fiat one
+	// (underpaymentToleranceAmount is then denominated in the fiat currency as well).
+	Fiat *FiatAmount `protobuf:"bytes,8,opt,name=fiat,proto3" json:"fiat,omitempty"`
+	// Shown to the payer by the wallet (BIP21 label, monero recipient_name).
+	Label        *string      `protobuf:"bytes,9,opt,name=label,proto3,oneof" json:"label,omitempty"`
+	QrCodeFormat QrCodeFormat `protobuf:"varint,10,opt,name=qrCodeFormat,proto3,enum=invoice.v1.QrCodeFormat" json:"qrCodeFormat,omitempty"`
+	// Unique per user. A retry with the same key and parameters returns the originally created invoice.
+	IdempotencyKey *string `protobuf:"bytes,11,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	Description    string  `protobuf:"bytes,12,opt,name=description,proto3" json:"description,omitempty"`
+	// The merchant's order reference, it can be used to look the invoices up via ListInvoices.
+	ExternalOrderId string            `protobuf:"bytes,13,opt,name=externalOrderId,proto3" json:"externalOrderId,omitempty"`
+	Metadata        map[string]string `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If set, coin and amount are ignored and an invoice is created per option, all sharing one groupId.
+	// The first option to get paid completes the checkout and the rest of the pending ones are cancelled.
+	CoinOptions []*CoinOption `protobuf:"bytes,15,rep,name=coinOptions,proto3" json:"coinOptions,omitempty"`
+	// A decimal string, e.g. "0.1". If set, amount is ignored. It can't be more precise than the coin.
+	ExactAmount *string `protobuf:"bytes,16,opt,name=exactAmount,proto3,oneof" json:"exactAmount,omitempty"`
+	// If set, coin is ignored. See Invoice.coinCode.
+	CoinCode *string `protobuf:"bytes,17,opt,name=coinCode,proto3,oneof" json:"coinCode,omitempty"`
+}
+
+func (x *CreateInvoiceRequest) Reset() {
+	*x = CreateInvoiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceRequest) ProtoMessage() {}
+
+func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateInvoiceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetCoin() CoinType {
+	if x != nil {
+		return x.Coin
+	}
+	return CoinType_XMR
+}
+
+func (x *CreateInvoiceRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetTimeout() uint64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (m *CreateInvoiceRequest) GetUnderpaymentTolerance() isCreateInvoiceRequest_UnderpaymentTolerance {
+	if m != nil {
+		return m.UnderpaymentTolerance
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetUnderpaymentTolerancePercent() float64 {
+	if x, ok := x.GetUnderpaymentTolerance().(*CreateInvoiceRequest_UnderpaymentTolerancePercent); ok {
+		return x.UnderpaymentTolerancePercent
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetUnderpaymentToleranceAmount() float64 {
+	if x, ok := x.GetUnderpaymentTolerance().(*CreateInvoiceRequest_UnderpaymentToleranceAmount); ok {
+		return x.UnderpaymentToleranceAmount
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetFiat() *FiatAmount {
+	if x != nil {
+		return x.Fiat
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetQrCodeFormat() QrCodeFormat {
+	if x != nil {
+		return x.QrCodeFormat
+	}
+	return QrCodeFormat_QR_CODE_NONE
+}
+
+func (x *CreateInvoiceRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetExternalOrderId() string {
+	if x != nil {
+		return x.ExternalOrderId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetCoinOptions() []*CoinOption {
+	if x != nil {
+		return x.CoinOptions
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetExactAmount() string {
+	if x != nil && x.ExactAmount != nil {
+		return *x.ExactAmount
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetCoinCode() string {
+	if x != nil && x.CoinCode != nil {
+		return *x.CoinCode
+	}
+	return ""
+}
+
+type isCreateInvoiceRequest_UnderpaymentTolerance interface {
+	isCreateInvoiceRequest_UnderpaymentTolerance()
+}
+
+type CreateInvoiceRequest_UnderpaymentTolerancePercent struct {
+	UnderpaymentTolerancePercent float64 `protobuf:"fixed64,6,opt,name=underpaymentTolerancePercent,proto3,oneof"`
+}
+
+type CreateInvoiceRequest_UnderpaymentToleranceAmount struct {
+	UnderpaymentToleranceAmount float64 `protobuf:"fixed64,7,opt,name=underpaymentToleranceAmount,proto3,oneof"`
+}
+
+func (*CreateInvoiceRequest_UnderpaymentTolerancePercent) isCreateInvoiceRequest_UnderpaymentTolerance() {
+}
+
+func (*CreateInvoiceRequest_UnderpaymentToleranceAmount) isCreateInvoiceRequest_UnderpaymentTolerance() {
+}
+
+type CreateInvoiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PaymentId string `protobuf:"bytes,1,opt,name=paymentId,proto3" json:"paymentId,omitempty"`
+	Address   string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// BIP21, monero: or EIP-681 URI with the address and the required amount.
+	PaymentUri string `protobuf:"bytes,3,opt,name=paymentUri,proto3" json:"paymentUri,omitempty"`
+	// The QR code of paymentUri in the requested qrCodeFormat.
+	QrCode []byte `protobuf:"bytes,4,opt,name=qrCode,proto3" json:"qrCode,omitempty"`
+	// Set for the requests with coinOptions, in which case the fields above describe the first option.
+	GroupId string           `protobuf:"bytes,5,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	Options []*PaymentOption `protobuf:"bytes,6,rep,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *CreateInvoiceResponse) Reset() {
+	*x = CreateInvoiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceResponse) ProtoMessage() {}
+
+func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateInvoiceResponse) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetPaymentUri() string {
+	if x != nil {
+		return x.PaymentUri
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetQrCode() []byte {
+	if x != nil {
+		return x.QrCode
+	}
+	return nil
+}
+
+func (x *CreateInvoiceResponse) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetOptions() []*PaymentOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type InvoiceStatusStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserIds    []string            `protobuf:"bytes,1,rep,name=userIds,proto3" json:"userIds,omitempty"`
+	InvoiceIds []string            `protobuf:"bytes,2,rep,name=invoiceIds,proto3" json:"invoiceIds,omitempty"`
+	Coins      []CoinType          `protobuf:"varint,3,rep,packed,name=coins,proto3,enum=crypto.v1.CoinType" json:"coins,omitempty"`
+	Statuses   []InvoiceStatusType `protobuf:"varint,4,rep,packed,name=statuses,proto3,enum=invoice.v1.InvoiceStatusType" json:"statuses,omitempty"`
+	// If set, the events with seq greater than fromSeq are replayed before the live ones.
+	FromSeq *uint64 `protobuf:"varint,5,opt,name=fromSeq,proto3,oneof" json:"fromSeq,omitempty"`
+}
+
+func (x *InvoiceStatusStreamRequest) Reset() {
+	*x = InvoiceStatusStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvoiceStatusStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceStatusStreamRequest) ProtoMessage() {}
+
+func (x *InvoiceStatusStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceStatusStreamRequest.ProtoReflect.Descriptor instead.
+func (*InvoiceStatusStreamRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *InvoiceStatusStreamRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *InvoiceStatusStreamRequest) GetInvoiceIds() []string {
+	if x != nil {
+		return x.InvoiceIds
+	}
+	return nil
+}
+
+func (x *InvoiceStatusStreamRequest) GetCoins() []CoinType {
+	if x != nil {
+		return x.Coins
+	}
+	return nil
+}
+
+func (x *InvoiceStatusStreamRequest) GetStatuses() []InvoiceStatusType {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *InvoiceStatusStreamRequest) GetFromSeq() uint64 {
+	if x != nil && x.FromSeq != nil {
+		return *x.FromSeq
+	}
+	return 0
+}
+
+type InvoiceStatusStreamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Invoice *Invoice `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	Seq     uint64   `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *InvoiceStatusStreamResponse) Reset() {
+	*x = InvoiceStatusStreamResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvoiceStatusStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceStatusStreamResponse) ProtoMessage() {}
+
+func (x *InvoiceStatusStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceStatusStreamResponse.ProtoReflect.Descriptor instead.
+func (*InvoiceStatusStreamResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InvoiceStatusStreamResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+func (x *InvoiceStatusStreamResponse) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetInvoiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetInvoiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Invoice *Invoice `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+}
+
+func (x *GetInvoiceResponse) Reset() {
+	*x = GetInvoiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceResponse) ProtoMessage() {}
+
+func (x *GetInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+type GetInvoiceHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetInvoiceHistoryRequest) Reset() {
+	*x = GetInvoiceHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvoiceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceHistoryRequest) ProtoMessage() {}
+
+func (x *GetInvoiceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetInvoiceHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetInvoiceHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*InvoiceEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *GetInvoiceHistoryResponse) Reset() {
+	*x = GetInvoiceHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvoiceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceHistoryResponse) ProtoMessage() {}
+
+func (x *GetInvoiceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetInvoiceHistoryResponse) GetEvents() []*InvoiceEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type CancelInvoiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelInvoiceRequest) Reset() {
+	*x = CancelInvoiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelInvoiceRequest) ProtoMessage() {}
+
+func (x *CancelInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CancelInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelInvoiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelInvoiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Invoice *Invoice `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+}
+
+func (x *CancelInvoiceResponse) Reset() {
+	*x = CancelInvoiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelInvoiceResponse) ProtoMessage() {}
+
+func (x *CancelInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*CancelInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+type ListInvoicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId          *string                `protobuf:"bytes,1,opt,name=userId,proto3,oneof" json:"userId,omitempty"`
+	Coin            *CoinType              `protobuf:"varint,2,opt,name=coin,proto3,enum=crypto.v1.CoinType,oneof" json:"coin,omitempty"`
+	Status          *InvoiceStatusType     `protobuf:"varint,3,opt,name=status,proto3,enum=invoice.v1.InvoiceStatusType,oneof" json:"status,omitempty"`
+	CreatedAtFrom   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=createdAtFrom,proto3" json:"createdAtFrom,omitempty"`
+	CreatedAtTo     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAtTo,proto3" json:"createdAtTo,omitempty"`
+	ExpiresAtFrom   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expiresAtFrom,proto3" json:"expiresAtFrom,omitempty"`
+	ExpiresAtTo     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expiresAtTo,proto3" json:"expiresAtTo,omitempty"`
+	Limit           uint32                 `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset          uint32                 `protobuf:"varint,9,opt,name=offset,proto3" json:"offset,omitempty"`
+	ExternalOrderId *string                `protobuf:"bytes,10,opt,name=externalOrderId,proto3,oneof" json:"externalOrderId,omitempty"`
+	GroupId         *string                `protobuf:"bytes,11,opt,name=groupId,proto3,oneof" json:"groupId,omitempty"`
+}
+
+func (x *ListInvoicesRequest) Reset() {
+	*x = ListInvoicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInvoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvoicesRequest) ProtoMessage() {}
+
+func (x *ListInvoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvoicesRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListInvoicesRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *ListInvoicesRequest) GetCoin() CoinType {
+	if x != nil && x.Coin != nil {
+		return *x.Coin
+	}
+	return CoinType_XMR
+}
+
+func (x *ListInvoicesRequest) GetStatus() InvoiceStatusType {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return InvoiceStatusType_PENDING
+}
+
+func (x *ListInvoicesRequest) GetCreatedAtFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAtFrom
+	}
+	return nil
+}
+
+func (x *ListInvoicesRequest) GetCreatedAtTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAtTo
+	}
+	return nil
+}
+
+func (x *ListInvoicesRequest) GetExpiresAtFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAtFrom
+	}
+	return nil
+}
+
+func (x *ListInvoicesRequest) GetExpiresAtTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAtTo
+	}
+	return nil
+}
+
+func (x *ListInvoicesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListInvoicesRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListInvoicesRequest) GetExternalOrderId() string {
+	if x != nil && x.ExternalOrderId != nil {
+		return *x.ExternalOrderId
+	}
+	return ""
+}
+
+func (x *ListInvoicesRequest) GetGroupId() string {
+	if x != nil && x.GroupId != nil {
+		return *x.GroupId
+	}
+	return ""
+}
+
+type ListInvoicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Invoices []*Invoice `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
+}
+
+func (x *ListInvoicesResponse) Reset() {
+	*x = ListInvoicesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInvoicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvoicesResponse) ProtoMessage() {}
+
+func (x *ListInvoicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvoicesResponse.ProtoReflect.Descriptor instead.
+func (*ListInvoicesResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListInvoicesResponse) GetInvoices() []*Invoice {
+	if x != nil {
+		return x.Invoices
+	}
+	return nil
+}
+
+var File_invoice_proto protoreflect.FileDescriptor
 
 var file_invoice_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
 	0x0a, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
 	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x63, 0x72,
-	0x79, 0x70, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xff, 0x03, 0x0a, 0x07, 0x49,
-	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f,
-	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63,
-	0x72, 0x79, 0x70, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x27, 0x0a, 0x04,
-	0x63, 0x6f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x79,
-	0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
-	0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x72,
-	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a,
-	0x0c, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x34, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x15, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
-	0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41, 0x74,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41, 0x74, 0x12,
-	0x35, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x1d, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76,
-	0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
-	0x73, 0x41, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x78, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xaf, 0x01, 0x0a,
-	0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a,
-	0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72,
-	0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x4f,
-	0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x79, 0x6d, 0x65,
-	0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x79, 0x6d,
-	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
-	0x1c, 0x0a, 0x1a, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4c, 0x0a,
-	0x1b, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07,
-	0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x79, 0x70, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x44, 0x0a, 0x06, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x20,
+	0x0a, 0x0b, 0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x55, 0x6e, 0x69, 0x74, 0x73,
+	0x22, 0xfd, 0x0a, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0d,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x27, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x13, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x2a, 0x0a, 0x0e, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0c, 0x61, 0x63, 0x74, 0x75, 0x61,
+	0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x42, 0x02, 0x18,
+	0x01, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x34, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x3c, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41, 0x74, 0x12, 0x35, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e,
 	0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69,
-	0x63, 0x65, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2a, 0x51, 0x0a, 0x11, 0x49,
-	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x13, 0x0a,
-	0x0f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x4d, 0x50, 0x4f, 0x4f, 0x4c,
-	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x02, 0x12,
-	0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x03, 0x32, 0xd0,
-	0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x54, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69,
-	0x63, 0x65, 0x12, 0x20, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x68, 0x0a, 0x13, 0x49, 0x6e, 0x76, 0x6f, 0x69,
-	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x26,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41,
+	0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x78, 0x49, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x15, 0x75, 0x6e,
+	0x64, 0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x42, 0x02, 0x18, 0x01, 0x52, 0x15, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6c, 0x65, 0x72,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x70, 0x61, 0x69, 0x64,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x42, 0x02, 0x18, 0x01,
+	0x52, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x70, 0x61, 0x69, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x23, 0x0a, 0x0a, 0x66, 0x69, 0x61, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0a, 0x66, 0x69, 0x61, 0x74, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0c, 0x66, 0x69, 0x61, 0x74, 0x43, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0c, 0x66,
+	0x69, 0x61, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x88, 0x01, 0x01, 0x12, 0x27,
+	0x0a, 0x0c, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x78, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x3d, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x16, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x44, 0x0a, 0x13, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x45, 0x78, 0x61, 0x63, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x13, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x45, 0x78, 0x61, 0x63, 0x74, 0x12, 0x40, 0x0a, 0x11, 0x61, 0x63, 0x74, 0x75,
+	0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x78, 0x61, 0x63, 0x74, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x11, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x78, 0x61, 0x63, 0x74, 0x12, 0x52, 0x0a, 0x1a, 0x75, 0x6e,
+	0x64, 0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61,
+	0x6e, 0x63, 0x65, 0x45, 0x78, 0x61, 0x63, 0x74, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x1a, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x78, 0x61, 0x63, 0x74, 0x12, 0x44,
+	0x0a, 0x13, 0x6f, 0x76, 0x65, 0x72, 0x70, 0x61, 0x69, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x45, 0x78, 0x61, 0x63, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x13, 0x6f, 0x76, 0x65, 0x72, 0x70, 0x61, 0x69, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x45,
+	0x78, 0x61, 0x63, 0x74, 0x12, 0x17, 0x0a, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x18, 0x1b, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x03, 0x52, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x66, 0x69, 0x61, 0x74, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x66, 0x69, 0x61, 0x74, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6d, 0x65, 0x6d, 0x6f,
+	0x22, 0xb2, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x27, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x25, 0x0a, 0x0b, 0x65, 0x78, 0x61, 0x63, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x78, 0x61, 0x63, 0x74, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x43,
+	0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x63, 0x6f, 0x69,
+	0x6e, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x65, 0x78, 0x61,
+	0x63, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x6f, 0x69,
+	0x6e, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xb8, 0x02, 0x0a, 0x0d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x55,
+	0x72, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x55, 0x72, 0x69, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x71, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x34, 0x0a, 0x0b,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x78, 0x61, 0x63, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x78, 0x61,
+	0x63, 0x74, 0x12, 0x17, 0x0a, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6d, 0x65, 0x6d, 0x6f,
+	0x22, 0x40, 0x0a, 0x0a, 0x46, 0x69, 0x61, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x8e, 0x03, 0x0a, 0x0c, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x54, 0x79, 0x70, 0x65, 0x48, 0x00, 0x52, 0x09, 0x6f, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x29, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0d, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x6f, 0x72, 0x67,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x6f, 0x72, 0x67, 0x12, 0x34, 0x0a,
+	0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x78, 0x61, 0x63, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x78,
+	0x61, 0x63, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6f, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x95, 0x07, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
+	0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x44, 0x0a, 0x1c, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x70, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x1c, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6c, 0x65, 0x72,
+	0x61, 0x6e, 0x63, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x42, 0x0a, 0x1b, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6c, 0x65, 0x72,
+	0x61, 0x6e, 0x63, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
+	0x48, 0x00, 0x52, 0x1b, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x2a, 0x0a, 0x04, 0x66, 0x69, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x61, 0x74, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x04, 0x66, 0x69, 0x61, 0x74, 0x12, 0x19, 0x0a, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x0c, 0x71, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x69,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0c, 0x71, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0e,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01,
+	0x01, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x4a, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2e, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x6f, 0x69,
+	0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0b, 0x65, 0x78, 0x61, 0x63, 0x74, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x0b, 0x65, 0x78, 0x61, 0x63,
+	0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x63, 0x6f,
+	0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x08,
+	0x63, 0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x17, 0x0a, 0x15, 0x75, 0x6e, 0x64, 0x65,
+	0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63,
+	0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x11, 0x0a, 0x0f, 0x5f,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x0e,
+	0x0a, 0x0c, 0x5f, 0x65, 0x78, 0x61, 0x63, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x0b,
+	0x0a, 0x09, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xd6, 0x01, 0x0a, 0x15,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x72, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x72, 0x69, 0x12, 0x16, 0x0a,
+	0x06, 0x71, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x71,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12,
+	0x33, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0xe7, 0x01, 0x0a, 0x1a, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x12, 0x29, 0x0a,
+	0x05, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x05, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x88,
+	0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x22, 0x5e,
+	0x0a, 0x1b, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a,
+	0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
 	0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f,
-	0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x69, 0x63, 0x65, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x22, 0x23,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x43, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x69, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52,
+	0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x22, 0x2a, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x49,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0x4d, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x30, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0x26, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x49, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x46, 0x0a, 0x15, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x22, 0xd7, 0x04, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x48, 0x01, 0x52, 0x04, 0x63,
+	0x6f, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x3a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
 	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
-	0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x54, 0x79, 0x70, 0x65, 0x48, 0x02, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88,
+	0x01, 0x01, 0x12, 0x40, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x46,
+	0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x46, 0x72, 0x6f, 0x6d, 0x12, 0x3c, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x54, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x54, 0x6f, 0x12, 0x40, 0x0a, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x46,
+	0x72, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x46, 0x72, 0x6f, 0x6d, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41,
+	0x74, 0x54, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x54, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x12, 0x2d, 0x0a, 0x0f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x1d, 0x0a, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x04, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x09,
+	0x0a, 0x07, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x63, 0x6f,
+	0x69, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x12, 0x0a,
+	0x10, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x22, 0x47, 0x0a,
+	0x14, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x08, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x2a, 0x8b, 0x01, 0x0a, 0x11, 0x49, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07,
+	0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x45, 0x4e,
+	0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x4d, 0x50, 0x4f, 0x4f, 0x4c, 0x10, 0x01, 0x12, 0x0b,
+	0x0a, 0x07, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x43,
+	0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x41,
+	0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x41, 0x52,
+	0x54, 0x49, 0x41, 0x4c, 0x4c, 0x59, 0x5f, 0x50, 0x41, 0x49, 0x44, 0x10, 0x05, 0x12, 0x15, 0x0a,
+	0x11, 0x50, 0x41, 0x49, 0x44, 0x5f, 0x41, 0x46, 0x54, 0x45, 0x52, 0x5f, 0x45, 0x58, 0x50, 0x49,
+	0x52, 0x59, 0x10, 0x06, 0x2a, 0x42, 0x0a, 0x0c, 0x51, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x0c, 0x51, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x51, 0x52, 0x5f, 0x43, 0x4f, 0x44,
+	0x45, 0x5f, 0x50, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x51, 0x52, 0x5f, 0x43, 0x4f,
+	0x44, 0x45, 0x5f, 0x53, 0x56, 0x47, 0x10, 0x02, 0x32, 0xa8, 0x04, 0x0a, 0x0e, 0x49, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x54, 0x0a, 0x0d, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x20, 0x2e, 0x69,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x68, 0x0a, 0x13, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x26, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x27, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4b, 0x0a, 0x0a, 0x47,
+	0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x69, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74,
+	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0d, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x20, 0x2e, 0x69,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x60, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x24, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x69,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -523,35 +2098,88 @@ func file_invoice_proto_rawDescGZIP() []byte {
 	return file_invoice_proto_rawDescData
 }
 
-var file_invoice_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_invoice_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_invoice_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_invoice_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_invoice_proto_goTypes = []any{
 	(InvoiceStatusType)(0),              // 0: invoice.v1.InvoiceStatusType
-	(*Invoice)(nil),                     // 1: invoice.v1.Invoice
-	(*CreateInvoiceRequest)(nil),        // 2: invoice.v1.CreateInvoiceRequest
-	(*CreateInvoiceResponse)(nil),       // 3: invoice.v1.CreateInvoiceResponse
-	(*InvoiceStatusStreamRequest)(nil),  // 4: invoice.v1.InvoiceStatusStreamRequest
-	(*InvoiceStatusStreamResponse)(nil), // 5: invoice.v1.InvoiceStatusStreamResponse
-	(CoinType)(0),                       // 6: crypto.v1.CoinType
-	(*timestamppb.Timestamp)(nil),       // 7: google.protobuf.Timestamp
+	(QrCodeFormat)(0),                   // 1: invoice.v1.QrCodeFormat
+	(*Amount)(nil),                      // 2: invoice.v1.Amount
+	(*Invoice)(nil),                     // 3: invoice.v1.Invoice
+	(*CoinOption)(nil),                  // 4: invoice.v1.CoinOption
+	(*PaymentOption)(nil),               // 5: invoice.v1.PaymentOption
+	(*FiatAmount)(nil),                  // 6: invoice.v1.FiatAmount
+	(*InvoiceEvent)(nil),                // 7: invoice.v1.InvoiceEvent
+	(*CreateInvoiceRequest)(nil),        // 8: invoice.v1.CreateInvoiceRequest
+	(*CreateInvoiceResponse)(nil),       // 9: invoice.v1.CreateInvoiceResponse
+	(*InvoiceStatusStreamRequest)(nil),  // 10: invoice.v1.InvoiceStatusStreamRequest
+	(*InvoiceStatusStreamResponse)(nil), // 11: invoice.v1.InvoiceStatusStreamResponse
+	(*GetInvoiceRequest)(nil),           // 12: invoice.v1.GetInvoiceRequest
+	(*GetInvoiceResponse)(nil),          // 13: invoice.v1.GetInvoiceResponse
+	(*GetInvoiceHistoryRequest)(nil),    // 14: invoice.v1.GetInvoiceHistoryRequest
+	(*GetInvoiceHistoryResponse)(nil),   // 15: invoice.v1.GetInvoiceHistoryResponse
+	(*CancelInvoiceRequest)(nil),        // 16: invoice.v1.CancelInvoiceRequest
+	(*CancelInvoiceResponse)(nil),       // 17: invoice.v1.CancelInvoiceResponse
+	(*ListInvoicesRequest)(nil),         // 18: invoice.v1.ListInvoicesRequest
+	(*ListInvoicesResponse)(nil),        // 19: invoice.v1.ListInvoicesResponse
+	nil,                                 // 20: invoice.v1.Invoice.MetadataEntry
+	nil,                                 // 21: invoice.v1.CreateInvoiceRequest.MetadataEntry
+	(CoinType)(0),                       // 22: crypto.v1.CoinType
+	(*timestamppb.Timestamp)(nil),       // 23: google.protobuf.Timestamp
 }
 var file_invoice_proto_depIdxs = []int32{
-	6, // 0: invoice.v1.Invoice.coin:type_name -> crypto.v1.CoinType
-	7, // 1: invoice.v1.Invoice.createdAt:type_name -> google.protobuf.Timestamp
-	7, // 2: invoice.v1.Invoice.confirmedAt:type_name -> google.protobuf.Timestamp
-	0, // 3: invoice.v1.Invoice.status:type_name -> invoice.v1.InvoiceStatusType
-	7, // 4: invoice.v1.Invoice.expiresAt:type_name -> google.protobuf.Timestamp
-	6, // 5: invoice.v1.CreateInvoiceRequest.coin:type_name -> crypto.v1.CoinType
-	1, // 6: invoice.v1.InvoiceStatusStreamResponse.invoice:type_name -> invoice.v1.Invoice
-	2, // 7: invoice.v1.InvoiceService.CreateInvoice:input_type -> invoice.v1.CreateInvoiceRequest
-	4, // 8: invoice.v1.InvoiceService.InvoiceStatusStream:input_type -> invoice.v1.InvoiceStatusStreamRequest
-	3, // 9: invoice.v1.InvoiceService.CreateInvoice:output_type -> invoice.v1.CreateInvoiceResponse
-	5, // 10: invoice.v1.InvoiceService.InvoiceStatusStream:output_type -> invoice.v1.InvoiceStatusStreamResponse
-	9, // [9:11] is the sub-list for method output_type
-	7, // [7:9] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	22, // 0: invoice.v1.Invoice.coin:type_name -> crypto.v1.CoinType
+	23, // 1: invoice.v1.Invoice.createdAt:type_name -> google.protobuf.Timestamp
+	23, // 2: invoice.v1.Invoice.confirmedAt:type_name -> google.protobuf.Timestamp
+	0,  // 3: invoice.v1.Invoice.status:type_name -> invoice.v1.InvoiceStatusType
+	23, // 4: invoice.v1.Invoice.expiresAt:type_name -> google.protobuf.Timestamp
+	20, // 5: invoice.v1.Invoice.metadata:type_name -> invoice.v1.Invoice.MetadataEntry
+	2,  // 6: invoice.v1.Invoice.requiredAmountExact:type_name -> invoice.v1.Amount
+	2,  // 7: invoice.v1.Invoice.actualAmountExact:type_name -> invoice.v1.Amount
+	2,  // 8: invoice.v1.Invoice.underpaymentToleranceExact:type_name -> invoice.v1.Amount
+	2,  // 9: invoice.v1.Invoice.overpaidAmountExact:type_name -> invoice.v1.Amount
+	22, // 10: invoice.v1.CoinOption.coin:type_name -> crypto.v1.CoinType
+	22, // 11: invoice.v1.PaymentOption.coin:type_name -> crypto.v1.CoinType
+	2,  // 12: invoice.v1.PaymentOption.amountExact:type_name -> invoice.v1.Amount
+	0,  // 13: invoice.v1.InvoiceEvent.oldStatus:type_name -> invoice.v1.InvoiceStatusType
+	0,  // 14: invoice.v1.InvoiceEvent.newStatus:type_name -> invoice.v1.InvoiceStatusType
+	23, // 15: invoice.v1.InvoiceEvent.createdAt:type_name -> google.protobuf.Timestamp
+	2,  // 16: invoice.v1.InvoiceEvent.amountExact:type_name -> invoice.v1.Amount
+	22, // 17: invoice.v1.CreateInvoiceRequest.coin:type_name -> crypto.v1.CoinType
+	6,  // 18: invoice.v1.CreateInvoiceRequest.fiat:type_name -> invoice.v1.FiatAmount
+	1,  // 19: invoice.v1.CreateInvoiceRequest.qrCodeFormat:type_name -> invoice.v1.QrCodeFormat
+	21, // 20: invoice.v1.CreateInvoiceRequest.metadata:type_name -> invoice.v1.CreateInvoiceRequest.MetadataEntry
+	4,  // 21: invoice.v1.CreateInvoiceRequest.coinOptions:type_name -> invoice.v1.CoinOption
+	5,  // 22: invoice.v1.CreateInvoiceResponse.options:type_name -> invoice.v1.PaymentOption
+	22, // 23: invoice.v1.InvoiceStatusStreamRequest.coins:type_name -> crypto.v1.CoinType
+	0,  // 24: invoice.v1.InvoiceStatusStreamRequest.statuses:type_name -> invoice.v1.InvoiceStatusType
+	3,  // 25: invoice.v1.InvoiceStatusStreamResponse.invoice:type_name -> invoice.v1.Invoice
+	3,  // 26: invoice.v1.GetInvoiceResponse.invoice:type_name -> invoice.v1.Invoice
+	7,  // 27: invoice.v1.GetInvoiceHistoryResponse.events:type_name -> invoice.v1.InvoiceEvent
+	3,  // 28: invoice.v1.CancelInvoiceResponse.invoice:type_name -> invoice.v1.Invoice
+	22, // 29: invoice.v1.ListInvoicesRequest.coin:type_name -> crypto.v1.CoinType
+	0,  // 30: invoice.v1.ListInvoicesRequest.status:type_name -> invoice.v1.InvoiceStatusType
+	23, // 31: invoice.v1.ListInvoicesRequest.createdAtFrom:type_name -> google.protobuf.Timestamp
+	23, // 32: invoice.v1.ListInvoicesRequest.createdAtTo:type_name -> google.protobuf.Timestamp
+	23, // 33: invoice.v1.ListInvoicesRequest.expiresAtFrom:type_name -> google.protobuf.Timestamp
+	23, // 34: invoice.v1.ListInvoicesRequest.expiresAtTo:type_name -> google.protobuf.Timestamp
+	3,  // 35: invoice.v1.ListInvoicesResponse.invoices:type_name -> invoice.v1.Invoice
+	8,  // 36: invoice.v1.InvoiceService.CreateInvoice:input_type -> invoice.v1.CreateInvoiceRequest
+	10, // 37: invoice.v1.InvoiceService.InvoiceStatusStream:input_type -> invoice.v1.InvoiceStatusStreamRequest
+	12, // 38: invoice.v1.InvoiceService.GetInvoice:input_type -> invoice.v1.GetInvoiceRequest
+	18, // 39: invoice.v1.InvoiceService.ListInvoices:input_type -> invoice.v1.ListInvoicesRequest
+	16, // 40: invoice.v1.InvoiceService.CancelInvoice:input_type -> invoice.v1.CancelInvoiceRequest
+	14, // 41: invoice.v1.InvoiceService.GetInvoiceHistory:input_type -> invoice.v1.GetInvoiceHistoryRequest
+	9,  // 42: invoice.v1.InvoiceService.CreateInvoice:output_type -> invoice.v1.CreateInvoiceResponse
+	11, // 43: invoice.v1.InvoiceService.InvoiceStatusStream:output_type -> invoice.v1.InvoiceStatusStreamResponse
+	13, // 44: invoice.v1.InvoiceService.GetInvoice:output_type -> invoice.v1.GetInvoiceResponse
+	19, // 45: invoice.v1.InvoiceService.ListInvoices:output_type -> invoice.v1.ListInvoicesResponse
+	17, // 46: invoice.v1.InvoiceService.CancelInvoice:output_type -> invoice.v1.CancelInvoiceResponse
+	15, // 47: invoice.v1.InvoiceService.GetInvoiceHistory:output_type -> invoice.v1.GetInvoiceHistoryResponse
+	42, // [42:48] is the sub-list for method output_type
+	36, // [36:42] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_invoice_proto_init() }
@@ -562,7 +2190,7 @@ func file_invoice_proto_init() {
 	file_crypto_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_invoice_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*Invoice); i {
+			switch v := v.(*Amount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -574,7 +2202,7 @@ func file_invoice_proto_init() {
 			}
 		}
 		file_invoice_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateInvoiceRequest); i {
+			switch v := v.(*Invoice); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -586,7 +2214,7 @@ func file_invoice_proto_init() {
 			}
 		}
 		file_invoice_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateInvoiceResponse); i {
+			switch v := v.(*CoinOption); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -598,7 +2226,7 @@ func file_invoice_proto_init() {
 			}
 		}
 		file_invoice_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*InvoiceStatusStreamRequest); i {
+			switch v := v.(*PaymentOption); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -610,6 +2238,66 @@ func file_invoice_proto_init() {
 			}
 		}
 		file_invoice_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*FiatAmount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*InvoiceEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateInvoiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateInvoiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*InvoiceStatusStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[9].Exporter = func(v any, i int) any {
 			switch v := v.(*InvoiceStatusStreamResponse); i {
 			case 0:
 				return &v.state
@@ -621,14 +2309,120 @@ func file_invoice_proto_init() {
 				return nil
 			}
 		}
+		file_invoice_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInvoiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInvoiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInvoiceHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInvoiceHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelInvoiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelInvoiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*ListInvoicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*ListInvoicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_invoice_proto_msgTypes[1].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[2].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[3].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[5].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[6].OneofWrappers = []any{
+		(*CreateInvoiceRequest_UnderpaymentTolerancePercent)(nil),
+		(*CreateInvoiceRequest_UnderpaymentToleranceAmount)(nil),
 	}
+	file_invoice_proto_msgTypes[8].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[16].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_invoice_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
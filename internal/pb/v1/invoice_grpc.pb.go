@@ -21,6 +21,10 @@ const _ = grpc.SupportPackageIsVersion8
 const (
 	InvoiceService_CreateInvoice_FullMethodName       = "/invoice.v1.InvoiceService/CreateInvoice"
 	InvoiceService_InvoiceStatusStream_FullMethodName = "/invoice.v1.InvoiceService/InvoiceStatusStream"
+	InvoiceService_GetInvoice_FullMethodName          = "/invoice.v1.InvoiceService/GetInvoice"
+	InvoiceService_ListInvoices_FullMethodName        = "/invoice.v1.InvoiceService/ListInvoices"
+	InvoiceService_CancelInvoice_FullMethodName       = "/invoice.v1.InvoiceService/CancelInvoice"
+	InvoiceService_GetInvoiceHistory_FullMethodName   = "/invoice.v1.InvoiceService/GetInvoiceHistory"
 )
 
 // InvoiceServiceClient is the client API for InvoiceService service.
@@ -29,6 +33,10 @@ const (
 type InvoiceServiceClient interface {
 	CreateInvoice(ctx context.Context, in *CreateInvoiceRequest, opts ...grpc.CallOption) (*CreateInvoiceResponse, error)
 	InvoiceStatusStream(ctx context.Context, in *InvoiceStatusStreamRequest, opts ...grpc.CallOption) (InvoiceService_InvoiceStatusStreamClient, error)
+	GetInvoice(ctx context.Context, in *GetInvoiceRequest, opts ...grpc.CallOption) (*GetInvoiceResponse, error)
+	ListInvoices(ctx context.Context, in *ListInvoicesRequest, opts ...grpc.CallOption) (*ListInvoicesResponse, error)
+	CancelInvoice(ctx context.Context, in *CancelInvoiceRequest, opts ...grpc.CallOption) (*CancelInvoiceResponse, error)
+	GetInvoiceHistory(ctx context.Context, in *GetInvoiceHistoryRequest, opts ...grpc.CallOption) (*GetInvoiceHistoryResponse, error)
 }
 
 type invoiceServiceClient struct {
@@ -82,12 +90,56 @@ func (x *invoiceServiceInvoiceStatusStreamClient) Recv() (*InvoiceStatusStreamRe
 	return m, nil
 }
 
+func (c *invoiceServiceClient) GetInvoice(ctx context.Context, in *GetInvoiceRequest, opts ...grpc.CallOption) (*GetInvoiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInvoiceResponse)
+	err := c.cc.Invoke(ctx, InvoiceService_GetInvoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceServiceClient) ListInvoices(ctx context.Context, in *ListInvoicesRequest, opts ...grpc.CallOption) (*ListInvoicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvoicesResponse)
+	err := c.cc.Invoke(ctx, InvoiceService_ListInvoices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceServiceClient) CancelInvoice(ctx context.Context, in *CancelInvoiceRequest, opts ...grpc.CallOption) (*CancelInvoiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelInvoiceResponse)
+	err := c.cc.Invoke(ctx, InvoiceService_CancelInvoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceServiceClient) GetInvoiceHistory(ctx context.Context, in *GetInvoiceHistoryRequest, opts ...grpc.CallOption) (*GetInvoiceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInvoiceHistoryResponse)
+	err := c.cc.Invoke(ctx, InvoiceService_GetInvoiceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InvoiceServiceServer is the server API for InvoiceService service.
 // All implementations must embed UnimplementedInvoiceServiceServer
 // for forward compatibility
 type InvoiceServiceServer interface {
 	CreateInvoice(context.Context, *CreateInvoiceRequest) (*CreateInvoiceResponse, error)
 	InvoiceStatusStream(*InvoiceStatusStreamRequest, InvoiceService_InvoiceStatusStreamServer) error
+	GetInvoice(context.Context, *GetInvoiceRequest) (*GetInvoiceResponse, error)
+	ListInvoices(context.Context, *ListInvoicesRequest) (*ListInvoicesResponse, error)
+	CancelInvoice(context.Context, *CancelInvoiceRequest) (*CancelInvoiceResponse, error)
+	GetInvoiceHistory(context.Context, *GetInvoiceHistoryRequest) (*GetInvoiceHistoryResponse, error)
 	mustEmbedUnimplementedInvoiceServiceServer()
 }
 
@@ -101,6 +153,18 @@ func (UnimplementedInvoiceServiceServer) CreateInvoice(context.Context, *CreateI
 func (UnimplementedInvoiceServiceServer) InvoiceStatusStream(*InvoiceStatusStreamRequest, InvoiceService_InvoiceStatusStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method InvoiceStatusStream not implemented")
 }
+func (UnimplementedInvoiceServiceServer) GetInvoice(context.Context, *GetInvoiceRequest) (*GetInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvoice not implemented")
+}
+func (UnimplementedInvoiceServiceServer) ListInvoices(context.Context, *ListInvoicesRequest) (*ListInvoicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvoices not implemented")
+}
+func (UnimplementedInvoiceServiceServer) CancelInvoice(context.Context, *CancelInvoiceRequest) (*CancelInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelInvoice not implemented")
+}
+func (UnimplementedInvoiceServiceServer) GetInvoiceHistory(context.Context, *GetInvoiceHistoryRequest) (*GetInvoiceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvoiceHistory not implemented")
+}
 func (UnimplementedInvoiceServiceServer) mustEmbedUnimplementedInvoiceServiceServer() {}
 
 // UnsafeInvoiceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -153,6 +217,78 @@ func (x *invoiceServiceInvoiceStatusStreamServer) Send(m *InvoiceStatusStreamRes
 	return x.ServerStream.SendMsg(m)
 }
 
+func _InvoiceService_GetInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceServiceServer).GetInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceService_GetInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceServiceServer).GetInvoice(ctx, req.(*GetInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InvoiceService_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvoicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceServiceServer).ListInvoices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceService_ListInvoices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceServiceServer).ListInvoices(ctx, req.(*ListInvoicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InvoiceService_CancelInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceServiceServer).CancelInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceService_CancelInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceServiceServer).CancelInvoice(ctx, req.(*CancelInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InvoiceService_GetInvoiceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInvoiceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceServiceServer).GetInvoiceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceService_GetInvoiceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceServiceServer).GetInvoiceHistory(ctx, req.(*GetInvoiceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InvoiceService_ServiceDesc is the grpc.ServiceDesc for InvoiceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -164,6 +300,22 @@ var InvoiceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateInvoice",
 			Handler:    _InvoiceService_CreateInvoice_Handler,
 		},
+		{
+			MethodName: "GetInvoice",
+			Handler:    _InvoiceService_GetInvoice_Handler,
+		},
+		{
+			MethodName: "ListInvoices",
+			Handler:    _InvoiceService_ListInvoices_Handler,
+		},
+		{
+			MethodName: "CancelInvoice",
+			Handler:    _InvoiceService_CancelInvoice_Handler,
+		},
+		{
+			MethodName: "GetInvoiceHistory",
+			Handler:    _InvoiceService_GetInvoiceHistory_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -119,12 +119,17 @@ type UpdateCryptoKeysRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string                `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	XmrReq *XmrKeysUpdateRequest `protobuf:"bytes,2,opt,name=xmrReq,proto3,oneof" json:"xmrReq,omitempty"`
-	BtcReq *BtcKeysUpdateRequest `protobuf:"bytes,3,opt,name=btcReq,proto3,oneof" json:"btcReq,omitempty"`
-	LtcReq *LtcKeysUpdateRequest `protobuf:"bytes,4,opt,name=ltcReq,proto3,oneof" json:"ltcReq,omitempty"`
-	EthReq *EthKeysUpdateRequest `protobuf:"bytes,5,opt,name=ethReq,proto3,oneof" json:"ethReq,omitempty"`
-	BnbReq *BnbKeysUpdateRequest `protobuf:"bytes,6,opt,name=bnbReq,proto3,oneof" json:"bnbReq,omitempty"`
+	UserId  string                  `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	XmrReq  *XmrKeysUpdateRequest   `protobuf:"bytes,2,opt,name=xmrReq,proto3,oneof" json:"xmrReq,omitempty"`
+	BtcReq  *BtcKeysUpdateRequest   `protobuf:"bytes,3,opt,name=btcReq,proto3,oneof" json:"btcReq,omitempty"`
+	LtcReq  *LtcKeysUpdateRequest   `protobuf:"bytes,4,opt,name=ltcReq,proto3,oneof" json:"ltcReq,omitempty"`
+	EthReq  *EthKeysUpdateRequest   `protobuf:"bytes,5,opt,name=ethReq,proto3,oneof" json:"ethReq,omitempty"`
+	BnbReq  *BnbKeysUpdateRequest   `protobuf:"bytes,6,opt,name=bnbReq,proto3,oneof" json:"bnbReq,omitempty"`
+	TonReq  *TonKeysUpdateRequest   `protobuf:"bytes,7,opt,name=tonReq,proto3,oneof" json:"tonReq,omitempty"`
+	TrxReq  *TrxKeysUpdateRequest   `protobuf:"bytes,8,opt,name=trxReq,proto3,oneof" json:"trxReq,omitempty"`
+	EvmReqs []*EvmKeysUpdateRequest `protobuf:"bytes,9,rep,name=evmReqs,proto3" json:"evmReqs,omitempty"`
+	// Applies to the addresses of all the coins of the user.
+	AddressReusePolicy *AddressReusePolicy `protobuf:"varint,10,opt,name=addressReusePolicy,proto3,enum=crypto.v1.AddressReusePolicy,oneof" json:"addressReusePolicy,omitempty"`
 }
 
 func (x *UpdateCryptoKeysRequest) Reset() {
@@ -201,6 +206,34 @@ func (x *UpdateCryptoKeysRequest) GetBnbReq() *BnbKeysUpdateRequest {
 	return nil
 }
 
+func (x *UpdateCryptoKeysRequest) GetTonReq() *TonKeysUpdateRequest {
+	if x != nil {
+		return x.TonReq
+	}
+	return nil
+}
+
+func (x *UpdateCryptoKeysRequest) GetTrxReq() *TrxKeysUpdateRequest {
+	if x != nil {
+		return x.TrxReq
+	}
+	return nil
+}
+
+func (x *UpdateCryptoKeysRequest) GetEvmReqs() []*EvmKeysUpdateRequest {
+	if x != nil {
+		return x.EvmReqs
+	}
+	return nil
+}
+
+func (x *UpdateCryptoKeysRequest) GetAddressReusePolicy() AddressReusePolicy {
+	if x != nil && x.AddressReusePolicy != nil {
+		return *x.AddressReusePolicy
+	}
+	return AddressReusePolicy_ADDRESS_REUSE_POLICY_REUSE
+}
+
 type UpdateCryptoKeysResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -239,6 +272,272 @@ func (*UpdateCryptoKeysResponse) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{3}
 }
 
+type UpdateAddressPoolConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	// The coin whose addresses are derived, e.g. ETH rather than USDT_ERC20, whose invoices take the addresses of the ETH pool.
+	Coin CoinType `protobuf:"varint,2,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
+	// If set, coin is ignored. The EVM chains declared in the config (e.g. POLYGON) have it only.
+	CoinCode *string `protobuf:"bytes,3,opt,name=coinCode,proto3,oneof" json:"coinCode,omitempty"`
+	// The free addresses kept pre-derived, so that creating an invoice doesn't have to derive one.
+	MinFreeAddresses uint32 `protobuf:"varint,4,opt,name=minFreeAddresses,proto3" json:"minFreeAddresses,omitempty"`
+	// The addresses that may be derived after the last one that received funds (BIP44 uses 20). 0, the default, turns the limit off.
+	// Once reached, invoices are only created on the free addresses, so under NEVER_REUSE the creation fails until an address receives funds.
+	GapLimit uint32 `protobuf:"varint,5,opt,name=gapLimit,proto3" json:"gapLimit,omitempty"`
+}
+
+func (x *UpdateAddressPoolConfigRequest) Reset() {
+	*x = UpdateAddressPoolConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateAddressPoolConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAddressPoolConfigRequest) ProtoMessage() {}
+
+func (x *UpdateAddressPoolConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAddressPoolConfigRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAddressPoolConfigRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateAddressPoolConfigRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateAddressPoolConfigRequest) GetCoin() CoinType {
+	if x != nil {
+		return x.Coin
+	}
+	return CoinType_XMR
+}
+
+func (x *UpdateAddressPoolConfigRequest) GetCoinCode() string {
+	if x != nil && x.CoinCode != nil {
+		return *x.CoinCode
+	}
+	return ""
+}
+
+func (x *UpdateAddressPoolConfigRequest) GetMinFreeAddresses() uint32 {
+	if x != nil {
+		return x.MinFreeAddresses
+	}
+	return 0
+}
+
+func (x *UpdateAddressPoolConfigRequest) GetGapLimit() uint32 {
+	if x != nil {
+		return x.GapLimit
+	}
+	return 0
+}
+
+type UpdateAddressPoolConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateAddressPoolConfigResponse) Reset() {
+	*x = UpdateAddressPoolConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateAddressPoolConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAddressPoolConfigResponse) ProtoMessage() {}
+
+func (x *UpdateAddressPoolConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAddressPoolConfigResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAddressPoolConfigResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{5}
+}
+
+type GetAddressPoolStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string   `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Coin   CoinType `protobuf:"varint,2,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
+	// If set, coin is ignored.
+	CoinCode *string `protobuf:"bytes,3,opt,name=coinCode,proto3,oneof" json:"coinCode,omitempty"`
+}
+
+func (x *GetAddressPoolStatsRequest) Reset() {
+	*x = GetAddressPoolStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAddressPoolStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressPoolStatsRequest) ProtoMessage() {}
+
+func (x *GetAddressPoolStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressPoolStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetAddressPoolStatsRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAddressPoolStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetAddressPoolStatsRequest) GetCoin() CoinType {
+	if x != nil {
+		return x.Coin
+	}
+	return CoinType_XMR
+}
+
+func (x *GetAddressPoolStatsRequest) GetCoinCode() string {
+	if x != nil && x.CoinCode != nil {
+		return *x.CoinCode
+	}
+	return ""
+}
+
+type GetAddressPoolStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinFreeAddresses uint32 `protobuf:"varint,1,opt,name=minFreeAddresses,proto3" json:"minFreeAddresses,omitempty"`
+	GapLimit         uint32 `protobuf:"varint,2,opt,name=gapLimit,proto3" json:"gapLimit,omitempty"`
+	TotalAddresses   uint64 `protobuf:"varint,3,opt,name=totalAddresses,proto3" json:"totalAddresses,omitempty"`
+	FreeAddresses    uint64 `protobuf:"varint,4,opt,name=freeAddresses,proto3" json:"freeAddresses,omitempty"`
+	// The addresses derived after the last one that received funds.
+	GapAddresses uint64 `protobuf:"varint,5,opt,name=gapAddresses,proto3" json:"gapAddresses,omitempty"`
+}
+
+func (x *GetAddressPoolStatsResponse) Reset() {
+	*x = GetAddressPoolStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAddressPoolStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressPoolStatsResponse) ProtoMessage() {}
+
+func (x *GetAddressPoolStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressPoolStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetAddressPoolStatsResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAddressPoolStatsResponse) GetMinFreeAddresses() uint32 {
+	if x != nil {
+		return x.MinFreeAddresses
+	}
+	return 0
+}
+
+func (x *GetAddressPoolStatsResponse) GetGapLimit() uint32 {
+	if x != nil {
+		return x.GapLimit
+	}
+	return 0
+}
+
+func (x *GetAddressPoolStatsResponse) GetTotalAddresses() uint64 {
+	if x != nil {
+		return x.TotalAddresses
+	}
+	return 0
+}
+
+func (x *GetAddressPoolStatsResponse) GetFreeAddresses() uint64 {
+	if x != nil {
+		return x.FreeAddresses
+	}
+	return 0
+}
+
+func (x *GetAddressPoolStatsResponse) GetGapAddresses() uint64 {
+	if x != nil {
+		return x.GapAddresses
+	}
+	return 0
+}
+
 var File_user_proto protoreflect.FileDescriptor
 
 var file_user_proto_rawDesc = []byte{
@@ -251,7 +550,7 @@ var file_user_proto_rawDesc = []byte{
 	0x49, 0x64, 0x22, 0x2e, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73,
 	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
 	0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x22, 0x9e, 0x03, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79,
+	0x49, 0x64, 0x22, 0xd6, 0x05, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79,
 	0x70, 0x74, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
 	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
 	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x06, 0x78, 0x6d, 0x72, 0x52, 0x65, 0x71,
@@ -273,24 +572,95 @@ var file_user_proto_rawDesc = []byte{
 	0x0a, 0x06, 0x62, 0x6e, 0x62, 0x52, 0x65, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
 	0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6e, 0x62, 0x4b, 0x65,
 	0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
-	0x04, 0x52, 0x06, 0x62, 0x6e, 0x62, 0x52, 0x65, 0x71, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07,
-	0x5f, 0x78, 0x6d, 0x72, 0x52, 0x65, 0x71, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x62, 0x74, 0x63, 0x52,
-	0x65, 0x71, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6c, 0x74, 0x63, 0x52, 0x65, 0x71, 0x42, 0x09, 0x0a,
-	0x07, 0x5f, 0x65, 0x74, 0x68, 0x52, 0x65, 0x71, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x62, 0x6e, 0x62,
-	0x52, 0x65, 0x71, 0x22, 0x1a, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79,
-	0x70, 0x74, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32,
-	0xb3, 0x01, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x4b, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12,
-	0x1c, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x4b, 0x65, 0x79, 0x73,
-	0x12, 0x20, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x04, 0x52, 0x06, 0x62, 0x6e, 0x62, 0x52, 0x65, 0x71, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x06,
+	0x74, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x05, 0x52,
+	0x06, 0x74, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x06, 0x74, 0x72,
+	0x78, 0x52, 0x65, 0x71, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x06, 0x52, 0x06, 0x74,
+	0x72, 0x78, 0x52, 0x65, 0x71, 0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x07, 0x65, 0x76, 0x6d, 0x52,
+	0x65, 0x71, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x6d, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x65, 0x76, 0x6d, 0x52,
+	0x65, 0x71, 0x73, 0x12, 0x52, 0x0a, 0x12, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x75, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1d, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x75, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x48, 0x07,
+	0x52, 0x12, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x75, 0x73, 0x65, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x78, 0x6d, 0x72, 0x52,
+	0x65, 0x71, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x62, 0x74, 0x63, 0x52, 0x65, 0x71, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x6c, 0x74, 0x63, 0x52, 0x65, 0x71, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x65, 0x74, 0x68,
+	0x52, 0x65, 0x71, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x62, 0x6e, 0x62, 0x52, 0x65, 0x71, 0x42, 0x09,
+	0x0a, 0x07, 0x5f, 0x74, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x74, 0x72,
+	0x78, 0x52, 0x65, 0x71, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x75, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x1a, 0x0a, 0x18, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd7, 0x01, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x13, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x08, 0x63,
+	0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x08, 0x63, 0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x10,
+	0x6d, 0x69, 0x6e, 0x46, 0x72, 0x65, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x65, 0x65, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x61, 0x70, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x67, 0x61, 0x70, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64,
+	0x65, 0x22, 0x21, 0x0a, 0x1f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x63,
+	0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x63, 0x6f, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x43, 0x6f,
+	0x64, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x43, 0x6f,
+	0x64, 0x65, 0x22, 0xd7, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x65, 0x65, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x6d, 0x69,
+	0x6e, 0x46, 0x72, 0x65, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x67, 0x61, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x67, 0x61, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x72, 0x65, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x66, 0x72, 0x65, 0x65, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x67, 0x61, 0x70, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x67, 0x61, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x32, 0x83, 0x03, 0x0a,
+	0x0b, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0c,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x20, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x6c, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x27, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f,
+	0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x60, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f,
+	0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -305,33 +675,52 @@ func file_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_rawDescData
 }
 
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_user_proto_goTypes = []any{
-	(*RegisterUserRequest)(nil),      // 0: user.v1.RegisterUserRequest
-	(*RegisterUserResponse)(nil),     // 1: user.v1.RegisterUserResponse
-	(*UpdateCryptoKeysRequest)(nil),  // 2: user.v1.UpdateCryptoKeysRequest
-	(*UpdateCryptoKeysResponse)(nil), // 3: user.v1.UpdateCryptoKeysResponse
-	(*XmrKeysUpdateRequest)(nil),     // 4: crypto.v1.XmrKeysUpdateRequest
-	(*BtcKeysUpdateRequest)(nil),     // 5: crypto.v1.BtcKeysUpdateRequest
-	(*LtcKeysUpdateRequest)(nil),     // 6: crypto.v1.LtcKeysUpdateRequest
-	(*EthKeysUpdateRequest)(nil),     // 7: crypto.v1.EthKeysUpdateRequest
-	(*BnbKeysUpdateRequest)(nil),     // 8: crypto.v1.BnbKeysUpdateRequest
+	(*RegisterUserRequest)(nil),             // 0: user.v1.RegisterUserRequest
+	(*RegisterUserResponse)(nil),            // 1: user.v1.RegisterUserResponse
+	(*UpdateCryptoKeysRequest)(nil),         // 2: user.v1.UpdateCryptoKeysRequest
+	(*UpdateCryptoKeysResponse)(nil),        // 3: user.v1.UpdateCryptoKeysResponse
+	(*UpdateAddressPoolConfigRequest)(nil),  // 4: user.v1.UpdateAddressPoolConfigRequest
+	(*UpdateAddressPoolConfigResponse)(nil), // 5: user.v1.UpdateAddressPoolConfigResponse
+	(*GetAddressPoolStatsRequest)(nil),      // 6: user.v1.GetAddressPoolStatsRequest
+	(*GetAddressPoolStatsResponse)(nil),     // 7: user.v1.GetAddressPoolStatsResponse
+	(*XmrKeysUpdateRequest)(nil),            // 8: crypto.v1.XmrKeysUpdateRequest
+	(*BtcKeysUpdateRequest)(nil),            // 9: crypto.v1.BtcKeysUpdateRequest
+	(*LtcKeysUpdateRequest)(nil),            // 10: crypto.v1.LtcKeysUpdateRequest
+	(*EthKeysUpdateRequest)(nil),            // 11: crypto.v1.EthKeysUpdateRequest
+	(*BnbKeysUpdateRequest)(nil),            // 12: crypto.v1.BnbKeysUpdateRequest
+	(*TonKeysUpdateRequest)(nil),            // 13: crypto.v1.TonKeysUpdateRequest
+	(*TrxKeysUpdateRequest)(nil),            // 14: crypto.v1.TrxKeysUpdateRequest
+	(*EvmKeysUpdateRequest)(nil),            // 15: crypto.v1.EvmKeysUpdateRequest
+	(AddressReusePolicy)(0),                 // 16: crypto.v1.AddressReusePolicy
+	(CoinType)(0),                           // 17: crypto.v1.CoinType
 }
 var file_user_proto_depIdxs = []int32{
-	4, // 0: user.v1.UpdateCryptoKeysRequest.xmrReq:type_name -> crypto.v1.XmrKeysUpdateRequest
-	5, // 1: user.v1.UpdateCryptoKeysRequest.btcReq:type_name -> crypto.v1.BtcKeysUpdateRequest
-	6, // 2: user.v1.UpdateCryptoKeysRequest.ltcReq:type_name -> crypto.v1.LtcKeysUpdateRequest
-	7, // 3: user.v1.UpdateCryptoKeysRequest.ethReq:type_name -> crypto.v1.EthKeysUpdateRequest
-	8, // 4: user.v1.UpdateCryptoKeysRequest.bnbReq:type_name -> crypto.v1.BnbKeysUpdateRequest
-	0, // 5: user.v1.UserService.RegisterUser:input_type -> user.v1.RegisterUserRequest
-	2, // 6: user.v1.UserService.UpdateCryptoKeys:input_type -> user.v1.UpdateCryptoKeysRequest
-	1, // 7: user.v1.UserService.RegisterUser:output_type -> user.v1.RegisterUserResponse
-	3, // 8: user.v1.UserService.UpdateCryptoKeys:output_type -> user.v1.UpdateCryptoKeysResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	8,  // 0: user.v1.UpdateCryptoKeysRequest.xmrReq:type_name -> crypto.v1.XmrKeysUpdateRequest
+	9,  // 1: user.v1.UpdateCryptoKeysRequest.btcReq:type_name -> crypto.v1.BtcKeysUpdateRequest
+	10, // 2: user.v1.UpdateCryptoKeysRequest.ltcReq:type_name -> crypto.v1.LtcKeysUpdateRequest
+	11, // 3: user.v1.UpdateCryptoKeysRequest.ethReq:type_name -> crypto.v1.EthKeysUpdateRequest
+	12, // 4: user.v1.UpdateCryptoKeysRequest.bnbReq:type_name -> crypto.v1.BnbKeysUpdateRequest
+	13, // 5: user.v1.UpdateCryptoKeysRequest.tonReq:type_name -> crypto.v1.TonKeysUpdateRequest
+	14, // 6: user.v1.UpdateCryptoKeysRequest.trxReq:type_name -> crypto.v1.TrxKeysUpdateRequest
+	15, // 7: user.v1.UpdateCryptoKeysRequest.evmReqs:type_name -> crypto.v1.EvmKeysUpdateRequest
+	16, // 8: user.v1.UpdateCryptoKeysRequest.addressReusePolicy:type_name -> crypto.v1.AddressReusePolicy
+	17, // 9: user.v1.UpdateAddressPoolConfigRequest.coin:type_name -> crypto.v1.CoinType
+	17, // 10: user.v1.GetAddressPoolStatsRequest.coin:type_name -> crypto.v1.CoinType
+	0,  // 11: user.v1.UserService.RegisterUser:input_type -> user.v1.RegisterUserRequest
+	2,  // 12: user.v1.UserService.UpdateCryptoKeys:input_type -> user.v1.UpdateCryptoKeysRequest
+	4,  // 13: user.v1.UserService.UpdateAddressPoolConfig:input_type -> user.v1.UpdateAddressPoolConfigRequest
+	6,  // 14: user.v1.UserService.GetAddressPoolStats:input_type -> user.v1.GetAddressPoolStatsRequest
+	1,  // 15: user.v1.UserService.RegisterUser:output_type -> user.v1.RegisterUserResponse
+	3,  // 16: user.v1.UserService.UpdateCryptoKeys:output_type -> user.v1.UpdateCryptoKeysResponse
+	5,  // 17: user.v1.UserService.UpdateAddressPoolConfig:output_type -> user.v1.UpdateAddressPoolConfigResponse
+	7,  // 18: user.v1.UserService.GetAddressPoolStats:output_type -> user.v1.GetAddressPoolStatsResponse
+	15, // [15:19] is the sub-list for method output_type
+	11, // [11:15] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -389,16 +778,66 @@ func file_user_proto_init() {
 				return nil
 			}
 		}
+		file_user_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateAddressPoolConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateAddressPoolConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAddressPoolStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAddressPoolStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_user_proto_msgTypes[0].OneofWrappers = []any{}
 	file_user_proto_msgTypes[2].OneofWrappers = []any{}
+	file_user_proto_msgTypes[4].OneofWrappers = []any{}
+	file_user_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_user_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	UserService_RegisterUser_FullMethodName     = "/user.v1.UserService/RegisterUser"
-	UserService_UpdateCryptoKeys_FullMethodName = "/user.v1.UserService/UpdateCryptoKeys"
+	UserService_RegisterUser_FullMethodName            = "/user.v1.UserService/RegisterUser"
+	UserService_UpdateCryptoKeys_FullMethodName        = "/user.v1.UserService/UpdateCryptoKeys"
+	UserService_UpdateAddressPoolConfig_FullMethodName = "/user.v1.UserService/UpdateAddressPoolConfig"
+	UserService_GetAddressPoolStats_FullMethodName     = "/user.v1.UserService/GetAddressPoolStats"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -29,6 +31,8 @@ const (
 type UserServiceClient interface {
 	RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*RegisterUserResponse, error)
 	UpdateCryptoKeys(ctx context.Context, in *UpdateCryptoKeysRequest, opts ...grpc.CallOption) (*UpdateCryptoKeysResponse, error)
+	UpdateAddressPoolConfig(ctx context.Context, in *UpdateAddressPoolConfigRequest, opts ...grpc.CallOption) (*UpdateAddressPoolConfigResponse, error)
+	GetAddressPoolStats(ctx context.Context, in *GetAddressPoolStatsRequest, opts ...grpc.CallOption) (*GetAddressPoolStatsResponse, error)
 }
 
 type userServiceClient struct {
@@ -59,12 +63,34 @@ func (c *userServiceClient) UpdateCryptoKeys(ctx context.Context, in *UpdateCryp
 	return out, nil
 }
 
+func (c *userServiceClient) UpdateAddressPoolConfig(ctx context.Context, in *UpdateAddressPoolConfigRequest, opts ...grpc.CallOption) (*UpdateAddressPoolConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAddressPoolConfigResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateAddressPoolConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAddressPoolStats(ctx context.Context, in *GetAddressPoolStatsRequest, opts ...grpc.CallOption) (*GetAddressPoolStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAddressPoolStatsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetAddressPoolStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
 type UserServiceServer interface {
 	RegisterUser(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error)
 	UpdateCryptoKeys(context.Context, *UpdateCryptoKeysRequest) (*UpdateCryptoKeysResponse, error)
+	UpdateAddressPoolConfig(context.Context, *UpdateAddressPoolConfigRequest) (*UpdateAddressPoolConfigResponse, error)
+	GetAddressPoolStats(context.Context, *GetAddressPoolStatsRequest) (*GetAddressPoolStatsResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -78,6 +104,12 @@ func (UnimplementedUserServiceServer) RegisterUser(context.Context, *RegisterUse
 func (UnimplementedUserServiceServer) UpdateCryptoKeys(context.Context, *UpdateCryptoKeysRequest) (*UpdateCryptoKeysResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCryptoKeys not implemented")
 }
+func (UnimplementedUserServiceServer) UpdateAddressPoolConfig(context.Context, *UpdateAddressPoolConfigRequest) (*UpdateAddressPoolConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAddressPoolConfig not implemented")
+}
+func (UnimplementedUserServiceServer) GetAddressPoolStats(context.Context, *GetAddressPoolStatsRequest) (*GetAddressPoolStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressPoolStats not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -127,6 +159,42 @@ func _UserService_UpdateCryptoKeys_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_UpdateAddressPoolConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAddressPoolConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateAddressPoolConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateAddressPoolConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateAddressPoolConfig(ctx, req.(*UpdateAddressPoolConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAddressPoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressPoolStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetAddressPoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetAddressPoolStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetAddressPoolStats(ctx, req.(*GetAddressPoolStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -142,6 +210,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateCryptoKeys",
 			Handler:    _UserService_UpdateCryptoKeys_Handler,
 		},
+		{
+			MethodName: "UpdateAddressPoolConfig",
+			Handler:    _UserService_UpdateAddressPoolConfig_Handler,
+		},
+		{
+			MethodName: "GetAddressPoolStats",
+			Handler:    _UserService_GetAddressPoolStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",
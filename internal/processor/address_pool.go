@@ -0,0 +1,199 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	AddressGapLimitReachedErr     error = errors.New("address gap limit has been reached")
+	AddressPoolUnsupportedCoinErr error = errors.New("coin has no address pool")
+	// The derived address is a retired one an invoice pending on the previous key still occupies, so its index is skipped.
+	RetiredCryptoAddressOccupiedErr   error = errors.New("derived address is retired and still occupied")
+	RetiredCryptoAddressesOccupiedErr error = errors.New("too many derived addresses in a row are retired and still occupied")
+)
+
+// The invoices of the memo-based coins share one address, so there is no gap to keep to.
+func isMemoBasedCoin(coin db.CoinType) bool {
+	return coin == db.CoinTypeTON
+}
+
+// findAddressPoolConfig returns an empty config for the users who haven't configured the pool, i.e. no pre-derived addresses and no gap limit.
+func findAddressPoolConfig(ctx context.Context, q *db.Queries, userId pgtype.UUID, coin db.CoinType) (db.AddressPoolConfig, error) {
+	config, err := q.FindAddressPoolConfigByUserIdAndCoin(ctx, db.FindAddressPoolConfigByUserIdAndCoinParams{UserID: userId, Coin: coin})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.AddressPoolConfig{UserID: userId, Coin: coin}, nil
+	}
+
+	return config, err
+}
+
+func isGapLimitReached(config *db.AddressPoolConfig, stats *db.FindAddressPoolStatsByUserIdAndCoinRow) bool {
+	return config.GapLimit > 0 && stats.GapAddresses >= int64(config.GapLimit)
+}
+
+// deriveNextAddress derives a new occupied address unless it would exceed the gap limit of the user.
+func (b *baseCryptoProcessor[T, B]) deriveNextAddress(ctx context.Context, q *db.Queries, userId pgtype.UUID) (db.CryptoAddress, error) {
+	if !isMemoBasedCoin(b.coin) {
+		config, err := findAddressPoolConfig(ctx, q, userId, b.coin)
+		if err != nil {
+			return db.CryptoAddress{}, err
+		}
+		stats, err := q.FindAddressPoolStatsByUserIdAndCoin(ctx, db.FindAddressPoolStatsByUserIdAndCoinParams{UserID: userId, Coin: b.coin})
+		if err != nil {
+			return db.CryptoAddress{}, err
+		}
+		if isGapLimitReached(&config, &stats) {
+			return db.CryptoAddress{}, AddressGapLimitReachedErr
+		}
+	}
+
+	// Bounded, as the key switched back to may derive a long run of the retired addresses still occupied.
+	for i := 0; i < util.MAX_SKIPPED_RETIRED_ADDRESSES; i++ {
+		addr, err := b.generateNextAddressHandler(ctx, q, &generateNextAddressHandlerData{userId: userId, network: b.network})
+		if !errors.Is(err, RetiredCryptoAddressOccupiedErr) {
+			return addr, err
+		}
+	}
+
+	return db.CryptoAddress{}, RetiredCryptoAddressesOccupiedErr
+}
+
+// derivationPath is relative to the key of the user, e.g. 0/5 for the 5th address of the external chain or the XMR subaddress 0/5.
+func derivationPath(majorIndex int32, minorIndex int32) pgtype.Text {
+	return pgtype.Text{String: fmt.Sprintf("%d/%d", majorIndex, minorIndex), Valid: true}
+}
+
+// createCryptoAddressHelper stores the derived address of the current key version.
+// An address derived again after the user has switched back to one of the previous keys takes over its retired row,
+// unless an invoice pending on the previous key still occupies it. Then RetiredCryptoAddressOccupiedErr is returned,
+// on which the handlers still store the advanced indices, so the next derivation skips the address.
+func createCryptoAddressHelper(ctx context.Context, q *db.Queries, params db.CreateCryptoAddressParams) (db.CryptoAddress, error) {
+	addr, err := q.ReviveRetiredCryptoAddress(ctx, db.ReviveRetiredCryptoAddressParams{
+		IsOccupied:     params.IsOccupied,
+		DerivationPath: params.DerivationPath,
+		UserID:         params.UserID,
+		Coin:           params.Coin,
+		Address:        params.Address,
+		Memo:           params.Memo,
+	})
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return addr, err
+	}
+
+	retired, err := q.ExistsRetiredCryptoAddress(ctx, db.ExistsRetiredCryptoAddressParams{Address: params.Address, Memo: params.Memo})
+	if err != nil {
+		return db.CryptoAddress{}, err
+	}
+	if retired {
+		return db.CryptoAddress{}, RetiredCryptoAddressOccupiedErr
+	}
+
+	return q.CreateCryptoAddress(ctx, params)
+}
+
+// replenishAddressPool pre-derives the free addresses the pool of the user lacks, as far as the gap limit allows.
+func (b *baseCryptoProcessor[T, B]) replenishAddressPool(ctx context.Context, userId pgtype.UUID) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	// Serializes the replenishments of the pool. Without a config there is no pool to replenish.
+	config, err := q.FindAddressPoolConfigAndLockByUserIdAndCoin(ctx, db.FindAddressPoolConfigAndLockByUserIdAndCoinParams{UserID: userId, Coin: b.coin})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindAddressPoolConfigAndLockByUserIdAndCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		}
+		return
+	}
+	stats, err := q.FindAddressPoolStatsByUserIdAndCoin(ctx, db.FindAddressPoolStatsByUserIdAndCoinParams{UserID: userId, Coin: b.coin})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindAddressPoolStatsByUserIdAndCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+
+	for i := stats.FreeAddresses; i < int64(config.MinFreeAddresses); i++ {
+		addr, err := b.deriveNextAddress(ctx, q, userId)
+		if err != nil {
+			if !errors.Is(err, AddressGapLimitReachedErr) {
+				b.log.Err(err).Str("coin", string(b.coin)).Msg("An error occurred while replenishing the address pool.")
+			}
+			break
+		}
+
+		if _, err := q.UpdateIsOccupiedByCryptoAddress(ctx, db.UpdateIsOccupiedByCryptoAddressParams{IsOccupied: false, Address: addr.Address, Memo: addr.Memo}); err != nil {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateIsOccupiedByCryptoAddress").Msg(util.DefaultFailedSqlQueryMsg)
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+	}
+}
+
+// findAddressPoolProcessor returns the processor deriving the addresses of the coin, e.g. of ETH but not of USDT_ERC20.
+// The pool of a chain serves its token invoices as well.
+func (p *PaymentProcessor) findAddressPoolProcessor(coin db.CoinType) (cryptoProcessor, error) {
+	cp, ok := p.cryptoProcessors[coin]
+	if !ok || isMemoBasedCoin(coin) {
+		return nil, AddressPoolUnsupportedCoinErr
+	}
+
+	return cp, nil
+}
+
+// UpdateAddressPoolConfig stores the pool configuration of the user and pre-derives the missing free addresses in the background.
+func (p *PaymentProcessor) UpdateAddressPoolConfig(userId pgtype.UUID, coin db.CoinType, minFreeAddresses int32, gapLimit int32) error {
+	cp, err := p.findAddressPoolProcessor(coin)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.New(p.dbConnPool).UpsertAddressPoolConfig(p.ctx, db.UpsertAddressPoolConfigParams{UserID: userId, Coin: coin, MinFreeAddresses: minFreeAddresses, GapLimit: gapLimit}); err != nil {
+		p.log.Err(err).Str("queryName", "UpsertAddressPoolConfig").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	go cp.replenishAddressPool(p.ctx, userId)
+
+	return nil
+}
+
+// GetAddressPoolStats counts the addresses of the chain, which the invoices of both the chain coin and its tokens take.
+func (p *PaymentProcessor) GetAddressPoolStats(userId pgtype.UUID, coin db.CoinType) (*dto.AddressPoolStats, error) {
+	if _, err := p.findAddressPoolProcessor(coin); err != nil {
+		return nil, err
+	}
+
+	q := db.New(p.dbConnPool)
+
+	config, err := findAddressPoolConfig(p.ctx, q, userId, coin)
+	if err != nil {
+		p.log.Err(err).Str("queryName", "FindAddressPoolConfigByUserIdAndCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	stats, err := q.FindAddressPoolStatsByUserIdAndCoin(p.ctx, db.FindAddressPoolStatsByUserIdAndCoinParams{UserID: userId, Coin: coin})
+	if err != nil {
+		p.log.Err(err).Str("queryName", "FindAddressPoolStatsByUserIdAndCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	return &dto.AddressPoolStats{
+		MinFreeAddresses: config.MinFreeAddresses,
+		GapLimit:         config.GapLimit,
+		TotalAddresses:   stats.TotalAddresses,
+		FreeAddresses:    stats.FreeAddresses,
+		GapAddresses:     stats.GapAddresses,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsGapLimitReached(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		gapLimit     int32
+		gapAddresses int64
+		expected     bool
+	}{
+		{name: "Should Allow Derivation Below Limit", gapLimit: 20, gapAddresses: 19, expected: false},
+		{name: "Should Forbid Derivation At Limit", gapLimit: 20, gapAddresses: 20, expected: true},
+		{name: "Should Allow Any Derivation With Limit Off", gapLimit: 0, gapAddresses: 1000, expected: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := db.AddressPoolConfig{GapLimit: c.gapLimit}
+			stats := db.FindAddressPoolStatsByUserIdAndCoinRow{GapAddresses: c.gapAddresses}
+			assert.Equal(t, c.expected, isGapLimitReached(&config, &stats))
+		})
+	}
+}
+
+func TestFindAddressPoolProcessor(t *testing.T) {
+	t.Parallel()
+
+	btc := newKeysTestPaymentProcessor(db.CoinTypeBTC, listener.MainnetBTC)
+	ton := newKeysTestPaymentProcessor(db.CoinTypeTON, listener.MainnetTON)
+
+	t.Run("Should Find Processor Deriving Addresses", func(t *testing.T) {
+		_, err := btc.findAddressPoolProcessor(db.CoinTypeBTC)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should Return Error (unsupported coin)", func(t *testing.T) {
+		_, err := btc.findAddressPoolProcessor(db.CoinTypeLTC)
+		assert.ErrorIs(t, err, AddressPoolUnsupportedCoinErr)
+
+		_, err = ton.findAddressPoolProcessor(db.CoinTypeTON)
+		assert.ErrorIs(t, err, AddressPoolUnsupportedCoinErr)
+	})
+}
+
+func TestDeriveNextAddress(t *testing.T) {
+	t.Parallel()
+
+	// The memo-based coins derive with no gap limit lookup, so no database is needed.
+	newProcessor := func(occupied int, calls *int) *baseCryptoProcessor[listener.TONTx, listener.TONBlock] {
+		return &baseCryptoProcessor[listener.TONTx, listener.TONBlock]{
+			coin: db.CoinTypeTON,
+			generateNextAddressHandler: func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				*calls++
+				if *calls <= occupied {
+					return db.CryptoAddress{}, RetiredCryptoAddressOccupiedErr
+				}
+				return db.CryptoAddress{Address: "addr"}, nil
+			},
+		}
+	}
+
+	t.Run("Should Skip Retired Addresses Still Occupied", func(t *testing.T) {
+		calls := 0
+		addr, err := newProcessor(2, &calls).deriveNextAddress(context.Background(), nil, pgtype.UUID{})
+		assert.NoError(t, err)
+		assert.Equal(t, "addr", addr.Address)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Should Return RetiredCryptoAddressesOccupiedErr", func(t *testing.T) {
+		calls := 0
+		_, err := newProcessor(util.MAX_SKIPPED_RETIRED_ADDRESSES, &calls).deriveNextAddress(context.Background(), nil, pgtype.UUID{})
+		assert.ErrorIs(t, err, RetiredCryptoAddressesOccupiedErr)
+		assert.Equal(t, util.MAX_SKIPPED_RETIRED_ADDRESSES, calls)
+	})
+}
@@ -2,7 +2,9 @@ package processor
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"math/big"
 	"sync/atomic"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/chekist32/goipay/internal/dto"
 	"github.com/chekist32/goipay/internal/listener"
 	"github.com/chekist32/goipay/internal/util"
+	"github.com/chekist32/goipay/internal/webhook"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -18,6 +21,8 @@ import (
 
 var (
 	unsupportedCoin error = errors.New("coin is unsupported by crypto processor")
+
+	InvoiceNotCancellableErr error = errors.New("only pending invoices can be cancelled")
 )
 
 type pendingInvoice struct {
@@ -39,7 +44,13 @@ type cryptoProcessor interface {
 	load(ctx context.Context) error
 	handleInvoicePbReq(ctx context.Context, req *dto.NewInvoiceRequest) (*db.Invoice, error)
 	handleInvoice(ctx context.Context, invoice db.Invoice)
+	handleExpiredInvoice(ctx context.Context, invoice db.Invoice)
+	cancelInvoice(ctx context.Context, invoice *db.Invoice) (*db.Invoice, error)
+	cancelGroupSiblingInvoice(ctx context.Context, invoice *db.Invoice) (*db.Invoice, error)
+	paymentUri(invoice *db.Invoice, label string) (string, error)
 	supportsCoin(coin db.CoinType) bool
+	networkType() listener.NetworkType
+	replenishAddressPool(ctx context.Context, userId pgtype.UUID)
 }
 
 type baseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock] struct {
@@ -56,11 +67,24 @@ type baseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock] struct {
 
 	invoiceCn       chan<- db.Invoice
 	pendingInvoices *util.SyncMapTypeSafe[string, pendingInvoice]
+	expiredInvoices *util.SyncMapTypeSafe[string, pendingInvoice]
+
+	latePaymentGraceWindow time.Duration
 
-	verifyTxHandler            func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (float64, error)
+	verifyTxHandler            func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (*big.Int, error)
 	generateNextAddressHandler func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error)
 }
 
+// invoiceSlotKey identifies the address occupied by the invoice.
+// The memo tells apart the invoices sharing one address, e.g. the TON ones.
+func invoiceSlotKey(invoice *db.Invoice) string {
+	if invoice.Memo.Valid {
+		return invoice.CryptoAddress + "#" + invoice.Memo.String
+	}
+
+	return invoice.CryptoAddress
+}
+
 func (b *baseCryptoProcessor[T, B]) verifyTxOnMempool(ctx context.Context, cryptoTx T) {
 	if cryptoTx.IsDoubleSpendSeen() {
 		return
@@ -76,6 +100,9 @@ func (b *baseCryptoProcessor[T, B]) verifyTxOnMempool(ctx context.Context, crypt
 			defer tx.Rollback(ctx)
 
 			invoice := value.invoice.Load()
+			if invoice.Status != db.InvoiceStatusTypePENDING && invoice.Status != db.InvoiceStatusTypePARTIALLYPAID {
+				return
+			}
 
 			amount, err := b.verifyTxHandler(ctx, q, &verifyTxHandlerData[T]{invoice: *invoice, tx: cryptoTx})
 			if err != nil {
@@ -83,89 +110,590 @@ func (b *baseCryptoProcessor[T, B]) verifyTxOnMempool(ctx context.Context, crypt
 				return
 			}
 
-			if invoice.RequiredAmount <= amount && invoice.Status == db.InvoiceStatusTypePENDING {
-				b.confirmPENDING_MEMPOOL(ctx, q, cryptoTx, amount, value)
-				b.confirmCONFIRMED(ctx, q, value)
+			var paidInvoice *db.Invoice
+			if amount.Sign() > 0 {
+				paidInvoice, err = b.registerPayment(ctx, q, cryptoTx, amount, value)
+				if err != nil {
+					return
+				}
+			}
+
+			if err := tx.Commit(ctx); err != nil {
+				b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+				return
 			}
 
-			tx.Commit(ctx)
+			if paidInvoice != nil {
+				value.invoice.Store(paidInvoice)
+				b.broadcastUpdatedInvoice(ctx, paidInvoice)
+			}
+			if amount.Sign() > 0 {
+				b.confirmCONFIRMED(ctx, value)
+			}
 		}()
 
 		return true
 	})
+
+	b.expiredInvoices.Range(func(key string, value pendingInvoice) bool {
+		go func() {
+			q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+			if err != nil {
+				b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+				return
+			}
+			defer tx.Rollback(ctx)
+
+			amount, err := b.verifyTxHandler(ctx, q, &verifyTxHandlerData[T]{invoice: *value.invoice.Load(), tx: cryptoTx})
+			if err != nil {
+				b.log.Err(err).Str("coin", string(b.coin)).Msg("An error occurred while verifying the tx output.")
+				return
+			}
+
+			var paidInvoice *db.Invoice
+			if amount.Sign() > 0 {
+				paidInvoice, err = b.registerLatePayment(ctx, q, cryptoTx, amount, value)
+				if err != nil {
+					return
+				}
+			}
+
+			if err := tx.Commit(ctx); err != nil {
+				b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+				return
+			}
+
+			if paidInvoice != nil {
+				b.log.Info().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(paidInvoice.ID)).Msgf("Tx %v paid the invoice after its expiry", cryptoTx.GetTxId())
+
+				value.invoice.Store(paidInvoice)
+				b.broadcastUpdatedInvoice(ctx, paidInvoice)
+			}
+		}()
+
+		return true
+	})
+}
+
+func isInvoicePaid(invoice *db.Invoice, amount *big.Int) bool {
+	minAmount := new(big.Int).Sub(util.PgNumericToBigInt(invoice.RequiredAmount), util.PgNumericToBigInt(invoice.UnderpaymentTolerance))
+	return minAmount.Cmp(amount) <= 0
+}
+
+// paidInvoiceStatus is the status of a not yet confirmed invoice whose payments sum up to the amount.
+func paidInvoiceStatus(invoice *db.Invoice, amount *big.Int) db.InvoiceStatusType {
+	if isInvoicePaid(invoice, amount) {
+		return db.InvoiceStatusTypePENDINGMEMPOOL
+	}
+	if amount.Sign() > 0 {
+		return db.InvoiceStatusTypePARTIALLYPAID
+	}
+
+	return db.InvoiceStatusTypePENDING
+}
+
+// registerPayment returns the invoice updated by the payment, or nil if the payment has been counted already.
+// Storing and broadcasting it is up to the caller, once the payment is committed.
+func (b *baseCryptoProcessor[T, B]) registerPayment(ctx context.Context, q *db.Queries, cryptoTx T, am *big.Int, value pendingInvoice) (*db.Invoice, error) {
+	// Locking the invoice serializes concurrent payments, so the sum below always sees the previous ones.
+	invoice, err := q.FindInvoiceAndLockById(ctx, value.invoice.Load().ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoiceAndLockById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	if invoice.Status != db.InvoiceStatusTypePENDING && invoice.Status != db.InvoiceStatusTypePARTIALLYPAID {
+		return nil, nil
+	}
+
+	if _, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: invoice.ID, TxID: cryptoTx.GetTxId(), Amount: util.BigIntToPgNumeric(am)}); err != nil {
+		// The tx has already been counted (e.g. it was seen in the mempool and then in a block).
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CreateInvoicePayment").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	sum, err := q.SumInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "SumInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	total := util.PgNumericToBigInt(sum)
+
+	if !isInvoicePaid(&invoice, total) {
+		return b.confirmPARTIALLY_PAID(ctx, q, cryptoTx, total, value)
+	}
+
+	// Confirmed by the caller once the payment is committed.
+	return b.confirmPENDING_MEMPOOL(ctx, q, cryptoTx, total, value)
 }
 
-func (b *baseCryptoProcessor[T, B]) confirmPENDING_MEMPOOL(ctx context.Context, q *db.Queries, cryptoTx T, am float64, value pendingInvoice) {
+// registerLatePayment returns the invoice paid after its expiry, or nil if the payment has been counted already.
+// Storing and broadcasting it is up to the caller, once the payment is committed.
+func (b *baseCryptoProcessor[T, B]) registerLatePayment(ctx context.Context, q *db.Queries, cryptoTx T, am *big.Int, value pendingInvoice) (*db.Invoice, error) {
+	invoice, err := q.FindInvoiceAndLockById(ctx, value.invoice.Load().ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoiceAndLockById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	if _, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: invoice.ID, TxID: cryptoTx.GetTxId(), Amount: util.BigIntToPgNumeric(am)}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CreateInvoicePayment").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	sum, err := q.SumInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "SumInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	total := util.PgNumericToBigInt(sum)
+
 	var txId pgtype.Text
 	if err := txId.Scan(cryptoTx.GetTxId()); err != nil {
 		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "txId").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
-		return
+		return nil, err
 	}
 
-	var amount pgtype.Float8
-	if err := amount.Scan(am); err != nil {
-		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "amount").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
-		return
+	var confirmations pgtype.Int8
+	if err := confirmations.Scan(int64(cryptoTx.GetConfirmations())); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "confirmations").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
+		return nil, err
+	}
+
+	paidInvoice, err := q.PayInvoiceAfterExpiryById(ctx, db.PayInvoiceAfterExpiryByIdParams{ID: invoice.ID, ActualAmount: util.BigIntToPgNumeric(total), TxID: txId})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "PayInvoiceAfterExpiryById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	if err := b.createInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{InvoiceStatusType: invoice.Status, Valid: true}, &paidInvoice, confirmations); err != nil {
+		return nil, err
+	}
+
+	return &paidInvoice, nil
+}
+
+func (b *baseCryptoProcessor[T, B]) confirmPARTIALLY_PAID(ctx context.Context, q *db.Queries, cryptoTx T, am *big.Int, value pendingInvoice) (*db.Invoice, error) {
+	var txId pgtype.Text
+	if err := txId.Scan(cryptoTx.GetTxId()); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "txId").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
+		return nil, err
+	}
+
+	var confirmations pgtype.Int8
+	if err := confirmations.Scan(int64(cryptoTx.GetConfirmations())); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "confirmations").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
+		return nil, err
+	}
+
+	oldStatus := value.invoice.Load().Status
+
+	invoice, err := q.PartiallyPayInvoiceById(ctx, db.PartiallyPayInvoiceByIdParams{ID: value.invoice.Load().ID, ActualAmount: util.BigIntToPgNumeric(am), TxID: txId})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "PartiallyPayInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
 	}
+	if err := b.createInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{InvoiceStatusType: oldStatus, Valid: true}, &invoice, confirmations); err != nil {
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+func (b *baseCryptoProcessor[T, B]) confirmPENDING_MEMPOOL(ctx context.Context, q *db.Queries, cryptoTx T, am *big.Int, value pendingInvoice) (*db.Invoice, error) {
+	var txId pgtype.Text
+	if err := txId.Scan(cryptoTx.GetTxId()); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "txId").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
+		return nil, err
+	}
+
+	var confirmations pgtype.Int8
+	if err := confirmations.Scan(int64(cryptoTx.GetConfirmations())); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "confirmations").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
+		return nil, err
+	}
+
+	oldStatus := value.invoice.Load().Status
 
-	invoice, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: value.invoice.Load().ID, ActualAmount: amount, TxID: txId})
+	invoice, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: value.invoice.Load().ID, ActualAmount: util.BigIntToPgNumeric(am), TxID: txId})
 	if err != nil {
 		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ConfirmInvoiceStatusMempoolById").Msg(util.DefaultFailedSqlQueryMsg)
-		return
+		return nil, err
+	}
+	if err := b.createInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{InvoiceStatusType: oldStatus, Valid: true}, &invoice, confirmations); err != nil {
+		return nil, err
 	}
 
-	value.invoice.Store(&invoice)
-	b.broadcastUpdatedInvoice(ctx, &invoice)
+	return &invoice, nil
 }
 
-func (b *baseCryptoProcessor[T, B]) confirmCONFIRMED(ctx context.Context, q *db.Queries, value pendingInvoice) {
-	invoice := value.invoice.Load()
-	if !invoice.TxID.Valid {
+// confirmCONFIRMED confirms the payments having the required confirmations and, once they cover the invoice, the invoice itself.
+func (b *baseCryptoProcessor[T, B]) confirmCONFIRMED(ctx context.Context, value pendingInvoice) {
+	if value.invoice.Load().Status != db.InvoiceStatusTypePENDINGMEMPOOL {
 		return
 	}
 
-	txs, err := b.daemon.GetTransactions([]string{invoice.TxID.String})
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
 	if err != nil {
-		b.log.Err(err).Str("coin", string(b.coin)).Str("method", "get_transactions").Msg(util.DefaultFailedFetchingDaemonMsg)
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
 		return
 	}
-	if len(txs) < 1 || txs[0].IsDoubleSpendSeen() {
-		b.log.Info().Str("coin", string(b.coin)).Msgf("Tx %v was rejected by blockchain", invoice.TxID.String)
-		b.expireInvoice(ctx, invoice)
+	defer tx.Rollback(ctx)
+
+	// Locking the invoice serializes the confirmations triggered by the concurrent blocks and payments.
+	lockedInvoice, err := q.FindInvoiceAndLockById(ctx, value.invoice.Load().ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoiceAndLockById").Msg(util.DefaultFailedSqlQueryMsg)
 		return
 	}
+	if lockedInvoice.Status != db.InvoiceStatusTypePENDINGMEMPOOL {
+		return
+	}
+	invoice := &lockedInvoice
 
-	if uint64(invoice.ConfirmationsRequired) > txs[0].GetConfirmations() {
+	payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
 		return
 	}
-	if _, loaded := b.pendingInvoices.LoadAndDelete(invoice.CryptoAddress); !loaded {
+
+	txIds := make([]string, 0, len(payments))
+	for i := 0; i < len(payments); i++ {
+		if !payments[i].ConfirmedAt.Valid {
+			txIds = append(txIds, payments[i].TxID)
+		}
+	}
+
+	txs := make(map[string]T, len(txIds))
+	if len(txIds) > 0 {
+		fetchedTxs, err := b.daemon.GetTransactions(txIds)
+		if err != nil {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("method", "get_transactions").Msg(util.DefaultFailedFetchingDaemonMsg)
+			return
+		}
+		for i := 0; i < len(fetchedTxs); i++ {
+			txs[fetchedTxs[i].GetTxId()] = fetchedTxs[i]
+		}
+	}
+
+	var confirmations pgtype.Int8
+	var lastTxId pgtype.Text
+	rejected := false
+	confirmedAmount := new(big.Int)
+	for i := 0; i < len(payments); i++ {
+		payment := &payments[i]
+		if payment.ConfirmedAt.Valid {
+			confirmedAmount.Add(confirmedAmount, util.PgNumericToBigInt(payment.Amount))
+			lastTxId = pgtype.Text{String: payment.TxID, Valid: true}
+			continue
+		}
+
+		cryptoTx, ok := txs[payment.TxID]
+		if !ok || cryptoTx.IsDoubleSpendSeen() {
+			b.log.Info().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msgf("Tx %v was rejected by blockchain, dropping its payment", payment.TxID)
+			if err := q.DeleteInvoicePaymentById(ctx, payment.ID); err != nil {
+				b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "DeleteInvoicePaymentById").Msg(util.DefaultFailedSqlQueryMsg)
+				return
+			}
+			rejected = true
+			continue
+		}
+		lastTxId = pgtype.Text{String: payment.TxID, Valid: true}
+
+		if !confirmations.Valid || uint64(confirmations.Int64) > cryptoTx.GetConfirmations() {
+			if err := confirmations.Scan(int64(cryptoTx.GetConfirmations())); err != nil {
+				b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "confirmations").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
+				return
+			}
+		}
+
+		if uint64(invoice.ConfirmationsRequired) > cryptoTx.GetConfirmations() {
+			continue
+		}
+		if _, err := q.ConfirmInvoicePaymentById(ctx, payment.ID); err != nil {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ConfirmInvoicePaymentById").Msg(util.DefaultFailedSqlQueryMsg)
+			return
+		}
+		confirmedAmount.Add(confirmedAmount, util.PgNumericToBigInt(payment.Amount))
+	}
+
+	// The invoice keeps only the payments left, which might no longer cover it.
+	changed := false
+	if rejected {
+		rejectedInvoice, err := b.dropRejectedPaymentsHelper(ctx, q, invoice, lastTxId)
+		if err != nil {
+			return
+		}
+		invoice = rejectedInvoice
+		changed = true
+	}
+
+	paid := invoice.Status == db.InvoiceStatusTypePENDINGMEMPOOL && isInvoicePaid(invoice, confirmedAmount)
+	if invoice.Status == db.InvoiceStatusTypePENDINGMEMPOOL && confirmations.Valid && confirmations.Int64 > int64(invoice.Confirmations) {
+		updatedInvoice, err := q.UpdateInvoiceConfirmationsById(ctx, db.UpdateInvoiceConfirmationsByIdParams{ID: invoice.ID, Confirmations: int32(confirmations.Int64)})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateInvoiceConfirmationsById").Msg(util.DefaultFailedSqlQueryMsg)
+			return
+		}
+		if err == nil {
+			// The confirmation below records the count on its own.
+			if !paid {
+				if err := b.createInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{InvoiceStatusType: invoice.Status, Valid: true}, &updatedInvoice, confirmations); err != nil {
+					return
+				}
+			}
+			invoice = &updatedInvoice
+			changed = true
+		}
+	}
+
+	if !paid {
+		if err := tx.Commit(ctx); err != nil {
+			b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+			return
+		}
+		if changed {
+			value.invoice.Store(invoice)
+			b.broadcastUpdatedInvoice(ctx, invoice)
+		}
 		return
 	}
-	value.cancelTimeoutFunc()
 
 	confirmedInvoice, err := q.ConfirmInvoiceById(ctx, invoice.ID)
 	if err != nil {
 		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ConfirmInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
 		return
 	}
+	if err := b.createInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{InvoiceStatusType: invoice.Status, Valid: true}, &confirmedInvoice, confirmations); err != nil {
+		return
+	}
 
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return
+	}
+
+	if value, ok := b.untrackPendingInvoice(invoice); ok {
+		value.cancelTimeoutFunc()
+	}
 	go b.releaseAddressHelper(ctx, invoice)
 	b.broadcastUpdatedInvoice(ctx, &confirmedInvoice)
 }
 
+// dropRejectedPaymentsHelper settles the invoice on the sum of its remaining payments, once the rejected ones have been deleted.
+func (b *baseCryptoProcessor[T, B]) dropRejectedPaymentsHelper(ctx context.Context, q *db.Queries, invoice *db.Invoice, lastTxId pgtype.Text) (*db.Invoice, error) {
+	sum, err := q.SumInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "SumInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	total := util.PgNumericToBigInt(sum)
+
+	// The expiry is left as it is.
+	rejectedInvoice, err := q.RollbackInvoiceById(ctx, db.RollbackInvoiceByIdParams{
+		ID:           invoice.ID,
+		Status:       paidInvoiceStatus(invoice, total),
+		ActualAmount: pgtype.Numeric{Int: total, Valid: total.Sign() > 0},
+		TxID:         lastTxId,
+		MinExpiresAt: invoice.ExpiresAt,
+	})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "RollbackInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	if err := b.createInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{InvoiceStatusType: invoice.Status, Valid: true}, &rejectedInvoice, pgtype.Int8{}); err != nil {
+		return nil, err
+	}
+
+	return &rejectedInvoice, nil
+}
+
 func (b *baseCryptoProcessor[T, B]) verifyTxOnNewBlock(ctx context.Context) {
 	b.pendingInvoices.Range(func(key string, value pendingInvoice) bool {
-		go func() {
-			q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
-			if err != nil {
-				b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		go b.confirmCONFIRMED(ctx, value)
+		return true
+	})
+}
+
+func (b *baseCryptoProcessor[T, B]) handleChainReorg(ctx context.Context, reorg listener.ChainReorg) {
+	b.log.Warn().Str("coin", string(b.coin)).Msgf("Chain reorganization above blockheight %v, rechecking the paid invoices", reorg.ForkHeight)
+
+	coins := []string{string(b.coin)}
+	for token := range b.supportedTokens {
+		coins = append(coins, string(token))
+	}
+
+	var confirmedAtFrom pgtype.Timestamptz
+	if err := confirmedAtFrom.Scan(time.Now().UTC().Add(-util.REORG_RECHECK_WINDOW)); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "confirmedAtFrom").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
+		return
+	}
+
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	invoices, err := q.FindReorgCandidateInvoicesByCoins(ctx, db.FindReorgCandidateInvoicesByCoinsParams{Coins: coins, ConfirmedAtFrom: confirmedAtFrom})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindReorgCandidateInvoicesByCoins").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return
+	}
+
+	for i := 0; i < len(invoices); i++ {
+		b.rollbackReorgedInvoice(ctx, &invoices[i])
+	}
+}
+
+// rollbackReorgedInvoice drops the payments whose tx is gone from the chain and unconfirms the ones lacking confirmations now,
+// moving the invoice back to the status matching the rest of the payments.
+func (b *baseCryptoProcessor[T, B]) rollbackReorgedInvoice(ctx context.Context, invoice *db.Invoice) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	lockedInvoice, err := q.FindInvoiceAndLockById(ctx, invoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoiceAndLockById").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+	if lockedInvoice.Status != db.InvoiceStatusTypePENDINGMEMPOOL && lockedInvoice.Status != db.InvoiceStatusTypeCONFIRMED {
+		return
+	}
+
+	payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, lockedInvoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+	if len(payments) == 0 {
+		return
+	}
+
+	txIds := make([]string, 0, len(payments))
+	for i := 0; i < len(payments); i++ {
+		txIds = append(txIds, payments[i].TxID)
+	}
+	fetchedTxs, err := b.daemon.GetTransactions(txIds)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("method", "get_transactions").Msg(util.DefaultFailedFetchingDaemonMsg)
+		return
+	}
+	txs := make(map[string]T, len(fetchedTxs))
+	for i := 0; i < len(fetchedTxs); i++ {
+		txs[fetchedTxs[i].GetTxId()] = fetchedTxs[i]
+	}
+
+	rolledBack := false
+	var txId pgtype.Text
+	for i := 0; i < len(payments); i++ {
+		payment := &payments[i]
+
+		cryptoTx, ok := txs[payment.TxID]
+		if !ok || cryptoTx.IsDoubleSpendSeen() {
+			b.log.Info().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(lockedInvoice.ID)).Msgf("Tx %v disappeared after the chain reorganization", payment.TxID)
+			if err := q.DeleteInvoicePaymentById(ctx, payment.ID); err != nil {
+				b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "DeleteInvoicePaymentById").Msg(util.DefaultFailedSqlQueryMsg)
 				return
 			}
-			b.confirmCONFIRMED(ctx, q, value)
-			tx.Commit(ctx)
-		}()
-		return true
+			rolledBack = true
+			continue
+		}
+
+		txId = pgtype.Text{String: payment.TxID, Valid: true}
+		if payment.ConfirmedAt.Valid && uint64(lockedInvoice.ConfirmationsRequired) > cryptoTx.GetConfirmations() {
+			if _, err := q.UnconfirmInvoicePaymentById(ctx, payment.ID); err != nil {
+				b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UnconfirmInvoicePaymentById").Msg(util.DefaultFailedSqlQueryMsg)
+				return
+			}
+			rolledBack = true
+		}
+	}
+	if !rolledBack {
+		return
+	}
+
+	sum, err := q.SumInvoicePaymentsByInvoiceId(ctx, lockedInvoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "SumInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+	total := util.PgNumericToBigInt(sum)
+
+	status := paidInvoiceStatus(&lockedInvoice, total)
+
+	var minExpiresAt pgtype.Timestamptz
+	if err := minExpiresAt.Scan(time.Now().UTC().Add(util.REORG_ROLLBACK_MIN_TIMEOUT)); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "minExpiresAt").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
+		return
+	}
+
+	// The released address of a confirmed invoice might have been handed to another invoice meanwhile.
+	// Both can't be watched on the same address, so the rolled back one gets expired instead.
+	slotTaken := false
+	if value, ok := b.pendingInvoices.Load(invoiceSlotKey(&lockedInvoice)); ok && value.invoice.Load().ID != lockedInvoice.ID {
+		slotTaken = true
+		status = db.InvoiceStatusTypeEXPIRED
+		minExpiresAt = lockedInvoice.ExpiresAt
+	}
+
+	rolledBackInvoice, err := q.RollbackInvoiceById(ctx, db.RollbackInvoiceByIdParams{
+		ID:           lockedInvoice.ID,
+		Status:       status,
+		ActualAmount: pgtype.Numeric{Int: total, Valid: total.Sign() > 0},
+		TxID:         txId,
+		MinExpiresAt: minExpiresAt,
 	})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "RollbackInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+	if err := b.createReorgInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{InvoiceStatusType: lockedInvoice.Status, Valid: true}, &rolledBackInvoice); err != nil {
+		return
+	}
+	// The address of a confirmed invoice has been released already.
+	if lockedInvoice.Status == db.InvoiceStatusTypeCONFIRMED && !slotTaken {
+		if _, err := q.UpdateIsOccupiedByCryptoAddress(ctx, db.UpdateIsOccupiedByCryptoAddressParams{IsOccupied: true, Address: rolledBackInvoice.CryptoAddress, Memo: rolledBackInvoice.Memo}); err != nil {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateIsOccupiedByCryptoAddress").Msg(util.DefaultFailedSqlQueryMsg)
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return
+	}
+
+	b.log.Warn().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(rolledBackInvoice.ID)).Msgf("Invoice rolled back from %v to %v after the chain reorganization", lockedInvoice.Status, rolledBackInvoice.Status)
+
+	if slotTaken {
+		b.log.Warn().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(rolledBackInvoice.ID)).Msg(util.ReorgedInvoiceSlotTakenMsg)
+		b.broadcastUpdatedInvoice(ctx, &rolledBackInvoice)
+		return
+	}
+
+	// Re-tracking the invoice, so the new expiry is picked up as well.
+	if value, ok := b.untrackPendingInvoice(&rolledBackInvoice); ok {
+		value.cancelTimeoutFunc()
+	}
+	b.handleInvoice(ctx, rolledBackInvoice)
+	b.broadcastUpdatedInvoice(ctx, &rolledBackInvoice)
 }
 
 func (b *baseCryptoProcessor[T, B]) persistCryptoCache(ctx context.Context) {
@@ -187,7 +715,24 @@ func (b *baseCryptoProcessor[T, B]) persistCryptoCache(ctx context.Context) {
 		return
 	}
 
-	tx.Commit(ctx)
+	blockHashes := b.daemonEx.RecentBlockHashes()
+	params := db.CreateCryptoBlockHashesParams{Coin: b.coin, Heights: make([]int64, 0, len(blockHashes)), Hashes: make([]string, 0, len(blockHashes))}
+	for i := 0; i < len(blockHashes); i++ {
+		params.Heights = append(params.Heights, int64(blockHashes[i].Height))
+		params.Hashes = append(params.Hashes, blockHashes[i].Hash)
+	}
+	if err := q.DeleteCryptoBlockHashesByCoin(ctx, b.coin); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "DeleteCryptoBlockHashesByCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+	if err := q.CreateCryptoBlockHashes(ctx, params); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CreateCryptoBlockHashes").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+	}
 }
 
 func (b *baseCryptoProcessor[T, B]) load(ctx context.Context) error {
@@ -215,7 +760,21 @@ func (b *baseCryptoProcessor[T, B]) load(ctx context.Context) error {
 		height = cache.LastSyncedBlockHeight.Int64
 	}
 
-	tx.Commit(ctx)
+	persistedBlockHashes, err := q.FindCryptoBlockHashesByCoin(ctx, b.coin)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindCryptoBlockHashesByCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+	blockHashes := make([]listener.BlockHash, 0, len(persistedBlockHashes))
+	for i := 0; i < len(persistedBlockHashes); i++ {
+		blockHashes = append(blockHashes, listener.BlockHash{Height: uint64(persistedBlockHashes[i].Height), Hash: persistedBlockHashes[i].Hash})
+	}
+	b.daemonEx.LoadBlockHashes(blockHashes)
+
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return err
+	}
 
 	go func() {
 		blockCn := b.daemonEx.NewBlockChan()
@@ -244,6 +803,19 @@ func (b *baseCryptoProcessor[T, B]) load(ctx context.Context) error {
 		}
 	}()
 
+	go func() {
+		reorgCn := b.daemonEx.NewChainReorgChan()
+
+		for {
+			select {
+			case reorg := <-reorgCn:
+				go b.handleChainReorg(ctx, reorg)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	go func() {
 		txPoolCn := b.daemonEx.NewTxPoolChan()
 
@@ -300,13 +872,30 @@ func (b *baseCryptoProcessor[T, B]) createInvoice(ctx context.Context, req *dto.
 		return nil, err
 	}
 
-	addr, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: userId, Coin: coin})
+	// Left NULL for an empty map, so the column defaults to {} rather than to the JSON null.
+	var metadata []byte
+	if len(req.Metadata) > 0 {
+		metadata, err = json.Marshal(req.Metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var groupId pgtype.UUID
+	if req.GroupId != "" {
+		if err := groupId.Scan(req.GroupId); err != nil {
+			return nil, err
+		}
+	}
+
+	// The addresses are derived for the chain, e.g. ETH, so the token invoices take them from the same pool.
+	addr, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: userId, Coin: b.coin})
 	if err != nil {
 		if !errors.Is(err, pgx.ErrNoRows) {
 			return nil, err
 		}
 
-		addr, err = b.generateNextAddressHandler(ctx, q, &generateNextAddressHandlerData{userId: userId, network: b.network})
+		addr, err = b.deriveNextAddress(ctx, q, userId)
 		if err != nil {
 			return nil, err
 		}
@@ -315,20 +904,37 @@ func (b *baseCryptoProcessor[T, B]) createInvoice(ctx context.Context, req *dto.
 	invoice, err := q.CreateInvoice(
 		ctx,
 		db.CreateInvoiceParams{
-			CryptoAddress:         addr.Address,
-			Coin:                  coin,
-			RequiredAmount:        req.Amount,
-			ConfirmationsRequired: int16(req.Confirmations),
-			ExpiresAt:             expiresAt,
-			UserID:                userId,
+			CryptoAddress:          addr.Address,
+			Memo:                   addr.Memo,
+			Coin:                   coin,
+			RequiredAmount:         util.BigIntToPgNumeric(req.Amount),
+			ConfirmationsRequired:  int16(req.Confirmations),
+			ExpiresAt:              expiresAt,
+			UserID:                 userId,
+			UnderpaymentTolerance:  util.BigIntToPgNumeric(req.UnderpaymentTolerance),
+			FiatAmount:             pgtype.Float8{Float64: req.FiatAmount, Valid: req.FiatCurrency != ""},
+			FiatCurrency:           pgtype.Text{String: req.FiatCurrency, Valid: req.FiatCurrency != ""},
+			ExchangeRate:           pgtype.Float8{Float64: req.ExchangeRate, Valid: req.FiatCurrency != ""},
+			IdempotencyKey:         pgtype.Text{String: req.IdempotencyKey, Valid: req.IdempotencyKey != ""},
+			IdempotencyRequestHash: pgtype.Text{String: req.IdempotencyRequestHash, Valid: req.IdempotencyKey != ""},
+			Description:            pgtype.Text{String: req.Description, Valid: req.Description != ""},
+			ExternalOrderID:        pgtype.Text{String: req.ExternalOrderId, Valid: req.ExternalOrderId != ""},
+			Metadata:               metadata,
+			GroupID:                groupId,
 		},
 	)
 	if err != nil {
 		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CreateInvoice").Msg(util.DefaultFailedSqlQueryMsg)
 		return nil, err
 	}
+	if err := b.createInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{}, &invoice, pgtype.Int8{}); err != nil {
+		return nil, err
+	}
 
-	tx.Commit(ctx)
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return nil, err
+	}
 
 	return &invoice, nil
 }
@@ -345,10 +951,13 @@ func (b *baseCryptoProcessor[T, B]) handleInvoicePbReq(ctx context.Context, req
 
 	b.handleInvoice(ctx, *invoice)
 	b.broadcastUpdatedInvoice(ctx, invoice)
+	// Outside of the invoice tx, so the next invoices find a free address instead of deriving one.
+	go b.replenishAddressPool(ctx, invoice.UserID)
 
 	return invoice, nil
 }
 
+// releaseAddressHelper frees the address of the invoice, whether it takes another one is up to the address reuse policy of the user.
 func (b *baseCryptoProcessor[T, B]) releaseAddressHelper(ctx context.Context, invoice *db.Invoice) {
 	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
 	if err != nil {
@@ -357,12 +966,67 @@ func (b *baseCryptoProcessor[T, B]) releaseAddressHelper(ctx context.Context, in
 	}
 	defer tx.Rollback(ctx)
 
-	if _, err := q.UpdateIsOccupiedByCryptoAddress(ctx, db.UpdateIsOccupiedByCryptoAddressParams{IsOccupied: false, Address: invoice.CryptoAddress}); err != nil {
+	if _, err := q.UpdateIsOccupiedByCryptoAddress(ctx, db.UpdateIsOccupiedByCryptoAddressParams{IsOccupied: false, Address: invoice.CryptoAddress, Memo: invoice.Memo}); err != nil {
 		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateIsOccupiedByCryptoAddress").Msg(util.DefaultFailedSqlQueryMsg)
 		return
 	}
 
-	tx.Commit(ctx)
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+	}
+}
+
+func (b *baseCryptoProcessor[T, B]) createInvoiceEventHelper(ctx context.Context, q *db.Queries, oldStatus db.NullInvoiceStatusType, invoice *db.Invoice, confirmations pgtype.Int8) error {
+	return b.createInvoiceEvent(ctx, q, oldStatus, invoice, confirmations, false)
+}
+
+func (b *baseCryptoProcessor[T, B]) createReorgInvoiceEventHelper(ctx context.Context, q *db.Queries, oldStatus db.NullInvoiceStatusType, invoice *db.Invoice) error {
+	return b.createInvoiceEvent(ctx, q, oldStatus, invoice, pgtype.Int8{}, true)
+}
+
+func (b *baseCryptoProcessor[T, B]) createInvoiceEvent(ctx context.Context, q *db.Queries, oldStatus db.NullInvoiceStatusType, invoice *db.Invoice, confirmations pgtype.Int8, reorg bool) error {
+	_, err := q.CreateInvoiceEvent(ctx, db.CreateInvoiceEventParams{
+		InvoiceID:     invoice.ID,
+		OldStatus:     oldStatus,
+		NewStatus:     invoice.Status,
+		TxID:          invoice.TxID,
+		Amount:        invoice.ActualAmount,
+		Confirmations: confirmations,
+		Reorg:         reorg,
+	})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CreateInvoiceEvent").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	// Locked till the end of the tx, so the stream events are committed in the order of their seqs
+	// and a subscriber resuming from a seq can't miss an earlier one committed later.
+	if err := q.LockInvoiceStreamEvents(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "LockInvoiceStreamEvents").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+	if _, err := q.CreateInvoiceStreamEvent(ctx, db.CreateInvoiceStreamEventParams{
+		InvoiceID:     invoice.ID,
+		Status:        invoice.Status,
+		ActualAmount:  invoice.ActualAmount,
+		TxID:          invoice.TxID,
+		ConfirmedAt:   invoice.ConfirmedAt,
+		Confirmations: invoice.Confirmations,
+	}); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CreateInvoiceStreamEvent").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	event := webhook.InvoiceUpdatedEvent
+	if reorg {
+		event = webhook.InvoiceReorgedEvent
+	}
+	if err := webhook.EnqueueInvoiceEvent(ctx, q, event, invoice); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msg(util.FailedWebhookEnqueueMsg)
+		return err
+	}
+
+	return nil
 }
 
 func (b *baseCryptoProcessor[T, B]) broadcastUpdatedInvoice(ctx context.Context, invoice *db.Invoice) {
@@ -382,9 +1046,11 @@ func (b *baseCryptoProcessor[T, B]) broadcastUpdatedInvoice(ctx context.Context,
 }
 
 func (b *baseCryptoProcessor[T, B]) expireInvoice(ctx context.Context, invoice *db.Invoice) {
-	if _, loaded := b.pendingInvoices.LoadAndDelete(invoice.CryptoAddress); !loaded {
+	value, ok := b.pendingInvoices.Load(invoiceSlotKey(invoice))
+	if !ok || value.invoice.Load().ID != invoice.ID {
 		return
 	}
+	oldStatus := value.invoice.Load().Status
 
 	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
 	if err != nil {
@@ -395,14 +1061,102 @@ func (b *baseCryptoProcessor[T, B]) expireInvoice(ctx context.Context, invoice *
 
 	expiredInvoice, err := q.ExpireInvoiceById(ctx, invoice.ID)
 	if err != nil {
-		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ExpireInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		// The invoice has been cancelled or confirmed meanwhile, that path untracks it.
+		if !errors.Is(err, pgx.ErrNoRows) {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ExpireInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		}
+		return
+	}
+	if err := b.createInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{InvoiceStatusType: oldStatus, Valid: true}, &expiredInvoice, pgtype.Int8{}); err != nil {
 		return
 	}
 
-	go b.releaseAddressHelper(ctx, invoice)
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return
+	}
+
+	if _, ok := b.untrackPendingInvoice(invoice); !ok {
+		return
+	}
+	b.handleExpiredInvoice(ctx, expiredInvoice)
 	b.broadcastUpdatedInvoice(ctx, &expiredInvoice)
+}
+
+func (b *baseCryptoProcessor[T, B]) cancelInvoice(ctx context.Context, invoice *db.Invoice) (*db.Invoice, error) {
+	return b.cancelInvoiceHelper(ctx, invoice, false)
+}
+
+// cancelGroupSiblingInvoice also cancels a partially paid invoice, as its group has already been paid through another coin.
+func (b *baseCryptoProcessor[T, B]) cancelGroupSiblingInvoice(ctx context.Context, invoice *db.Invoice) (*db.Invoice, error) {
+	return b.cancelInvoiceHelper(ctx, invoice, true)
+}
+
+func (b *baseCryptoProcessor[T, B]) cancelInvoiceHelper(ctx context.Context, invoice *db.Invoice, groupSibling bool) (*db.Invoice, error) {
+	value, ok := b.pendingInvoices.Load(invoiceSlotKey(invoice))
+	if !ok || value.invoice.Load().ID != invoice.ID {
+		return nil, InvoiceNotCancellableErr
+	}
+	oldStatus := value.invoice.Load().Status
+	if oldStatus != db.InvoiceStatusTypePENDING && (!groupSibling || oldStatus != db.InvoiceStatusTypePARTIALLYPAID) {
+		return nil, InvoiceNotCancellableErr
+	}
+
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var cancelledInvoice db.Invoice
+	queryName := "CancelInvoiceById"
+	if groupSibling {
+		queryName = "CancelGroupSiblingInvoiceById"
+		cancelledInvoice, err = q.CancelGroupSiblingInvoiceById(ctx, invoice.ID)
+	} else {
+		cancelledInvoice, err = q.CancelInvoiceById(ctx, invoice.ID)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, InvoiceNotCancellableErr
+		}
+
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", queryName).Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	if err := b.createInvoiceEventHelper(ctx, q, db.NullInvoiceStatusType{InvoiceStatusType: oldStatus, Valid: true}, &cancelledInvoice, pgtype.Int8{}); err != nil {
+		return nil, err
+	}
 
-	tx.Commit(ctx)
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return nil, err
+	}
+
+	// Untracked only once the cancellation is committed, a failure above leaves the invoice pending and watched.
+	if value, ok := b.untrackPendingInvoice(invoice); ok {
+		value.cancelTimeoutFunc()
+	}
+
+	go b.releaseAddressHelper(ctx, invoice)
+	b.broadcastUpdatedInvoice(ctx, &cancelledInvoice)
+
+	return &cancelledInvoice, nil
+}
+
+// untrackPendingInvoice stops watching the invoice, unless its address has already been taken over by another one.
+// Stopping its expiry timer is up to the caller, as the expiry itself runs on the context of the timer.
+func (b *baseCryptoProcessor[T, B]) untrackPendingInvoice(invoice *db.Invoice) (pendingInvoice, bool) {
+	value, ok := b.pendingInvoices.Load(invoiceSlotKey(invoice))
+	if !ok || value.invoice.Load().ID != invoice.ID {
+		return value, false
+	}
+	if _, loaded := b.pendingInvoices.LoadAndDelete(invoiceSlotKey(invoice)); !loaded {
+		return value, false
+	}
+
+	return value, true
 }
 
 func (b *baseCryptoProcessor[T, B]) handleInvoiceHelper(confirmedInvoiceCtx context.Context, invoice *db.Invoice) {
@@ -415,7 +1169,7 @@ func (b *baseCryptoProcessor[T, B]) handleInvoiceHelper(confirmedInvoiceCtx cont
 	}
 }
 func (b *baseCryptoProcessor[T, B]) handleInvoice(ctx context.Context, invoice db.Invoice) {
-	if _, ok := b.pendingInvoices.Load(invoice.CryptoAddress); ok {
+	if _, ok := b.pendingInvoices.Load(invoiceSlotKey(&invoice)); ok {
 		return
 	}
 
@@ -423,23 +1177,59 @@ func (b *baseCryptoProcessor[T, B]) handleInvoice(ctx context.Context, invoice d
 
 	invoicePtr := &atomic.Pointer[db.Invoice]{}
 	invoicePtr.Store(&invoice)
-	b.pendingInvoices.Store(invoice.CryptoAddress, pendingInvoice{invoice: invoicePtr, cancelTimeoutFunc: cancel})
+	b.pendingInvoices.Store(invoiceSlotKey(&invoice), pendingInvoice{invoice: invoicePtr, cancelTimeoutFunc: cancel})
 
 	go b.handleInvoiceHelper(confirmedInvoiceCtx, &invoice)
 }
 
+func (b *baseCryptoProcessor[T, B]) handleExpiredInvoiceHelper(graceWindowCtx context.Context, invoice *db.Invoice) {
+	select {
+	case <-time.After(invoice.ExpiresAt.Time.Add(b.latePaymentGraceWindow).Sub(time.Now().UTC())):
+		b.expiredInvoices.Delete(invoiceSlotKey(invoice))
+		b.releaseAddressHelper(graceWindowCtx, invoice)
+		return
+	case <-graceWindowCtx.Done():
+		return
+	}
+}
+
+// handleExpiredInvoice keeps watching the address of the expired invoice for late payments
+// and releases it only once the grace window has passed, so it can't be handed to a new invoice meanwhile.
+func (b *baseCryptoProcessor[T, B]) handleExpiredInvoice(ctx context.Context, invoice db.Invoice) {
+	if _, ok := b.expiredInvoices.Load(invoiceSlotKey(&invoice)); ok {
+		return
+	}
+
+	graceWindowCtx, cancel := context.WithCancel(ctx)
+
+	invoicePtr := &atomic.Pointer[db.Invoice]{}
+	invoicePtr.Store(&invoice)
+	b.expiredInvoices.Store(invoiceSlotKey(&invoice), pendingInvoice{invoice: invoicePtr, cancelTimeoutFunc: cancel})
+
+	go b.handleExpiredInvoiceHelper(graceWindowCtx, &invoice)
+}
+
+func (b *baseCryptoProcessor[T, B]) paymentUri(invoice *db.Invoice, label string) (string, error) {
+	return buildPaymentUri(b.network, invoice, label)
+}
+
 func (b *baseCryptoProcessor[T, B]) supportsCoin(coin db.CoinType) bool {
 	return b.coin == coin || b.supportedTokens[coin]
 }
 
+func (b *baseCryptoProcessor[T, B]) networkType() listener.NetworkType {
+	return b.network
+}
+
 func newBaseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock](
 	log *zerolog.Logger,
 	dbConnPool *pgxpool.Pool,
 	invoiceCn chan<- db.Invoice,
 	daemon listener.SharedDaemonRpcClient[T, B],
-	verifyTxHandler func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (float64, error),
+	verifyTxHandler func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (*big.Int, error),
 	generateNextAddressHandler func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error),
 	supportedTokens []db.CoinType,
+	latePaymentGraceWindow time.Duration,
 ) (*baseCryptoProcessor[T, B], error) {
 	net, err := daemon.GetNetworkType()
 	if err != nil {
@@ -456,6 +1246,8 @@ func newBaseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock](
 			coin:                       daemon.GetCoinType(),
 			supportedTokens:            util.SliceToSet(supportedTokens),
 			pendingInvoices:            new(util.SyncMapTypeSafe[string, pendingInvoice]),
+			expiredInvoices:            new(util.SyncMapTypeSafe[string, pendingInvoice]),
+			latePaymentGraceWindow:     latePaymentGraceWindow,
 			verifyTxHandler:            verifyTxHandler,
 			generateNextAddressHandler: generateNextAddressHandler,
 		},
@@ -2,8 +2,10 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math/big"
 	"os"
 	"sync/atomic"
 	"testing"
@@ -12,6 +14,7 @@ import (
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
 	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/rate"
 	"github.com/chekist32/goipay/internal/util"
 	"github.com/chekist32/goipay/test"
 	db_test "github.com/chekist32/goipay/test/db"
@@ -39,12 +42,20 @@ func (t TestTx) IsDoubleSpendSeen() bool {
 }
 
 type TestBlock struct {
-	Height uint64
+	Height     uint64
+	Hash       string
+	ParentHash string
 }
 
 func (b TestBlock) GetTxHashes() []string {
 	return nil
 }
+func (b TestBlock) GetHash() string {
+	return b.Hash
+}
+func (b TestBlock) GetParentHash() string {
+	return b.ParentHash
+}
 
 func getInvoiceOrFatal(ctx context.Context, q *db_test.Queries, id *pgtype.UUID) db.Invoice {
 	invoice, err := q.FindInvoiceById(ctx, *id)
@@ -68,9 +79,26 @@ func getInvoiceOrFatal(ctx context.Context, q *db_test.Queries, id *pgtype.UUID)
 	}
 }
 
+func atomicUnitsOrFatal(amount string, coin db.CoinType) *big.Int {
+	units, err := util.ParseDecimalAmount(amount, coin)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return units
+}
+
+func pgAmountOrFatal(amount string, coin db.CoinType) pgtype.Numeric {
+	return util.BigIntToPgNumeric(atomicUnitsOrFatal(amount, coin))
+}
+
+func formatPgAmount(amount pgtype.Numeric, coin db.CoinType) string {
+	return util.FormatAtomicUnits(util.PgNumericToBigInt(amount), coin)
+}
+
 func createNewTestBaseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock](
 	daemon listener.SharedDaemonRpcClient[T, B],
-	verifyTxHandler func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (float64, error),
+	verifyTxHandler func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (*big.Int, error),
 	generateNextAddressHandler func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error),
 ) (chan db.Invoice, *baseCryptoProcessor[T, B], testcontainers.Container, func(ctx context.Context)) {
 	invoiceCn := make(chan db.Invoice)
@@ -84,6 +112,7 @@ func createNewTestBaseCryptoProcessor[T listener.SharedTx, B listener.SharedBloc
 		verifyTxHandler,
 		generateNextAddressHandler,
 		nil,
+		0,
 	)
 	if err != nil {
 		log.Fatal(err)
@@ -104,8 +133,8 @@ func TestBroadcastInvoice(t *testing.T) {
 
 		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -135,7 +164,7 @@ func TestBroadcastInvoice(t *testing.T) {
 		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -165,8 +194,8 @@ func TestReleaseAddressHelper(t *testing.T) {
 
 		_, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -196,7 +225,7 @@ func TestReleaseAddressHelper(t *testing.T) {
 		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -228,8 +257,8 @@ func TestExpireInvoice(t *testing.T) {
 		d.On("GetCoinType").Return(db.CoinTypeXMR)
 		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -260,7 +289,7 @@ func TestExpireInvoice(t *testing.T) {
 		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -294,67 +323,146 @@ func TestExpireInvoice(t *testing.T) {
 		assert.Equal(t, expectedPendingInvoice.ID, expiredInvoice.ID)
 		assert.Equal(t, db.InvoiceStatusTypeEXPIRED, expiredInvoice.Status)
 		assert.False(t, expiredInvoice.ConfirmedAt.Valid)
+
+		events, err := q.FindInvoiceEventsByInvoiceId(ctx, expectedPendingInvoice.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(events))
+		assert.Equal(t, db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypePENDING, Valid: true}, events[0].OldStatus)
+		assert.Equal(t, db.InvoiceStatusTypeEXPIRED, events[0].NewStatus)
 	})
 }
 
-func TestPersistCryptoCache(t *testing.T) {
+func TestHandleExpiredInvoice(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 
-	t.Run("Should Properly Persist Crypto Cache", func(t *testing.T) {
-		// Given
-		expectedCoin := db.CoinTypeXMR
-		expectedLastHeight := uint64(123)
+	setup := func(t *testing.T, latePaymentGraceWindow time.Duration) (chan db.Invoice, *baseCryptoProcessor[TestTx, TestBlock], *db.Queries, db.CryptoAddress, db.Invoice, func(ctx context.Context)) {
 		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
 		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
-		d.On("GetCoinType").Return(expectedCoin)
-		d.On("GetLastBlockHeight").Return(expectedLastHeight, error(nil)).Maybe()
-		d.On("GetTransactionPool").Return([]string{}, error(nil)).Maybe()
-
-		_, p, _, close := createNewTestBaseCryptoProcessor(
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
 			},
 		)
-		defer close(ctx)
+		p.latePaymentGraceWindow = latePaymentGraceWindow
 
-		// When/Assert
 		q := db.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+			Address:    uuid.NewString(),
+			Coin:       db.CoinTypeXMR,
+			IsOccupied: true,
+			UserID:     userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
 
-		cache, err := q.FindCryptoCacheByCoin(ctx, expectedCoin)
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         addr.Address,
+			Coin:                  addr.Coin,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
+			ConfirmationsRequired: 0,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		expiredInvoice, err := q.ExpireInvoiceById(ctx, invoice.ID)
 		if err != nil {
 			log.Fatal(err)
 		}
-		assert.False(t, cache.LastSyncedBlockHeight.Valid)
 
-		p.daemonEx.Start(expectedLastHeight)
-		p.persistCryptoCache(ctx)
+		return invoiceCn, p, q, addr, expiredInvoice, close
+	}
 
-		cache, err = q.FindCryptoCacheByCoin(ctx, expectedCoin)
+	t.Run("Should Release The Address Only After The Grace Window", func(t *testing.T) {
+		// Given
+		_, p, q, expectedAddr, expiredInvoice, close := setup(t, 1*time.Second)
+		defer close(ctx)
+
+		// When
+		p.handleExpiredInvoice(ctx, expiredInvoice)
+
+		// Assert
+		_, ok := p.expiredInvoices.Load(expiredInvoice.CryptoAddress)
+		assert.True(t, ok)
+		_, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: expiredInvoice.UserID, Coin: expiredInvoice.Coin})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		<-time.After(1500 * time.Millisecond)
+
+		_, ok = p.expiredInvoices.Load(expiredInvoice.CryptoAddress)
+		assert.False(t, ok)
+		addr, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: expiredInvoice.UserID, Coin: expiredInvoice.Coin})
 		assert.NoError(t, err)
-		assert.True(t, cache.LastSyncedBlockHeight.Valid)
-		assert.Equal(t, int64(expectedLastHeight), cache.LastSyncedBlockHeight.Int64)
+		assert.Equal(t, expectedAddr, addr)
+	})
+
+	t.Run("Should Record Late Payment", func(t *testing.T) {
+		// Given
+		_, p, q, _, expiredInvoice, close := setup(t, 1*time.Hour)
+		defer close(ctx)
+
+		p.handleExpiredInvoice(ctx, expiredInvoice)
+		value, ok := p.expiredInvoices.Load(expiredInvoice.CryptoAddress)
+		if !ok {
+			log.Fatal("invoice is not watched")
+		}
+
+		// When
+		qTx, tx, err := util.InitDbQueriesWithTx(ctx, p.dbConnPool)
+		if err != nil {
+			log.Fatal(err)
+		}
+		paidInvoice, err := p.registerLatePayment(ctx, qTx, TestTx{TxId: "tx1"}, atomicUnitsOrFatal("0.5", db.CoinTypeXMR), value)
+		if err := tx.Commit(ctx); err != nil {
+			log.Fatal(err)
+		}
+
+		// Assert
+		assert.NoError(t, err)
+		// Storing it is up to the caller, once the payment is committed.
+		assert.Equal(t, db.InvoiceStatusTypeEXPIRED, value.invoice.Load().Status)
+		assert.Equal(t, expiredInvoice.ID, paidInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypePAIDAFTEREXPIRY, paidInvoice.Status)
+		assert.Equal(t, "0.5", formatPgAmount(paidInvoice.ActualAmount, paidInvoice.Coin))
+		assert.Equal(t, "tx1", paidInvoice.TxID.String)
+
+		events, err := q.FindInvoiceEventsByInvoiceId(ctx, expiredInvoice.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(events))
+		assert.Equal(t, db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypeEXPIRED, Valid: true}, events[0].OldStatus)
+		assert.Equal(t, db.InvoiceStatusTypePAIDAFTEREXPIRY, events[0].NewStatus)
 	})
 }
 
-func TestHandleInvoice(t *testing.T) {
+func TestCancelInvoice(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 
-	t.Run("Should Delete Expired Invoice From The Pending Invoices", func(t *testing.T) {
+	t.Run("Should Properly Cancel The Invoice And Release The Address", func(t *testing.T) {
 		// Given
 		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
 		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
 		d.On("GetCoinType").Return(db.CoinTypeXMR)
-
 		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -379,13 +487,13 @@ func TestHandleInvoice(t *testing.T) {
 		}
 
 		var expiresAt pgtype.Timestamptz
-		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
 			log.Fatal(err)
 		}
 		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -393,37 +501,53 @@ func TestHandleInvoice(t *testing.T) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		p.handleInvoice(ctx, expectedPendingInvoice)
 
 		// When
-		p.handleInvoice(ctx, expectedPendingInvoice)
+		cancelledInvoice, err := p.cancelInvoice(ctx, &expectedPendingInvoice)
 
 		// Assert
-		_ = test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
-		<-time.After(300 * time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, cancelledInvoice.Status)
 
 		_, ok := p.pendingInvoices.Load(expectedPendingInvoice.CryptoAddress)
 		assert.False(t, ok)
 
+		receivedInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, expectedPendingInvoice.ID, receivedInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, receivedInvoice.Status)
+
+		// wait for releaseAddressHelper
+		<-time.After(300 * time.Millisecond)
+
 		addr, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: expectedPendingInvoice.UserID, Coin: expectedPendingInvoice.Coin})
 		assert.NoError(t, err)
 		assert.Equal(t, expectedAddr, addr)
 
-		expiredInvoice := getInvoiceOrFatal(ctx, qTest, &expectedPendingInvoice.ID)
-		assert.Equal(t, expectedPendingInvoice.ID, expiredInvoice.ID)
-		assert.Equal(t, db.InvoiceStatusTypeEXPIRED, expiredInvoice.Status)
-		assert.False(t, expiredInvoice.ConfirmedAt.Valid)
+		invoice := getInvoiceOrFatal(ctx, qTest, &expectedPendingInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, invoice.Status)
+
+		// The stream event is committed along with the cancellation.
+		events, err := q.FindInvoiceStreamEventsAfterSeq(ctx, db.FindInvoiceStreamEventsAfterSeqParams{Seq: 0, Limit: 100})
+		assert.NoError(t, err)
+		cancelledEvents := 0
+		for i := 0; i < len(events); i++ {
+			if events[i].Invoice.ID == expectedPendingInvoice.ID && events[i].InvoiceStreamEvent.Status == db.InvoiceStatusTypeCANCELLED {
+				cancelledEvents++
+			}
+		}
+		assert.Equal(t, 1, cancelledEvents)
 	})
 
-	t.Run("Should Return From handleInvoiceHelper", func(t *testing.T) {
+	t.Run("Should Cancel Partially Paid Invoice Only As A Group Sibling", func(t *testing.T) {
 		// Given
 		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
 		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
 		d.On("GetCoinType").Return(db.CoinTypeXMR)
-
-		_, p, _, close := createNewTestBaseCryptoProcessor(
+		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -451,10 +575,10 @@ func TestHandleInvoice(t *testing.T) {
 		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
 			log.Fatal(err)
 		}
-		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+		pendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -462,126 +586,1148 @@ func TestHandleInvoice(t *testing.T) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		partiallyPaidInvoice, err := q.PartiallyPayInvoiceById(ctx, db.PartiallyPayInvoiceByIdParams{ID: pendingInvoice.ID, ActualAmount: pgAmountOrFatal("0.5", db.CoinTypeXMR), TxID: pgtype.Text{String: "tx1", Valid: true}})
+		if err != nil {
+			log.Fatal(err)
+		}
+		p.handleInvoice(ctx, partiallyPaidInvoice)
 
 		// When
-		cancelCtx, cancel := context.WithCancel(ctx)
+		_, err = p.cancelInvoice(ctx, &partiallyPaidInvoice)
 
-		p.handleInvoice(cancelCtx, expectedPendingInvoice)
-		cancel()
+		// Assert
+		assert.ErrorIs(t, err, InvoiceNotCancellableErr)
+
+		// When
+		cancelledInvoice, err := p.cancelGroupSiblingInvoice(ctx, &partiallyPaidInvoice)
 
 		// Assert
-		_, ok := p.pendingInvoices.Load(expectedPendingInvoice.CryptoAddress)
-		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, cancelledInvoice.Status)
 
-		_, err = q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: expectedPendingInvoice.UserID, Coin: expectedPendingInvoice.Coin})
-		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		_, ok := p.pendingInvoices.Load(partiallyPaidInvoice.CryptoAddress)
+		assert.False(t, ok)
 
-		pendingInvoice := getInvoiceOrFatal(ctx, qTest, &expectedPendingInvoice.ID)
-		assert.Equal(t, expectedPendingInvoice, pendingInvoice)
-	})
-}
+		receivedInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, partiallyPaidInvoice.ID, receivedInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, receivedInvoice.Status)
 
-func TestCreateInvoice(t *testing.T) {
-	t.Parallel()
-	ctx := context.Background()
+		invoice := getInvoiceOrFatal(ctx, qTest, &partiallyPaidInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, invoice.Status)
 
-	// Given
-	expectedAddress := uuid.NewString()
-	expectedUserId, err := util.StringToPgUUID(uuid.NewString())
-	if err != nil {
-		log.Fatal(err)
-	}
-	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
-	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
-	d.On("GetCoinType").Return(db.CoinTypeXMR)
+		events, err := q.FindInvoiceEventsByInvoiceId(ctx, partiallyPaidInvoice.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypePARTIALLYPAID, Valid: true}, events[len(events)-1].OldStatus)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, events[len(events)-1].NewStatus)
+	})
 
-	_, p, _, close := createNewTestBaseCryptoProcessor(
-		d,
-		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-			return 0, nil
-		},
-		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
-			return q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
-				Address:    expectedAddress,
-				Coin:       db.CoinTypeXMR,
-				IsOccupied: true,
-				UserID:     data.userId,
-			})
-		},
-	)
-	defer close(ctx)
+	t.Run("Should Return InvoiceNotCancellableErr", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		_, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
 
-	q := db.New(p.dbConnPool)
-	if _, err := q.CreateUserWithId(ctx, *expectedUserId); err != nil {
-		log.Fatal(err)
-	}
+		// When
+		_, err := p.cancelInvoice(ctx, &db.Invoice{CryptoAddress: uuid.NewString()})
 
-	// When
-	createdInvoice, err := p.createInvoice(ctx, &dto.NewInvoiceRequest{
-		UserId:        util.PgUUIDToString(*expectedUserId),
-		Coin:          db.CoinTypeXMR,
-		Amount:        123,
-		Timeout:       600,
-		Confirmations: 0,
+		// Assert
+		assert.ErrorIs(t, err, InvoiceNotCancellableErr)
 	})
-
-	// Assert
-	assert.NoError(t, err)
-	assert.Equal(t, db.CoinTypeXMR, createdInvoice.Coin)
-	assert.EqualValues(t, 123, createdInvoice.RequiredAmount)
-	assert.EqualValues(t, 0, createdInvoice.ConfirmationsRequired)
-	assert.Equal(t, expectedAddress, createdInvoice.CryptoAddress)
 }
 
-func TestHandleInvoicePbReq(t *testing.T) {
-	t.Parallel()
-	ctx := context.Background()
-
-	// Given
-	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
-	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
-	d.On("GetCoinType").Return(db.CoinTypeXMR)
-	invCn, p, _, close := createNewTestBaseCryptoProcessor(
-		d,
-		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-			return 0, nil
-		},
-		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
-			return db.CryptoAddress{}, nil
-		},
-	)
-	defer close(ctx)
+func TestIsInvoicePaid(t *testing.T) {
+	invoice := &db.Invoice{RequiredAmount: pgAmountOrFatal("1", db.CoinTypeXMR), UnderpaymentTolerance: pgAmountOrFatal("0.1", db.CoinTypeXMR)}
 
-	q := db.New(p.dbConnPool)
-	qTest := db_test.New(p.dbConnPool)
-	userId, _, _ := createUserWithXmrData(ctx, q)
+	assert.True(t, isInvoicePaid(invoice, atomicUnitsOrFatal("1.2", db.CoinTypeXMR)))
+	assert.True(t, isInvoicePaid(invoice, atomicUnitsOrFatal("1", db.CoinTypeXMR)))
+	assert.True(t, isInvoicePaid(invoice, atomicUnitsOrFatal("0.9", db.CoinTypeXMR)))
+	assert.False(t, isInvoicePaid(invoice, atomicUnitsOrFatal("0.899999999999", db.CoinTypeXMR)))
+}
 
-	req := &dto.NewInvoiceRequest{
-		UserId:        util.PgUUIDToString(userId),
-		Coin:          db.CoinTypeXMR,
-		Amount:        123,
-		Timeout:       600,
-		Confirmations: 0,
-	}
+func TestPaidInvoiceStatus(t *testing.T) {
+	invoice := &db.Invoice{RequiredAmount: pgAmountOrFatal("1", db.CoinTypeXMR), UnderpaymentTolerance: pgAmountOrFatal("0.1", db.CoinTypeXMR)}
 
-	// When
-	invoice, err := p.handleInvoicePbReq(ctx, req)
+	assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, paidInvoiceStatus(invoice, atomicUnitsOrFatal("0.9", db.CoinTypeXMR)))
+	assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, paidInvoiceStatus(invoice, atomicUnitsOrFatal("0.5", db.CoinTypeXMR)))
+	assert.Equal(t, db.InvoiceStatusTypePENDING, paidInvoiceStatus(invoice, new(big.Int)))
+}
 
-	// Assert
-	assert.NoError(t, err)
+func TestRegisterPayment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
 
-	expectedInvoice := getInvoiceOrFatal(ctx, qTest, &invoice.ID)
+	t.Run("Should Accumulate Partial Payments", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		d.On("GetTransactions", []string{"tx1", "tx2"}).Return([]TestTx{{TxId: "tx1"}, {TxId: "tx2"}}, error(nil))
+		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
 
-	assert.Equal(t, expectedInvoice, *invoice)
+		q := db.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		expectedAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+			Address:    uuid.NewString(),
+			Coin:       db.CoinTypeXMR,
+			IsOccupied: true,
+			UserID:     userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	pendingInvoice, ok := p.pendingInvoices.Load(expectedInvoice.CryptoAddress)
-	assert.True(t, ok)
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         expectedAddr.Address,
+			Coin:                  expectedAddr.Coin,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
+			ConfirmationsRequired: 1,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		p.handleInvoice(ctx, expectedPendingInvoice)
+		value, ok := p.pendingInvoices.Load(expectedPendingInvoice.CryptoAddress)
+		if !ok {
+			log.Fatal("invoice is not pending")
+		}
+
+		registerPayment := func(cryptoTx TestTx, amount string, commit bool) {
+			qTx, tx, err := util.InitDbQueriesWithTx(ctx, p.dbConnPool)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer tx.Rollback(ctx)
+
+			paidInvoice, err := p.registerPayment(ctx, qTx, cryptoTx, atomicUnitsOrFatal(amount, db.CoinTypeXMR), value)
+			assert.NoError(t, err)
+			if !commit {
+				return
+			}
+			assert.NoError(t, tx.Commit(ctx))
+
+			if paidInvoice != nil {
+				value.invoice.Store(paidInvoice)
+				p.broadcastUpdatedInvoice(ctx, paidInvoice)
+			}
+		}
+
+		// When/Assert
+		// Nothing is stored until the payment is committed.
+		registerPayment(TestTx{TxId: "tx0"}, "0.4", false)
+		assert.Equal(t, db.InvoiceStatusTypePENDING, value.invoice.Load().Status)
+
+		registerPayment(TestTx{TxId: "tx1"}, "0.4", true)
+		partiallyPaidInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, partiallyPaidInvoice.Status)
+		assert.Equal(t, "0.4", formatPgAmount(partiallyPaidInvoice.ActualAmount, partiallyPaidInvoice.Coin))
+
+		registerPayment(TestTx{TxId: "tx1"}, "0.4", true)
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, value.invoice.Load().Status)
+		assert.Equal(t, "0.4", formatPgAmount(value.invoice.Load().ActualAmount, db.CoinTypeXMR))
+
+		registerPayment(TestTx{TxId: "tx2"}, "0.6", true)
+		p.confirmCONFIRMED(ctx, value)
+		paidInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, paidInvoice.Status)
+		assert.Equal(t, "1", formatPgAmount(paidInvoice.ActualAmount, paidInvoice.Coin))
+		assert.Equal(t, "tx2", paidInvoice.TxID.String)
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, expectedPendingInvoice.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(payments))
+		for i := 0; i < len(payments); i++ {
+			assert.False(t, payments[i].ConfirmedAt.Valid)
+		}
+	})
+}
+
+func TestConfirmCONFIRMED(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("Should Broadcast Confirmation Progress", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		d.On("GetTransactions", []string{"tx1"}).Return([]TestTx{{TxId: "tx1", Confirmations: 0}}, error(nil)).Once()
+		d.On("GetTransactions", []string{"tx1"}).Return([]TestTx{{TxId: "tx1", Confirmations: 2}}, error(nil)).Twice()
+		d.On("GetTransactions", []string{"tx1"}).Return([]TestTx{{TxId: "tx1", Confirmations: 3}}, error(nil)).Once()
+		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		expectedAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+			Address:    uuid.NewString(),
+			Coin:       db.CoinTypeXMR,
+			IsOccupied: true,
+			UserID:     userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         expectedAddr.Address,
+			Coin:                  expectedAddr.Coin,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
+			ConfirmationsRequired: 3,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		p.handleInvoice(ctx, expectedPendingInvoice)
+		value, ok := p.pendingInvoices.Load(expectedPendingInvoice.CryptoAddress)
+		if !ok {
+			log.Fatal("invoice is not pending")
+		}
+
+		inTx := func(f func(q *db.Queries)) {
+			qTx, tx, err := util.InitDbQueriesWithTx(ctx, p.dbConnPool)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer tx.Rollback(ctx)
+
+			f(qTx)
+
+			tx.Commit(ctx)
+		}
+
+		// When/Assert
+		var paidInvoice *db.Invoice
+		inTx(func(q *db.Queries) {
+			paidInvoice, err = p.registerPayment(ctx, q, TestTx{TxId: "tx1"}, atomicUnitsOrFatal("1", db.CoinTypeXMR), value)
+			assert.NoError(t, err)
+		})
+		value.invoice.Store(paidInvoice)
+		p.broadcastUpdatedInvoice(ctx, paidInvoice)
+		mempoolInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, mempoolInvoice.Status)
+		assert.Equal(t, int32(0), mempoolInvoice.Confirmations)
+
+		// Still in the mempool.
+		p.confirmCONFIRMED(ctx, value)
+		assert.Equal(t, int32(0), value.invoice.Load().Confirmations)
+
+		p.confirmCONFIRMED(ctx, value)
+		progressInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, progressInvoice.Status)
+		assert.Equal(t, int32(2), progressInvoice.Confirmations)
+
+		events, err := q.FindInvoiceEventsByInvoiceId(ctx, expectedPendingInvoice.ID)
+		assert.NoError(t, err)
+		progressEvent := events[len(events)-1]
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, progressEvent.OldStatus.InvoiceStatusType)
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, progressEvent.NewStatus)
+		assert.Equal(t, int64(2), progressEvent.Confirmations.Int64)
+
+		// The count hasn't grown, so nothing is broadcasted.
+		p.confirmCONFIRMED(ctx, value)
+		assert.Equal(t, int32(2), value.invoice.Load().Confirmations)
+
+		p.confirmCONFIRMED(ctx, value)
+		confirmedInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, db.InvoiceStatusTypeCONFIRMED, confirmedInvoice.Status)
+		assert.Equal(t, int32(3), confirmedInvoice.Confirmations)
+	})
+}
+
+func TestConfirmCONFIRMEDWithRejectedTx(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("Should Drop Only The Rejected Payment", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		// tx2 is gone from the chain.
+		d.On("GetTransactions", []string{"tx1", "tx2"}).Return([]TestTx{{TxId: "tx1", Confirmations: 1}}, error(nil)).Once()
+		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		expectedAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+			Address:    uuid.NewString(),
+			Coin:       db.CoinTypeXMR,
+			IsOccupied: true,
+			UserID:     userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         expectedAddr.Address,
+			Coin:                  expectedAddr.Coin,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
+			ConfirmationsRequired: 1,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, txId := range []string{"tx1", "tx2"} {
+			if _, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: expectedPendingInvoice.ID, TxID: txId, Amount: pgAmountOrFatal("0.5", db.CoinTypeXMR)}); err != nil {
+				log.Fatal(err)
+			}
+		}
+		mempoolInvoice, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: expectedPendingInvoice.ID, ActualAmount: pgAmountOrFatal("1", db.CoinTypeXMR), TxID: pgtype.Text{String: "tx2", Valid: true}})
+		if err != nil {
+			log.Fatal(err)
+		}
+		p.handleInvoice(ctx, mempoolInvoice)
+		value, ok := p.pendingInvoices.Load(mempoolInvoice.CryptoAddress)
+		if !ok {
+			log.Fatal("invoice is not pending")
+		}
+
+		// When
+		p.confirmCONFIRMED(ctx, value)
+
+		// Assert
+		partiallyPaidInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, partiallyPaidInvoice.Status)
+		assert.Equal(t, "0.5", formatPgAmount(partiallyPaidInvoice.ActualAmount, partiallyPaidInvoice.Coin))
+		assert.Equal(t, "tx1", partiallyPaidInvoice.TxID.String)
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, mempoolInvoice.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(payments))
+		assert.Equal(t, "tx1", payments[0].TxID)
+
+		// Still tracked, so the rest can be paid.
+		_, ok = p.pendingInvoices.Load(mempoolInvoice.CryptoAddress)
+		assert.True(t, ok)
+	})
+}
+
+func TestRollbackReorgedInvoice(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	createPaidInvoice := func(q *db.Queries, userId pgtype.UUID, confirmationsRequired int16, txIds ...string) db.Invoice {
+		addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+			Address:    uuid.NewString(),
+			Coin:       db.CoinTypeXMR,
+			IsOccupied: true,
+			UserID:     userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         addr.Address,
+			Coin:                  addr.Coin,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
+			ConfirmationsRequired: confirmationsRequired,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		for i := 0; i < len(txIds); i++ {
+			payment, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: invoice.ID, TxID: txIds[i], Amount: util.BigIntToPgNumeric(new(big.Int).Quo(atomicUnitsOrFatal("1", db.CoinTypeXMR), big.NewInt(int64(len(txIds)))))})
+			if err != nil {
+				log.Fatal(err)
+			}
+			if _, err := q.ConfirmInvoicePaymentById(ctx, payment.ID); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if _, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: invoice.ID, ActualAmount: pgAmountOrFatal("1", db.CoinTypeXMR), TxID: pgtype.Text{String: txIds[len(txIds)-1], Valid: true}}); err != nil {
+			log.Fatal(err)
+		}
+		invoice, err = q.ConfirmInvoiceById(ctx, invoice.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := q.UpdateIsOccupiedByCryptoAddress(ctx, db.UpdateIsOccupiedByCryptoAddressParams{IsOccupied: false, Address: addr.Address}); err != nil {
+			log.Fatal(err)
+		}
+
+		return invoice
+	}
+
+	t.Run("Should Roll Back The Confirmed Invoice Whose Tx Disappeared", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		d.On("GetTransactions", []string{"tx1", "tx2"}).Return([]TestTx{{TxId: "tx1", Confirmations: 5}}, error(nil))
+		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		invoice := createPaidInvoice(q, userId, 1, "tx1", "tx2")
+
+		// When
+		p.handleChainReorg(ctx, listener.ChainReorg{ForkHeight: 10, TipHeight: 12})
+
+		// Assert
+		rolledBackInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, invoice.ID, rolledBackInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, rolledBackInvoice.Status)
+		assert.Equal(t, "0.5", formatPgAmount(rolledBackInvoice.ActualAmount, rolledBackInvoice.Coin))
+		assert.Equal(t, "tx1", rolledBackInvoice.TxID.String)
+		assert.False(t, rolledBackInvoice.ConfirmedAt.Valid)
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+		assert.NoError(t, err)
+		assert.Len(t, payments, 1)
+		assert.Equal(t, "tx1", payments[0].TxID)
+
+		events, err := q.FindInvoiceEventsByInvoiceId(ctx, invoice.ID)
+		assert.NoError(t, err)
+		lastEvent := events[len(events)-1]
+		assert.True(t, lastEvent.Reorg)
+		assert.Equal(t, db.InvoiceStatusTypeCONFIRMED, lastEvent.OldStatus.InvoiceStatusType)
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, lastEvent.NewStatus)
+
+		value, ok := p.pendingInvoices.Load(invoice.CryptoAddress)
+		assert.True(t, ok)
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, value.invoice.Load().Status)
+
+		_, err = q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeXMR})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Should Expire The Rolled Back Invoice Whose Address Is In Use By Another Invoice", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		d.On("GetTransactions", []string{"tx1"}).Return([]TestTx{}, error(nil))
+		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		invoice := createPaidInvoice(q, userId, 1, "tx1")
+
+		if _, err := q.UpdateIsOccupiedByCryptoAddress(ctx, db.UpdateIsOccupiedByCryptoAddressParams{IsOccupied: true, Address: invoice.CryptoAddress}); err != nil {
+			log.Fatal(err)
+		}
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		newInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:  invoice.CryptoAddress,
+			Coin:           invoice.Coin,
+			RequiredAmount: pgAmountOrFatal("1", db.CoinTypeXMR),
+			ExpiresAt:      expiresAt,
+			UserID:         userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		p.handleInvoice(ctx, newInvoice)
+
+		// When
+		p.handleChainReorg(ctx, listener.ChainReorg{ForkHeight: 10, TipHeight: 12})
+
+		// Assert
+		rolledBackInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, invoice.ID, rolledBackInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeEXPIRED, rolledBackInvoice.Status)
+		assert.False(t, rolledBackInvoice.ConfirmedAt.Valid)
+
+		events, err := q.FindInvoiceEventsByInvoiceId(ctx, invoice.ID)
+		assert.NoError(t, err)
+		lastEvent := events[len(events)-1]
+		assert.True(t, lastEvent.Reorg)
+		assert.Equal(t, db.InvoiceStatusTypeCONFIRMED, lastEvent.OldStatus.InvoiceStatusType)
+		assert.Equal(t, db.InvoiceStatusTypeEXPIRED, lastEvent.NewStatus)
+
+		value, ok := p.pendingInvoices.Load(invoice.CryptoAddress)
+		assert.True(t, ok)
+		assert.Equal(t, newInvoice.ID, value.invoice.Load().ID)
+
+		_, ok = p.expiredInvoices.Load(invoice.CryptoAddress)
+		assert.False(t, ok)
+	})
+
+	t.Run("Should Leave The Invoice Whose Tx Is Still In The Chain", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		d.On("GetTransactions", []string{"tx1"}).Return([]TestTx{{TxId: "tx1", Confirmations: 3}}, error(nil))
+		_, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		qTest := db_test.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		invoice := createPaidInvoice(q, userId, 2, "tx1")
+
+		// When
+		p.handleChainReorg(ctx, listener.ChainReorg{ForkHeight: 10, TipHeight: 12})
+
+		// Assert
+		assert.Equal(t, db.InvoiceStatusTypeCONFIRMED, getInvoiceOrFatal(ctx, qTest, &invoice.ID).Status)
+
+		_, ok := p.pendingInvoices.Load(invoice.CryptoAddress)
+		assert.False(t, ok)
+	})
+}
+
+func TestPersistCryptoCache(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("Should Properly Persist Crypto Cache", func(t *testing.T) {
+		// Given
+		expectedCoin := db.CoinTypeXMR
+		expectedLastHeight := uint64(123)
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(expectedCoin)
+		d.On("GetLastBlockHeight").Return(expectedLastHeight, error(nil)).Maybe()
+		d.On("GetTransactionPool").Return([]string{}, error(nil)).Maybe()
+
+		_, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		// When/Assert
+		q := db.New(p.dbConnPool)
+
+		cache, err := q.FindCryptoCacheByCoin(ctx, expectedCoin)
+		if err != nil {
+			log.Fatal(err)
+		}
+		assert.False(t, cache.LastSyncedBlockHeight.Valid)
+
+		p.daemonEx.Start(expectedLastHeight)
+		p.persistCryptoCache(ctx)
+
+		cache, err = q.FindCryptoCacheByCoin(ctx, expectedCoin)
+		assert.NoError(t, err)
+		assert.True(t, cache.LastSyncedBlockHeight.Valid)
+		assert.Equal(t, int64(expectedLastHeight), cache.LastSyncedBlockHeight.Int64)
+	})
+}
+
+func TestHandleInvoice(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("Should Delete Expired Invoice From The Pending Invoices", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+
+		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		qTest := db_test.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		expectedAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+			Address:    uuid.NewString(),
+			Coin:       db.CoinTypeXMR,
+			IsOccupied: true,
+			UserID:     userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         expectedAddr.Address,
+			Coin:                  expectedAddr.Coin,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
+			ConfirmationsRequired: 0,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// When
+		p.handleInvoice(ctx, expectedPendingInvoice)
+
+		// Assert
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		<-time.After(300 * time.Millisecond)
+
+		_, ok := p.pendingInvoices.Load(expectedPendingInvoice.CryptoAddress)
+		assert.False(t, ok)
+
+		addr, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: expectedPendingInvoice.UserID, Coin: expectedPendingInvoice.Coin})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedAddr, addr)
+
+		expiredInvoice := getInvoiceOrFatal(ctx, qTest, &expectedPendingInvoice.ID)
+		assert.Equal(t, expectedPendingInvoice.ID, expiredInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeEXPIRED, expiredInvoice.Status)
+		assert.False(t, expiredInvoice.ConfirmedAt.Valid)
+	})
+
+	t.Run("Should Return From handleInvoiceHelper", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+
+		_, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		qTest := db_test.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		expectedAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+			Address:    uuid.NewString(),
+			Coin:       db.CoinTypeXMR,
+			IsOccupied: true,
+			UserID:     userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         expectedAddr.Address,
+			Coin:                  expectedAddr.Coin,
+			RequiredAmount:        pgAmountOrFatal("1", db.CoinTypeXMR),
+			ConfirmationsRequired: 0,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// When
+		cancelCtx, cancel := context.WithCancel(ctx)
+
+		p.handleInvoice(cancelCtx, expectedPendingInvoice)
+		cancel()
+
+		// Assert
+		_, ok := p.pendingInvoices.Load(expectedPendingInvoice.CryptoAddress)
+		assert.True(t, ok)
+
+		_, err = q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: expectedPendingInvoice.UserID, Coin: expectedPendingInvoice.Coin})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		pendingInvoice := getInvoiceOrFatal(ctx, qTest, &expectedPendingInvoice.ID)
+		assert.Equal(t, expectedPendingInvoice, pendingInvoice)
+	})
+}
+
+func TestCreateInvoice(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	expectedAddress := uuid.NewString()
+	expectedUserId, err := util.StringToPgUUID(uuid.NewString())
+	if err != nil {
+		log.Fatal(err)
+	}
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+
+	_, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return new(big.Int), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+				Address:    expectedAddress,
+				Coin:       db.CoinTypeXMR,
+				IsOccupied: true,
+				UserID:     data.userId,
+			})
+		},
+	)
+	defer close(ctx)
+
+	q := db.New(p.dbConnPool)
+	if _, err := q.CreateUserWithId(ctx, *expectedUserId); err != nil {
+		log.Fatal(err)
+	}
+
+	// When
+	createdInvoice, err := p.createInvoice(ctx, &dto.NewInvoiceRequest{
+		UserId:        util.PgUUIDToString(*expectedUserId),
+		Coin:          db.CoinTypeXMR,
+		Amount:        atomicUnitsOrFatal("123", db.CoinTypeXMR),
+		Timeout:       600,
+		Confirmations: 0,
+
+		UnderpaymentTolerance: atomicUnitsOrFatal("1.5", db.CoinTypeXMR),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, db.CoinTypeXMR, createdInvoice.Coin)
+	assert.Equal(t, "123", formatPgAmount(createdInvoice.RequiredAmount, createdInvoice.Coin))
+	assert.Equal(t, "1.5", formatPgAmount(createdInvoice.UnderpaymentTolerance, createdInvoice.Coin))
+	assert.EqualValues(t, 0, createdInvoice.ConfirmationsRequired)
+	assert.Equal(t, expectedAddress, createdInvoice.CryptoAddress)
+}
+
+func TestCreateInvoiceAddressPool(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("Should Derive Past The BIP44 Gap Without A Pool Config", func(t *testing.T) {
+		// Given
+		expectedAddress := uuid.NewString()
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		_, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: expectedAddress, Coin: db.CoinTypeXMR, IsOccupied: true, UserID: data.userId})
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// Occupied and never funded
+		for i := 0; i < 25; i++ {
+			if _, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeXMR, IsOccupied: true, UserID: userId}); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		// When
+		createdInvoice, err := p.createInvoice(ctx, &dto.NewInvoiceRequest{
+			UserId:  util.PgUUIDToString(userId),
+			Coin:    db.CoinTypeXMR,
+			Amount:  atomicUnitsOrFatal("1", db.CoinTypeXMR),
+			Timeout: 600,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedAddress, createdInvoice.CryptoAddress)
+	})
+
+	t.Run("Should Take The Free Address Of The Chain For A Token Invoice", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.MainnetETH, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeETH)
+		_, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return new(big.Int), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, errors.New("no address is expected to be derived")
+			},
+		)
+		defer close(ctx)
+		p.supportedTokens = map[db.CoinType]bool{db.CoinTypeUSDTERC20: true}
+
+		q := db.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		freeAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeETH, IsOccupied: false, UserID: userId})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// When
+		createdInvoice, err := p.createInvoice(ctx, &dto.NewInvoiceRequest{
+			UserId:  util.PgUUIDToString(userId),
+			Coin:    db.CoinTypeUSDTERC20,
+			Amount:  atomicUnitsOrFatal("1", db.CoinTypeUSDTERC20),
+			Timeout: 600,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, db.CoinTypeUSDTERC20, createdInvoice.Coin)
+		assert.Equal(t, freeAddr.Address, createdInvoice.CryptoAddress)
+	})
+}
+
+func TestHandleInvoicePbReq(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	invCn, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return new(big.Int), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+
+	q := db.New(p.dbConnPool)
+	qTest := db_test.New(p.dbConnPool)
+	userId, _, _ := createUserWithXmrData(ctx, q)
+
+	req := &dto.NewInvoiceRequest{
+		UserId:        util.PgUUIDToString(userId),
+		Coin:          db.CoinTypeXMR,
+		Amount:        atomicUnitsOrFatal("123", db.CoinTypeXMR),
+		Timeout:       600,
+		Confirmations: 0,
+	}
+
+	// When
+	invoice, err := p.handleInvoicePbReq(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+
+	expectedInvoice := getInvoiceOrFatal(ctx, qTest, &invoice.ID)
+
+	assert.Equal(t, expectedInvoice, *invoice)
+
+	pendingInvoice, ok := p.pendingInvoices.Load(expectedInvoice.CryptoAddress)
+	assert.True(t, ok)
 	assert.Equal(t, expectedInvoice, *pendingInvoice.invoice.Load())
 
 	invoiceFromCn := test.GetValueFromCnOrLogFatalWithTimeout(invCn, util.SEND_TIMEOUT, "Timeout expired")
 	assert.Equal(t, expectedInvoice, invoiceFromCn)
 }
 
+func TestHandleNewInvoiceIdempotency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	_, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return new(big.Int), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+				Address:    uuid.NewString(),
+				Coin:       db.CoinTypeXMR,
+				IsOccupied: true,
+				UserID:     data.userId,
+			})
+		},
+	)
+	defer close(ctx)
+
+	pp := &PaymentProcessor{
+		ctx:              ctx,
+		log:              &zerolog.Logger{},
+		dbConnPool:       p.dbConnPool,
+		cryptoProcessors: map[db.CoinType]cryptoProcessor{db.CoinTypeXMR: p},
+		rateProvider:     rate.NewStaticRateProvider(map[db.CoinType]map[string]float64{db.CoinTypeXMR: {"USD": 150}}),
+	}
+
+	q := db.New(p.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	newReq := func(fiatAmount float64) *dto.NewInvoiceRequest {
+		return &dto.NewInvoiceRequest{
+			UserId:         util.PgUUIDToString(userId),
+			Coin:           db.CoinTypeXMR,
+			FiatAmount:     fiatAmount,
+			FiatCurrency:   "USD",
+			Timeout:        600,
+			IdempotencyKey: "order-42",
+		}
+	}
+
+	invoice, err := pp.HandleNewInvoice(newReq(300))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	t.Run("Should Return The Original Invoice On Retry", func(t *testing.T) {
+		// The rate moves between the calls.
+		pp.rateProvider = rate.NewStaticRateProvider(map[db.CoinType]map[string]float64{db.CoinTypeXMR: {"USD": 160}})
+
+		retried, err := pp.HandleNewInvoice(newReq(300))
+		assert.NoError(t, err)
+		assert.Equal(t, invoice.ID, retried.ID)
+		assert.Equal(t, invoice.CryptoAddress, retried.CryptoAddress)
+		assert.Equal(t, formatPgAmount(invoice.RequiredAmount, invoice.Coin), formatPgAmount(retried.RequiredAmount, retried.Coin))
+
+		invoices, err := q.FindInvoicesByFilter(ctx, db.FindInvoicesByFilterParams{UserID: userId, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 1)
+	})
+
+	t.Run("Should Return IdempotencyKeyReusedErr", func(t *testing.T) {
+		_, err := pp.HandleNewInvoice(newReq(301))
+		assert.ErrorIs(t, err, IdempotencyKeyReusedErr)
+	})
+}
+
+func TestHandleNewMultiCoinInvoice(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	newAddressHandler := func(coin db.CoinType) func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+		return func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+				Address:    uuid.NewString(),
+				Coin:       coin,
+				IsOccupied: true,
+				UserID:     data.userId,
+			})
+		}
+	}
+	verifyTxHandler := func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+		return new(big.Int), nil
+	}
+
+	xmrDaemon := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	xmrDaemon.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	xmrDaemon.On("GetCoinType").Return(db.CoinTypeXMR)
+	invoiceCn, xmr, _, close := createNewTestBaseCryptoProcessor(xmrDaemon, verifyTxHandler, newAddressHandler(db.CoinTypeXMR))
+	defer close(ctx)
+
+	btcDaemon := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	btcDaemon.On("GetNetworkType").Return(listener.TestnetBTC, error(nil))
+	btcDaemon.On("GetCoinType").Return(db.CoinTypeBTC)
+	btc, err := newBaseCryptoProcessor(&zerolog.Logger{}, xmr.dbConnPool, invoiceCn, btcDaemon, verifyTxHandler, newAddressHandler(db.CoinTypeBTC), nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pp := &PaymentProcessor{
+		ctx:              ctx,
+		log:              &zerolog.Logger{},
+		dbConnPool:       xmr.dbConnPool,
+		cryptoProcessors: map[db.CoinType]cryptoProcessor{db.CoinTypeXMR: xmr, db.CoinTypeBTC: btc},
+	}
+
+	q := db.New(xmr.dbConnPool)
+	qTest := db_test.New(xmr.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	newReq := func() *dto.NewInvoiceRequest {
+		return &dto.NewInvoiceRequest{
+			UserId:         util.PgUUIDToString(userId),
+			Timeout:        600,
+			IdempotencyKey: "order-42",
+			CoinOptions:    []dto.CoinOption{{Coin: db.CoinTypeXMR, Amount: atomicUnitsOrFatal("1", db.CoinTypeXMR)}, {Coin: db.CoinTypeBTC, Amount: atomicUnitsOrFatal("0.01", db.CoinTypeBTC)}},
+		}
+	}
+
+	invoices, err := pp.HandleNewMultiCoinInvoice(newReq())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	t.Run("Should Create An Invoice Per Coin Option", func(t *testing.T) {
+		assert.Len(t, invoices, 2)
+		assert.Equal(t, db.CoinTypeXMR, invoices[0].Coin)
+		assert.Equal(t, "1", formatPgAmount(invoices[0].RequiredAmount, invoices[0].Coin))
+		assert.Equal(t, db.CoinTypeBTC, invoices[1].Coin)
+		assert.Equal(t, "0.01", formatPgAmount(invoices[1].RequiredAmount, invoices[1].Coin))
+
+		assert.True(t, invoices[0].GroupID.Valid)
+		assert.Equal(t, invoices[0].GroupID, invoices[1].GroupID)
+		assert.Equal(t, "order-42", invoices[0].IdempotencyKey.String)
+		assert.False(t, invoices[1].IdempotencyKey.Valid)
+	})
+
+	t.Run("Should Return The Original Group On Retry", func(t *testing.T) {
+		retried, err := pp.HandleNewMultiCoinInvoice(newReq())
+		assert.NoError(t, err)
+		assert.Len(t, retried, 2)
+		assert.Equal(t, invoices[0].ID, retried[0].ID)
+		assert.Equal(t, invoices[1].ID, retried[1].ID)
+	})
+
+	t.Run("Should Cancel The Pending Siblings Once One Is Paid", func(t *testing.T) {
+		paidInvoice, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{
+			ID:           invoices[1].ID,
+			ActualAmount: pgAmountOrFatal("0.01", db.CoinTypeBTC),
+			TxID:         pgtype.Text{String: uuid.NewString(), Valid: true},
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pp.cancelInvoiceGroupSiblings(paidInvoice)
+
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, getInvoiceOrFatal(ctx, qTest, &invoices[0].ID).Status)
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, getInvoiceOrFatal(ctx, qTest, &invoices[1].ID).Status)
+
+		_, ok := xmr.pendingInvoices.Load(invoices[0].CryptoAddress)
+		assert.False(t, ok)
+	})
+}
+
 func TestLoad(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -595,8 +1741,8 @@ func TestLoad(t *testing.T) {
 	d.On("GetTransactionPool").Return([]string{}, error(nil)).Maybe()
 	_, p, _, close := createNewTestBaseCryptoProcessor(
 		d,
-		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-			return 0, nil
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return new(big.Int), nil
 		},
 		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 			return db.CryptoAddress{}, nil
@@ -2,19 +2,20 @@ package processor
 
 import (
 	"context"
+	"errors"
+	"math/big"
 	"unsafe"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
 	"github.com/chekist32/goipay/internal/listener"
-	"github.com/chekist32/goipay/internal/util"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
 
-func verifyBNBTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.BNBTx]) (float64, error) {
+func verifyBNBTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.BNBTx]) (*big.Int, error) {
 	return verifyETHBasedTxHandler(ctx, q, (*verifyTxHandlerData[listener.ETHTx])(unsafe.Pointer(data)))
 }
 
@@ -42,8 +43,8 @@ func generateNextBNBAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	addr, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: crypto.PubkeyToAddress(*pubKey.ToECDSA()).Hex(), Coin: db.CoinTypeBNB, IsOccupied: true, UserID: data.userId})
-	if err != nil {
+	addr, err = createCryptoAddressHelper(ctx, q, db.CreateCryptoAddressParams{Address: crypto.PubkeyToAddress(*pubKey.ToECDSA()).Hex(), Coin: db.CoinTypeBNB, IsOccupied: true, UserID: data.userId, DerivationPath: derivationPath(i.LastMajorIndex, i.LastMinorIndex)})
+	if err != nil && !errors.Is(err, RetiredCryptoAddressOccupiedErr) {
 		return addr, err
 	}
 
@@ -51,15 +52,24 @@ func generateNextBNBAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	return addr, nil
+	return addr, err
 }
 
 type bnbProcessor struct {
 	baseCryptoProcessor[listener.BNBTx, listener.BNBBlock]
 }
 
-func newBnbProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*bnbProcessor, error) {
-	client, err := ethclient.Dial(c.Bnb.Url)
+func newBnbProcessor(ctx context.Context, log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.ProcessorConfig) (*bnbProcessor, error) {
+	client, err := ethclient.Dial(c.Daemons.Bnb.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := registerETHCompatibleTokens(ctx, dbConnPool, db.CoinTypeBNB, chainId.Uint64(), c.Daemons.Bnb.Tokens)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +81,8 @@ func newBnbProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn ch
 		listener.NewSharedBNBDaemonRpcClient(client),
 		verifyBNBTxHandler,
 		generateNextBNBAddressHandler,
-		util.GetMapKeys(tokenDataETHCompatible[db.CoinTypeBNB]),
+		tokens,
+		c.LatePaymentGraceWindow,
 	)
 	if err != nil {
 		return nil, err
@@ -2,12 +2,17 @@ package processor
 
 import (
 	"context"
+	"errors"
+	"math/big"
 	"net/url"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
 	"github.com/chekist32/goipay/internal/listener"
@@ -16,21 +21,49 @@ import (
 	"github.com/rs/zerolog"
 )
 
+var (
+	invalidAddressTypeErr error = errors.New("invalid address type")
+)
+
 type btcProcessor struct {
 	baseCryptoProcessor[listener.BTCTx, listener.BTCBlock]
 }
 
-func verifyBTCTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.BTCTx]) (float64, error) {
-	var amount float64 = 0
+func verifyBTCTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.BTCTx]) (*big.Int, error) {
+	var amount btcutil.Amount = 0
 	for i := 0; i < len(data.tx.Vout); i++ {
 		txOut := &data.tx.Vout[i]
 
 		if txOut.ScriptPubKey.Address == data.invoice.CryptoAddress {
-			amount += data.tx.Vout[i].Value
+			// The daemon reports the values in BTC, they are rounded to the exact satoshis here.
+			am, err := btcutil.NewAmount(txOut.Value)
+			if err != nil {
+				return nil, err
+			}
+			amount += am
 		}
 	}
 
-	return amount, nil
+	return big.NewInt(int64(amount)), nil
+}
+
+func encodeBTCAddress(pubKey *btcec.PublicKey, addressType db.AddressType, net *chaincfg.Params) (btcutil.Address, error) {
+	pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
+
+	switch addressType {
+	case db.AddressTypeP2PKH:
+		return btcutil.NewAddressPubKeyHash(pubKeyHash, net)
+	case db.AddressTypeP2SHP2WPKH:
+		// The redeem script is the P2WPKH witness program, i.e. OP_0 <20 bytes pubkey hash>.
+		return btcutil.NewAddressScriptHash(append([]byte{txscript.OP_0, txscript.OP_DATA_20}, pubKeyHash...), net)
+	case db.AddressTypeP2WPKH:
+		return btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, net)
+	case db.AddressTypeP2TR:
+		// BIP86, i.e. the output key commits to no script.
+		return btcutil.NewAddressTaproot(schnorr.SerializePubKey(txscript.ComputeTaprootKeyNoScript(pubKey)), net)
+	}
+
+	return nil, invalidAddressTypeErr
 }
 
 func generateNextBTCAddressHandler(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
@@ -64,12 +97,12 @@ func generateNextBTCAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	mPubStr, err := q.FindKeysAndLockBTCCryptoDataById(ctx, cd.BtcID)
+	keys, err := q.FindKeysAndLockBTCCryptoDataById(ctx, cd.BtcID)
 	if err != nil {
 		return addr, err
 	}
 
-	mPub, err := hdkeychain.NewKeyFromString(mPubStr)
+	mPub, err := hdkeychain.NewKeyFromString(keys.MasterPubKey)
 	if err != nil {
 		return addr, err
 	}
@@ -94,14 +127,13 @@ func generateNextBTCAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
-	newAddr, err := btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, net)
+	newAddr, err := encodeBTCAddress(pubKey, keys.AddressType, net)
 	if err != nil {
 		return addr, err
 	}
 
-	addr, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: newAddr.EncodeAddress(), Coin: db.CoinTypeBTC, IsOccupied: true, UserID: data.userId})
-	if err != nil {
+	addr, err = createCryptoAddressHelper(ctx, q, db.CreateCryptoAddressParams{Address: newAddr.EncodeAddress(), Coin: db.CoinTypeBTC, IsOccupied: true, UserID: data.userId, DerivationPath: derivationPath(indices.LastMajorIndex, indices.LastMinorIndex)})
+	if err != nil && !errors.Is(err, RetiredCryptoAddressOccupiedErr) {
 		return addr, err
 	}
 
@@ -109,19 +141,19 @@ func generateNextBTCAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	return addr, nil
+	return addr, err
 }
 
-func newBtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*btcProcessor, error) {
-	u, err := url.Parse(c.Btc.Url)
+func newBtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.ProcessorConfig) (*btcProcessor, error) {
+	u, err := url.Parse(c.Daemons.Btc.Url)
 	if err != nil {
 		return nil, err
 	}
 
 	client, err := rpcclient.New(&rpcclient.ConnConfig{
 		Host:         u.Host + u.RequestURI(),
-		User:         c.Btc.User,
-		Pass:         c.Btc.Pass,
+		User:         c.Daemons.Btc.User,
+		Pass:         c.Daemons.Btc.Pass,
 		DisableTLS:   u.Scheme != "https",
 		HTTPPostMode: true,
 	}, nil)
@@ -137,6 +169,7 @@ func newBtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn ch
 		verifyBTCTxHandler,
 		generateNextBTCAddressHandler,
 		nil,
+		c.LatePaymentGraceWindow,
 	)
 	if err != nil {
 		return nil, err
@@ -11,6 +11,7 @@ import (
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
 	"github.com/chekist32/goipay/test"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -130,7 +131,7 @@ func TestVerifyBTCTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeBTC,
 				CryptoAddress:         "bc1q8e8qkxqtgfypwwnh6zf5msx82yw2p4l9sy26ey",
-				RequiredAmount:        0.00480740,
+				RequiredAmount:        pgAmountOrFatal("0.0048074", db.CoinTypeBTC),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -150,7 +151,7 @@ func TestVerifyBTCTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, expectedInvoice.RequiredAmount, amount)
+			assert.Equal(t, util.PgNumericToBigInt(expectedInvoice.RequiredAmount), amount)
 		})
 	})
 
@@ -165,7 +166,7 @@ func TestVerifyBTCTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeBTC,
 				CryptoAddress:         "bc1q8e8qkxqtgfypwwnh6zf5msx82yw2p4l9sy26ey",
-				RequiredAmount:        0.00480740,
+				RequiredAmount:        pgAmountOrFatal("0.0048074", db.CoinTypeBTC),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -185,7 +186,7 @@ func TestVerifyBTCTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, float64(0), amount)
+			assert.Equal(t, 0, amount.Sign())
 		})
 	})
 }
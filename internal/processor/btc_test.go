@@ -0,0 +1,86 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeBTCAddress(t *testing.T) {
+	t.Parallel()
+
+	// The test vectors of BIP44, BIP49, BIP84 and BIP86 (the "abandon ... about" mnemonic), m/purpose'/0'/0'/0/0.
+	cases := []struct {
+		name        string
+		accountKey  string
+		addressType db.AddressType
+		expected    string
+	}{
+		{
+			name:        "Should Encode P2PKH Address",
+			accountKey:  "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj",
+			addressType: db.AddressTypeP2PKH,
+			expected:    "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA",
+		},
+		{
+			name:        "Should Encode P2SH-P2WPKH Address",
+			accountKey:  "ypub6Ww3ibxVfGzLrAH1PNcjyAWenMTbbAosGNB6VvmSEgytSER9azLDWCxoJwW7Ke7icmizBMXrzBx9979FfaHxHcrArf3zbeJJJUZPf663zsP",
+			addressType: db.AddressTypeP2SHP2WPKH,
+			expected:    "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf",
+		},
+		{
+			name:        "Should Encode P2WPKH Address",
+			accountKey:  "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs",
+			addressType: db.AddressTypeP2WPKH,
+			expected:    "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		},
+		{
+			name:        "Should Encode P2TR Address",
+			accountKey:  "xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ",
+			addressType: db.AddressTypeP2TR,
+			expected:    "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mPub, err := hdkeychain.NewKeyFromString(c.accountKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			extKey, err := mPub.Derive(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			extKey, err = extKey.Derive(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pubKey, err := extKey.ECPubKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			addr, err := encodeBTCAddress(pubKey, c.addressType, &chaincfg.MainNetParams)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, addr.EncodeAddress())
+		})
+	}
+
+	t.Run("Should Return Error (invalid address type)", func(t *testing.T) {
+		mPub, err := hdkeychain.NewKeyFromString(cases[0].accountKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKey, err := mPub.ECPubKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = encodeBTCAddress(pubKey, "P2WSH", &chaincfg.MainNetParams)
+		assert.ErrorIs(t, err, invalidAddressTypeErr)
+	})
+}
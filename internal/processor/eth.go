@@ -2,13 +2,14 @@ package processor
 
 import (
 	"context"
+	"errors"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
 	"github.com/chekist32/goipay/internal/listener"
-	"github.com/chekist32/goipay/internal/util"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
@@ -17,6 +18,16 @@ type ethProcessor struct {
 	baseCryptoProcessor[listener.ETHTx, listener.ETHBlock]
 }
 
+// createNextETHAddressHelper derives the next address of the master public key and occupies it for the user.
+func createNextETHAddressHelper(ctx context.Context, q *db.Queries, userId pgtype.UUID, coin db.CoinType, indices *db.FindIndicesAndLockETHCryptoDataByIdRow, masterPubKey string) (db.CryptoAddress, error) {
+	pubKey, err := deriveNextETHBasedECPubKeyHelper(indices, masterPubKey)
+	if err != nil {
+		return db.CryptoAddress{}, err
+	}
+
+	return createCryptoAddressHelper(ctx, q, db.CreateCryptoAddressParams{Address: crypto.PubkeyToAddress(*pubKey.ToECDSA()).Hex(), Coin: coin, IsOccupied: true, UserID: userId, DerivationPath: derivationPath(indices.LastMajorIndex, indices.LastMinorIndex)})
+}
+
 func generateNextETHAddressHandler(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 	var addr db.CryptoAddress
 
@@ -35,13 +46,8 @@ func generateNextETHAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	pubKey, err := deriveNextETHBasedECPubKeyHelper(&indices, mPubStr)
-	if err != nil {
-		return addr, err
-	}
-
-	addr, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: crypto.PubkeyToAddress(*pubKey.ToECDSA()).Hex(), Coin: db.CoinTypeETH, IsOccupied: true, UserID: data.userId})
-	if err != nil {
+	addr, err = createNextETHAddressHelper(ctx, q, data.userId, db.CoinTypeETH, &indices, mPubStr)
+	if err != nil && !errors.Is(err, RetiredCryptoAddressOccupiedErr) {
 		return addr, err
 	}
 
@@ -49,11 +55,20 @@ func generateNextETHAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	return addr, nil
+	return addr, err
 }
 
-func newEthProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*ethProcessor, error) {
-	client, err := ethclient.Dial(c.Eth.Url)
+func newEthProcessor(ctx context.Context, log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.ProcessorConfig) (*ethProcessor, error) {
+	client, err := ethclient.Dial(c.Daemons.Eth.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := registerETHCompatibleTokens(ctx, dbConnPool, db.CoinTypeETH, chainId.Uint64(), c.Daemons.Eth.Tokens)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +80,8 @@ func newEthProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn ch
 		listener.NewSharedETHDaemonRpcClient(client),
 		verifyETHBasedTxHandler,
 		generateNextETHAddressHandler,
-		util.GetMapKeys(tokenDataETHCompatible[db.CoinTypeETH]),
+		tokens,
+		c.LatePaymentGraceWindow,
 	)
 	if err != nil {
 		return nil, err
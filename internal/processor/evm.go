@@ -0,0 +1,101 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+// evmProcessor serves an EVM chain declared in the config, e.g. Polygon, Arbitrum or Base.
+type evmProcessor struct {
+	baseCryptoProcessor[listener.ETHTx, listener.ETHBlock]
+
+	chainId uint64
+}
+
+func (p *evmProcessor) paymentUri(invoice *db.Invoice, label string) (string, error) {
+	return buildEVMPaymentUri(p.coin, p.chainId, invoice)
+}
+
+func generateNextEVMAddressHandler(chain db.CoinType) func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+	return func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+		var addr db.CryptoAddress
+
+		indices, err := q.FindIndicesAndLockEVMCryptoDataByUserIdAndChain(ctx, db.FindIndicesAndLockEVMCryptoDataByUserIdAndChainParams{UserID: data.userId, Chain: string(chain)})
+		if err != nil {
+			return addr, err
+		}
+
+		mPubStr, err := q.FindKeysAndLockEVMCryptoDataByUserIdAndChain(ctx, db.FindKeysAndLockEVMCryptoDataByUserIdAndChainParams{UserID: data.userId, Chain: string(chain)})
+		if err != nil {
+			return addr, err
+		}
+
+		i := db.FindIndicesAndLockETHCryptoDataByIdRow(indices)
+		addr, err = createNextETHAddressHelper(ctx, q, data.userId, chain, &i, mPubStr)
+		if err != nil && !errors.Is(err, RetiredCryptoAddressOccupiedErr) {
+			return addr, err
+		}
+
+		if _, err := q.UpdateIndicesEVMCryptoDataByUserIdAndChain(ctx, db.UpdateIndicesEVMCryptoDataByUserIdAndChainParams{UserID: data.userId, Chain: string(chain), LastMajorIndex: i.LastMajorIndex, LastMinorIndex: i.LastMinorIndex}); err != nil {
+			return addr, err
+		}
+
+		return addr, err
+	}
+}
+
+// registerEVMChain makes the native coin of the chain known to the app and adds it to coin_type, so a chain needs no migration.
+func registerEVMChain(ctx context.Context, dbConnPool *pgxpool.Pool, c *dto.EVMChainConfig) error {
+	chain := db.CoinType(c.Name)
+
+	if err := util.RegisterEVMChain(chain, c.Decimals); err != nil {
+		return fmt.Errorf("%w: %v", err, c.Name)
+	}
+	if err := addCoinTypeValue(ctx, dbConnPool, chain); err != nil {
+		return err
+	}
+
+	return db.New(dbConnPool).CreateCryptoCacheIfNotExists(ctx, chain)
+}
+
+func newEvmProcessor(ctx context.Context, log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.ProcessorConfig, chainConfig *dto.EVMChainConfig) (*evmProcessor, error) {
+	if err := registerEVMChain(ctx, dbConnPool, chainConfig); err != nil {
+		return nil, err
+	}
+
+	chain := db.CoinType(chainConfig.Name)
+	tokens, err := registerETHCompatibleTokens(ctx, dbConnPool, chain, chainConfig.ChainId, chainConfig.Tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(chainConfig.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := newBaseCryptoProcessor(
+		log,
+		dbConnPool,
+		invoiceCn,
+		listener.NewSharedEVMDaemonRpcClient(client, chain, chainConfig.ChainId),
+		verifyETHBasedTxHandler,
+		generateNextEVMAddressHandler(chain),
+		tokens,
+		c.LatePaymentGraceWindow,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &evmProcessor{baseCryptoProcessor: *base, chainId: chainConfig.ChainId}, nil
+}
@@ -0,0 +1,27 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildEVMPaymentUri(t *testing.T) {
+	chain := db.CoinType("TESTARBITRUM")
+	tokenDataETHCompatible.Store(chain, map[db.CoinType]tokenData{"USDC_TESTARBITRUM": {contractAddress: "0xaf88d065e77c8cC2239327C5EDb3A432268e5831"}})
+	defer tokenDataETHCompatible.Delete(chain)
+
+	address := "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"
+
+	uri, err := buildEVMPaymentUri(chain, 42161, &db.Invoice{Coin: chain, CryptoAddress: address, RequiredAmount: pgAmountOrFatal("1.5", db.CoinTypeETH)})
+	assert.NoError(t, err)
+	assert.Equal(t, "ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359@42161?value=1500000000000000000", uri)
+
+	uri, err = buildEVMPaymentUri(chain, 42161, &db.Invoice{Coin: "USDC_TESTARBITRUM", CryptoAddress: address, RequiredAmount: pgAmountOrFatal("12.34", db.CoinTypeUSDCERC20)})
+	assert.NoError(t, err)
+	assert.Equal(t, "ethereum:0xaf88d065e77c8cC2239327C5EDb3A432268e5831@42161/transfer?address=0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359&uint256=12340000", uri)
+
+	_, err = buildEVMPaymentUri(chain, 42161, &db.Invoice{Coin: db.CoinTypeUSDCERC20, CryptoAddress: address})
+	assert.ErrorIs(t, err, paymentUriUnsupportedCoinErr)
+}
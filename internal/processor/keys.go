@@ -0,0 +1,91 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/chekist32/go-monero/utils"
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+)
+
+// The depth of m/purpose'/coin_type'/account', the addresses are derived from its 0/i children.
+const accountKeyDepth uint8 = 3
+
+var (
+	PrivateMasterKeyErr    error = errors.New("extended key is private")
+	MasterPubKeyDepthErr   error = errors.New("extended public key is not of the account level")
+	MasterPubKeyNetworkErr error = errors.New("extended public key is not of the daemon network")
+	XMRPrivViewKeyErr      error = errors.New("XMR private view key is not a reduced scalar")
+	XMRPubSpendKeyErr      error = errors.New("XMR public spend key is not a point of the curve")
+)
+
+func isTestNetwork(network listener.NetworkType) bool {
+	switch network {
+	case listener.TestnetBTC, listener.RegtestBTC, listener.SignetBTC,
+		listener.TestnetLTC, listener.RegtestLTC, listener.SignetLTC:
+		return true
+	default:
+		return false
+	}
+}
+
+// findNetworkType returns false if no processor of the coin is set up.
+func (p *PaymentProcessor) findNetworkType(coin db.CoinType) (listener.NetworkType, bool) {
+	cp, ok := p.cryptoProcessors[coin]
+	if !ok {
+		return 0, false
+	}
+
+	return cp.networkType(), true
+}
+
+// ValidateMasterPubKey checks that the key is an account-level extended public key and, for BTC and LTC, that its SLIP-132 version is of the daemon network.
+// The keys stored before the check was introduced are left as is, only the updated ones are validated.
+func (p *PaymentProcessor) ValidateMasterPubKey(coin db.CoinType, masterPubKey string) error {
+	key, err := hdkeychain.NewKeyFromString(masterPubKey)
+	if err != nil {
+		return err
+	}
+	if key.IsPrivate() {
+		return PrivateMasterKeyErr
+	}
+	if key.Depth() != accountKeyDepth {
+		return MasterPubKeyDepthErr
+	}
+
+	// The addresses of the other coins don't depend on the version of the key.
+	if coin != db.CoinTypeBTC && coin != db.CoinTypeLTC {
+		return nil
+	}
+
+	network, ok := p.findNetworkType(coin)
+	if !ok {
+		return nil
+	}
+
+	testnet, err := util.IsTestnetExtendedKey(masterPubKey)
+	if err != nil {
+		return err
+	}
+	if testnet != isTestNetwork(network) {
+		return MasterPubKeyNetworkErr
+	}
+
+	return nil
+}
+
+// ValidateXMRKeys checks that the private view key is a reduced scalar and the public spend key is a point of the curve.
+// Neither key tells the network, so it's up to the merchant to provide the keys of the daemon network.
+func (p *PaymentProcessor) ValidateXMRKeys(privViewKey string, pubSpendKey string) error {
+	if _, err := utils.NewPrivateKey(privViewKey); err != nil {
+		return fmt.Errorf("%w: %v", XMRPrivViewKeyErr, err)
+	}
+	if _, err := utils.NewPublicKey(pubSpendKey); err != nil {
+		return fmt.Errorf("%w: %v", XMRPubSpendKeyErr, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func newKeysTestPaymentProcessor(coin db.CoinType, network listener.NetworkType) *PaymentProcessor {
+	cp := &btcProcessor{baseCryptoProcessor: baseCryptoProcessor[listener.BTCTx, listener.BTCBlock]{coin: coin, network: network}}
+	return &PaymentProcessor{cryptoProcessors: map[db.CoinType]cryptoProcessor{coin: cp}}
+}
+
+func TestValidateMasterPubKey(t *testing.T) {
+	t.Parallel()
+
+	const (
+		xpub string = "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj"
+		vpub string = "vpub5Y9M3sStNdseZMUDKh9bUr2B9XaorxYanhLRwUkXjUwwUjXf7k5xGKBzCxgY8MdFk9NQ6umF4BetVTzK13cn4HPhu25mz9hcZPpkNEQ4Gjp"
+		xprv string = "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"
+		// m/84'
+		shallowZpub string = "zpub6o5L7tQbC4zavTL1Lzq1eg5qev4WQMXNWMGoruoHSX8YRss8V4U1k4UUae8abXpVxNh9eBHTLBGBjvuCSRtfVtAmf4LRBtsNxQX4gpj56Dc"
+		// The multisig version, which isn't supported
+		ypubMultisig string = "Ypub6hYE67C5Pe4TXpCwmRySwBeVPE4QuBCfrJYmCvhjFGuzGDYFe7xvDd2L5HWmgWovCXnoDvZ3KP73GVSmTN1mbUZBLWHT9Hd5GJHZ7uXrYU8"
+	)
+
+	mainnet := newKeysTestPaymentProcessor(db.CoinTypeBTC, listener.MainnetBTC)
+	regtest := newKeysTestPaymentProcessor(db.CoinTypeBTC, listener.RegtestBTC)
+
+	t.Run("Should Accept Key Of Daemon Network", func(t *testing.T) {
+		assert.NoError(t, mainnet.ValidateMasterPubKey(db.CoinTypeBTC, xpub))
+		assert.NoError(t, regtest.ValidateMasterPubKey(db.CoinTypeBTC, vpub))
+	})
+
+	t.Run("Should Reject Private Key", func(t *testing.T) {
+		assert.ErrorIs(t, mainnet.ValidateMasterPubKey(db.CoinTypeBTC, xprv), PrivateMasterKeyErr)
+		assert.ErrorIs(t, mainnet.ValidateMasterPubKey(db.CoinTypeETH, xprv), PrivateMasterKeyErr)
+	})
+
+	t.Run("Should Reject Key Not Of Account Level", func(t *testing.T) {
+		assert.ErrorIs(t, mainnet.ValidateMasterPubKey(db.CoinTypeBTC, shallowZpub), MasterPubKeyDepthErr)
+	})
+
+	t.Run("Should Reject Key Of Other Network", func(t *testing.T) {
+		assert.ErrorIs(t, mainnet.ValidateMasterPubKey(db.CoinTypeBTC, vpub), MasterPubKeyNetworkErr)
+		assert.ErrorIs(t, regtest.ValidateMasterPubKey(db.CoinTypeBTC, xpub), MasterPubKeyNetworkErr)
+	})
+
+	t.Run("Should Reject Key Of Unknown Version", func(t *testing.T) {
+		assert.ErrorIs(t, mainnet.ValidateMasterPubKey(db.CoinTypeBTC, ypubMultisig), util.UnknownExtendedKeyVersionErr)
+		assert.NoError(t, mainnet.ValidateMasterPubKey(db.CoinTypeETH, ypubMultisig))
+	})
+
+	t.Run("Should Skip Network Check Without Processor", func(t *testing.T) {
+		assert.NoError(t, mainnet.ValidateMasterPubKey(db.CoinTypeLTC, vpub))
+		assert.NoError(t, mainnet.ValidateMasterPubKey(db.CoinTypeETH, vpub))
+	})
+}
+
+func TestValidateXMRKeys(t *testing.T) {
+	t.Parallel()
+
+	const (
+		privViewKey string = "8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009"
+		pubSpendKey string = "38e9908d33d034de0ba1281aa7afe3907b795cea14852b3d8fe276e8931cb130"
+		// The order of the group, so not reduced
+		unreducedPrivViewKey string = "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010"
+		// There's no point with y = 2
+		offCurvePubSpendKey string = "0200000000000000000000000000000000000000000000000000000000000000"
+	)
+
+	p := newKeysTestPaymentProcessor(db.CoinTypeXMR, listener.MainnetXMR)
+
+	t.Run("Should Accept Valid Keys", func(t *testing.T) {
+		assert.NoError(t, p.ValidateXMRKeys(privViewKey, pubSpendKey))
+	})
+
+	t.Run("Should Reject Malformed Private View Key", func(t *testing.T) {
+		assert.ErrorIs(t, p.ValidateXMRKeys(unreducedPrivViewKey, pubSpendKey), XMRPrivViewKeyErr)
+		assert.ErrorIs(t, p.ValidateXMRKeys(privViewKey[:62], pubSpendKey), XMRPrivViewKeyErr)
+		assert.ErrorIs(t, p.ValidateXMRKeys("zz", pubSpendKey), XMRPrivViewKeyErr)
+	})
+
+	t.Run("Should Reject Malformed Public Spend Key", func(t *testing.T) {
+		assert.ErrorIs(t, p.ValidateXMRKeys(privViewKey, offCurvePubSpendKey), XMRPubSpendKeyErr)
+		assert.ErrorIs(t, p.ValidateXMRKeys(privViewKey, pubSpendKey[:62]), XMRPubSpendKeyErr)
+		assert.ErrorIs(t, p.ValidateXMRKeys(privViewKey, "zz"), XMRPubSpendKeyErr)
+	})
+}
@@ -2,6 +2,8 @@ package processor
 
 import (
 	"context"
+	"errors"
+	"math/big"
 	"net/url"
 
 	"github.com/btcsuite/btcd/rpcclient"
@@ -10,10 +12,13 @@ import (
 	"github.com/chekist32/goipay/internal/listener"
 	"github.com/chekist32/goipay/internal/util"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+	"github.com/ltcsuite/ltcd/btcec/v2/schnorr"
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
 	ltcrpc "github.com/ltcsuite/ltcd/rpcclient"
+	"github.com/ltcsuite/ltcd/txscript"
 	"github.com/rs/zerolog"
 )
 
@@ -21,18 +26,42 @@ type ltcProcessor struct {
 	baseCryptoProcessor[listener.LTCTx, listener.LTCBlock]
 }
 
-func verifyLTCTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.LTCTx]) (float64, error) {
-	var amount float64 = 0
+func verifyLTCTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.LTCTx]) (*big.Int, error) {
+	var amount ltcutil.Amount = 0
 	for i := 0; i < len(data.tx.Vout); i++ {
 		txOut := &data.tx.Vout[i]
 
 		if txOut.ScriptPubKey.Address == data.invoice.CryptoAddress ||
 			(len(txOut.ScriptPubKey.Addresses) == 1 && txOut.ScriptPubKey.Addresses[0] == data.invoice.CryptoAddress) {
-			amount += data.tx.Vout[i].Value
+			// The daemon reports the values in LTC, they are rounded to the exact litoshis here.
+			am, err := ltcutil.NewAmount(txOut.Value)
+			if err != nil {
+				return nil, err
+			}
+			amount += am
 		}
 	}
 
-	return amount, nil
+	return big.NewInt(int64(amount)), nil
+}
+
+func encodeLTCAddress(pubKey *btcec.PublicKey, addressType db.AddressType, net *chaincfg.Params) (ltcutil.Address, error) {
+	pubKeyHash := ltcutil.Hash160(pubKey.SerializeCompressed())
+
+	switch addressType {
+	case db.AddressTypeP2PKH:
+		return ltcutil.NewAddressPubKeyHash(pubKeyHash, net)
+	case db.AddressTypeP2SHP2WPKH:
+		// The redeem script is the P2WPKH witness program, i.e. OP_0 <20 bytes pubkey hash>.
+		return ltcutil.NewAddressScriptHash(append([]byte{txscript.OP_0, txscript.OP_DATA_20}, pubKeyHash...), net)
+	case db.AddressTypeP2WPKH:
+		return ltcutil.NewAddressWitnessPubKeyHash(pubKeyHash, net)
+	case db.AddressTypeP2TR:
+		// BIP86, i.e. the output key commits to no script.
+		return ltcutil.NewAddressTaproot(schnorr.SerializePubKey(txscript.ComputeTaprootKeyNoScript(pubKey)), net)
+	}
+
+	return nil, invalidAddressTypeErr
 }
 
 func generateNextLTCAddressHandler(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
@@ -66,12 +95,12 @@ func generateNextLTCAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	mPubStr, err := q.FindKeysAndLockLTCCryptoDataById(ctx, cd.LtcID)
+	keys, err := q.FindKeysAndLockLTCCryptoDataById(ctx, cd.LtcID)
 	if err != nil {
 		return addr, err
 	}
 
-	mPub, err := hdkeychain.NewKeyFromString(mPubStr)
+	mPub, err := hdkeychain.NewKeyFromString(keys.MasterPubKey)
 	if err != nil {
 		return addr, err
 	}
@@ -96,14 +125,13 @@ func generateNextLTCAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	pubKeyHash := ltcutil.Hash160(pubKey.SerializeCompressed())
-	newAddr, err := ltcutil.NewAddressWitnessPubKeyHash(pubKeyHash, net)
+	newAddr, err := encodeLTCAddress(pubKey, keys.AddressType, net)
 	if err != nil {
 		return addr, err
 	}
 
-	addr, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: newAddr.EncodeAddress(), Coin: db.CoinTypeLTC, IsOccupied: true, UserID: data.userId})
-	if err != nil {
+	addr, err = createCryptoAddressHelper(ctx, q, db.CreateCryptoAddressParams{Address: newAddr.EncodeAddress(), Coin: db.CoinTypeLTC, IsOccupied: true, UserID: data.userId, DerivationPath: derivationPath(indices.LastMajorIndex, indices.LastMinorIndex)})
+	if err != nil && !errors.Is(err, RetiredCryptoAddressOccupiedErr) {
 		return addr, err
 	}
 
@@ -111,19 +139,19 @@ func generateNextLTCAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	return addr, nil
+	return addr, err
 }
 
-func newLtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*ltcProcessor, error) {
-	u, err := url.Parse(c.Ltc.Url)
+func newLtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.ProcessorConfig) (*ltcProcessor, error) {
+	u, err := url.Parse(c.Daemons.Ltc.Url)
 	if err != nil {
 		return nil, err
 	}
 
 	conf := &rpcclient.ConnConfig{
 		Host:         u.Host + u.RequestURI(),
-		User:         c.Ltc.User,
-		Pass:         c.Ltc.Pass,
+		User:         c.Daemons.Ltc.User,
+		Pass:         c.Daemons.Ltc.Pass,
 		DisableTLS:   u.Scheme != "https",
 		HTTPPostMode: true,
 	}
@@ -150,6 +178,7 @@ func newLtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn ch
 		verifyLTCTxHandler,
 		generateNextLTCAddressHandler,
 		nil,
+		c.LatePaymentGraceWindow,
 	)
 	if err != nil {
 		return nil, err
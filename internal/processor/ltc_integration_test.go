@@ -11,6 +11,7 @@ import (
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
 	"github.com/chekist32/goipay/test"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -147,7 +148,7 @@ func TestVerifyLTCTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeLTC,
 				CryptoAddress:         "ltc1qa9fetyxs65t03w32vfyen4w2nph9uq9wr7pmg4",
-				RequiredAmount:        3.05398200,
+				RequiredAmount:        pgAmountOrFatal("3.053982", db.CoinTypeLTC),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -167,7 +168,7 @@ func TestVerifyLTCTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, expectedInvoice.RequiredAmount, amount)
+			assert.Equal(t, util.PgNumericToBigInt(expectedInvoice.RequiredAmount), amount)
 		})
 	})
 
@@ -182,7 +183,7 @@ func TestVerifyLTCTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeLTC,
 				CryptoAddress:         "ltc1qa9fetyxs65t03w32vfyen4w2nph9uq9wr7pmg4",
-				RequiredAmount:        3.05398200,
+				RequiredAmount:        pgAmountOrFatal("3.053982", db.CoinTypeLTC),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -202,7 +203,7 @@ func TestVerifyLTCTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, float64(0), amount)
+			assert.Equal(t, 0, amount.Sign())
 		})
 	})
 }
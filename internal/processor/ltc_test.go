@@ -0,0 +1,47 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeLTCAddress(t *testing.T) {
+	t.Parallel()
+
+	mPub, err := hdkeychain.NewKeyFromString("zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey, err := mPub.ECPubKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should Encode Address Of Address Type", func(t *testing.T) {
+		cases := []struct {
+			addressType db.AddressType
+			prefix      string
+		}{
+			{addressType: db.AddressTypeP2PKH, prefix: "L"},
+			{addressType: db.AddressTypeP2SHP2WPKH, prefix: "M"},
+			{addressType: db.AddressTypeP2WPKH, prefix: "ltc1q"},
+			{addressType: db.AddressTypeP2TR, prefix: "ltc1p"},
+		}
+
+		for i := 0; i < len(cases); i++ {
+			addr, err := encodeLTCAddress(pubKey, cases[i].addressType, &chaincfg.MainNetParams)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(addr.EncodeAddress(), cases[i].prefix), addr.EncodeAddress())
+		}
+	})
+
+	t.Run("Should Return Error (invalid address type)", func(t *testing.T) {
+		_, err := encodeLTCAddress(pubKey, "P2WSH", &chaincfg.MainNetParams)
+		assert.ErrorIs(t, err, invalidAddressTypeErr)
+	})
+}
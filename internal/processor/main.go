@@ -2,13 +2,24 @@ package processor
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/rate"
 	"github.com/chekist32/goipay/internal/util"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
@@ -19,8 +30,23 @@ const (
 
 var (
 	unimplementedError error = errors.New("coin is either unimplemented or not set up")
+
+	FiatInvoicesUnsupportedErr error = errors.New("exchange rate provider is not set up")
+	IdempotencyKeyReusedErr    error = errors.New("idempotency key has already been used with different parameters")
+)
+
+const (
+	uniqueViolationPgErrCode  string = "23505"
+	idempotencyKeyUniqueIndex string = "unique_user_id_idempotency_key"
 )
 
+// invoiceStreamSubscriber is dropped, closing its dropped channel, once it doesn't take an event within SEND_TIMEOUT.
+type invoiceStreamSubscriber struct {
+	cn       chan dto.InvoiceStreamEvent
+	dropped  chan struct{}
+	dropOnce *sync.Once
+}
+
 type PaymentProcessor struct {
 	dbConnPool *pgxpool.Pool
 
@@ -28,9 +54,48 @@ type PaymentProcessor struct {
 	log *zerolog.Logger
 
 	invoiceCn      chan db.Invoice
-	newInvoicesCns *util.SyncMapTypeSafe[string, chan db.Invoice]
+	newInvoicesCns *util.SyncMapTypeSafe[string, invoiceStreamSubscriber]
+
+	// Touched by the publisher goroutine only.
+	publishedSeq uint64
+	publishCn    chan struct{}
 
 	cryptoProcessors map[db.CoinType]cryptoProcessor
+
+	rateProvider rate.RateProvider
+}
+
+// percentOf rounds down, so the result never exceeds the given percentage of the amount.
+func percentOf(amount *big.Int, percent float64) *big.Int {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(percent, 'f', -1, 64))
+	if !ok {
+		return new(big.Int)
+	}
+	r.Mul(r, new(big.Rat).SetInt(amount))
+	r.Quo(r, big.NewRat(100, 1))
+
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+func (p *PaymentProcessor) convertFiatAmount(req *dto.NewInvoiceRequest) error {
+	if p.rateProvider == nil {
+		return FiatInvoicesUnsupportedErr
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, util.RATE_PROVIDER_TIMEOUT)
+	defer cancel()
+
+	r, err := p.rateProvider.GetRate(ctx, req.Coin, req.FiatCurrency)
+	if err != nil {
+		p.log.Err(err).Str("coin", string(req.Coin)).Str("currency", req.FiatCurrency).Msg("An error occurred while fetching the exchange rate.")
+		return err
+	}
+
+	req.ExchangeRate = r
+	req.Amount = util.FloatToAtomicUnits(util.RoundUpToDecimals(req.FiatAmount/r, int(min(util.CoinDecimals(req.Coin), util.FIAT_CONVERSION_MAX_DECIMALS))), req.Coin)
+	req.UnderpaymentTolerance = util.FloatToAtomicUnits(req.FiatUnderpaymentTolerance/r, req.Coin)
+
+	return nil
 }
 
 func (p *PaymentProcessor) loadPersistedPendingInvoices() error {
@@ -52,9 +117,24 @@ func (p *PaymentProcessor) loadPersistedPendingInvoices() error {
 		return err
 	}
 
-	tx.Commit(p.ctx)
+	expiredInvoices, err := q.FindAllExpiredInvoicesOccupyingCryptoAddress(p.ctx)
+	if err != nil {
+		p.log.Err(err).Str("queryName", "FindAllExpiredInvoicesOccupyingCryptoAddress").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	paidGroupSiblings, err := q.FindAllPendingInvoicesWithPaidGroupSibling(p.ctx)
+	if err != nil {
+		p.log.Err(err).Str("queryName", "FindAllPendingInvoicesWithPaidGroupSibling").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	// The invoices are tracked only once their shifted expiry is committed.
+	if err := tx.Commit(p.ctx); err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return err
+	}
 
-	// TODO: Add impelmentation for TON
 	for i := 0; i < len(invoices); i++ {
 		for _, cp := range p.cryptoProcessors {
 			if cp.supportsCoin(invoices[i].Coin) {
@@ -62,32 +142,157 @@ func (p *PaymentProcessor) loadPersistedPendingInvoices() error {
 			}
 		}
 	}
+	for i := 0; i < len(expiredInvoices); i++ {
+		for _, cp := range p.cryptoProcessors {
+			if cp.supportsCoin(expiredInvoices[i].Coin) {
+				cp.handleExpiredInvoice(p.ctx, expiredInvoices[i])
+			}
+		}
+	}
+	// A sibling might have been paid while the service was down.
+	for i := 0; i < len(paidGroupSiblings); i++ {
+		if _, err := p.cancelGroupSiblingInvoice(&paidGroupSiblings[i]); err != nil {
+			p.log.Err(err).Str("invoiceId", util.PgUUIDToString(paidGroupSiblings[i].ID)).Msg(util.FailedGroupSiblingCancellationMsg)
+		}
+	}
 
 	return nil
 }
 
+func (p *PaymentProcessor) findInvoicesByGroupId(groupId pgtype.UUID) ([]db.Invoice, error) {
+	q, tx, err := util.InitDbQueriesWithTx(p.ctx, p.dbConnPool)
+	if err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, err
+	}
+	defer tx.Rollback(p.ctx)
+
+	invoices, err := q.FindInvoicesByGroupId(p.ctx, groupId)
+	if err != nil {
+		p.log.Err(err).Str("queryName", "FindInvoicesByGroupId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	tx.Commit(p.ctx)
+
+	return invoices, nil
+}
+
+func isGroupCompletingStatus(status db.InvoiceStatusType) bool {
+	return status == db.InvoiceStatusTypePENDINGMEMPOOL || status == db.InvoiceStatusTypeCONFIRMED
+}
+
+// cancelInvoiceGroupSiblings releases the addresses of the still pending or partially paid siblings once the invoice has received its required amount.
+func (p *PaymentProcessor) cancelInvoiceGroupSiblings(invoice db.Invoice) {
+	siblings, err := p.findInvoicesByGroupId(invoice.GroupID)
+	if err != nil {
+		return
+	}
+
+	for i := 0; i < len(siblings); i++ {
+		if siblings[i].ID == invoice.ID || (siblings[i].Status != db.InvoiceStatusTypePENDING && siblings[i].Status != db.InvoiceStatusTypePARTIALLYPAID) {
+			continue
+		}
+
+		if _, err := p.cancelGroupSiblingInvoice(&siblings[i]); err != nil && !errors.Is(err, InvoiceNotCancellableErr) {
+			p.log.Err(err).Str("invoiceId", util.PgUUIDToString(siblings[i].ID)).Msg(util.FailedGroupSiblingCancellationMsg)
+		}
+	}
+}
+
+// sendInvoiceStreamEvent hands the event to every subscriber in turn, a subscriber not taking it within SEND_TIMEOUT is dropped.
+func (p *PaymentProcessor) sendInvoiceStreamEvent(event dto.InvoiceStreamEvent) {
+	p.newInvoicesCns.Range(func(key string, sub invoiceStreamSubscriber) bool {
+		select {
+		case sub.cn <- event:
+		case <-time.After(util.SEND_TIMEOUT):
+			p.newInvoicesCns.Delete(key)
+			sub.dropOnce.Do(func() { close(sub.dropped) })
+		case <-p.ctx.Done():
+			return false
+		}
+
+		return true
+	})
+}
+
+// publishInvoiceStreamEvents sends the stream events committed since the last published one.
+// The events are inserted along with the status changes, so they are read back in the order of their seqs.
+func (p *PaymentProcessor) publishInvoiceStreamEvents() {
+	for {
+		events, err := p.FindInvoiceStreamEventsAfterSeq(p.publishedSeq, util.INVOICE_STREAM_REPLAY_BATCH_SIZE)
+		if err != nil {
+			return
+		}
+
+		for i := 0; i < len(events); i++ {
+			p.sendInvoiceStreamEvent(events[i])
+			p.publishedSeq = events[i].Seq
+		}
+
+		if len(events) < util.INVOICE_STREAM_REPLAY_BATCH_SIZE {
+			return
+		}
+	}
+}
+
+func (p *PaymentProcessor) findLastInvoiceStreamEventSeq() (uint64, error) {
+	q, tx, err := util.InitDbQueriesWithTx(p.ctx, p.dbConnPool)
+	if err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return 0, err
+	}
+	defer tx.Rollback(p.ctx)
+
+	seq, err := q.FindLastInvoiceStreamEventSeq(p.ctx)
+	if err != nil {
+		p.log.Err(err).Str("queryName", "FindLastInvoiceStreamEventSeq").Msg(util.DefaultFailedSqlQueryMsg)
+		return 0, err
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return 0, err
+	}
+
+	return uint64(seq), nil
+}
+
 func (p *PaymentProcessor) load() error {
+	// The subscribers get only the events committed from now on, the earlier ones are up to the replay.
+	seq, err := p.findLastInvoiceStreamEventSeq()
+	if err != nil {
+		return err
+	}
+	p.publishedSeq = seq
+
+	// The single publisher, so the subscribers get the events in order.
+	go func() {
+		for {
+			select {
+			case <-p.publishCn:
+				p.publishInvoiceStreamEvents()
+			case <-p.ctx.Done():
+				return
+			}
+		}
+	}()
+
 	go func() {
 		for {
 			select {
 			case tx := <-p.invoiceCn:
-				p.newInvoicesCns.Range(func(key string, cn chan db.Invoice) bool {
-					go func() {
-						select {
-						case cn <- tx:
-							return
-						case <-time.After(util.SEND_TIMEOUT):
-							p.newInvoicesCns.Delete(key)
-							return
-						case <-p.ctx.Done():
-							return
-						}
-					}()
-
-					return true
-				})
+				// A pending signal covers this event as well.
+				select {
+				case p.publishCn <- struct{}{}:
+				default:
+				}
 
 				p.log.Info().Msgf("Transaction %v changed status to %v", util.PgUUIDToString(tx.ID), tx.Status)
+
+				if tx.GroupID.Valid && isGroupCompletingStatus(tx.Status) {
+					go p.cancelInvoiceGroupSiblings(tx)
+				}
 			case <-p.ctx.Done():
 				return
 			}
@@ -107,68 +312,322 @@ func (p *PaymentProcessor) load() error {
 	return nil
 }
 
+// idempotencyRequestHash fingerprints the invoice parameters as requested, i.e. before the fiat conversion,
+// so a retry matches the original call regardless of the exchange rate movements.
+func idempotencyRequestHash(req *dto.NewInvoiceRequest) string {
+	// Map keys are sorted by json.Marshal, so the metadata is encoded deterministically.
+	metadata, _ := json.Marshal(req.Metadata)
+	coinOptions, _ := json.Marshal(req.CoinOptions)
+
+	h := sha256.New()
+	fmt.Fprintf(
+		h,
+		"%v|%v|%v|%v|%v|%v|%v|%v|%v|%q|%q|%s|%s",
+		req.Coin,
+		req.Amount,
+		req.Timeout,
+		req.Confirmations,
+		req.UnderpaymentTolerance,
+		req.UnderpaymentTolerancePercent,
+		req.FiatAmount,
+		req.FiatCurrency,
+		req.FiatUnderpaymentTolerance,
+		req.Description,
+		req.ExternalOrderId,
+		metadata,
+		coinOptions,
+	)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func isIdempotencyKeyConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationPgErrCode && pgErr.ConstraintName == idempotencyKeyUniqueIndex
+}
+
+// findInvoiceByIdempotencyKey returns pgx.ErrNoRows if there is no invoice with the key yet.
+func (p *PaymentProcessor) findInvoiceByIdempotencyKey(req *dto.NewInvoiceRequest) (*db.Invoice, error) {
+	userId, err := util.StringToPgUUID(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	q, tx, err := util.InitDbQueriesWithTx(p.ctx, p.dbConnPool)
+	if err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, err
+	}
+	defer tx.Rollback(p.ctx)
+
+	invoice, err := q.FindInvoiceByUserIdAndIdempotencyKey(p.ctx, db.FindInvoiceByUserIdAndIdempotencyKeyParams{
+		UserID:         *userId,
+		IdempotencyKey: pgtype.Text{String: req.IdempotencyKey, Valid: true},
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			p.log.Err(err).Str("queryName", "FindInvoiceByUserIdAndIdempotencyKey").Msg(util.DefaultFailedSqlQueryMsg)
+		}
+		return nil, err
+	}
+
+	tx.Commit(p.ctx)
+
+	if invoice.IdempotencyRequestHash.String != req.IdempotencyRequestHash {
+		return nil, IdempotencyKeyReusedErr
+	}
+
+	return &invoice, nil
+}
+
 func (p *PaymentProcessor) HandleNewInvoice(req *dto.NewInvoiceRequest) (*db.Invoice, error) {
-	// TODO: Add impelmentation for TON
+	if req.IdempotencyKey != "" {
+		req.IdempotencyRequestHash = idempotencyRequestHash(req)
+
+		invoice, err := p.findInvoiceByIdempotencyKey(req)
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return invoice, err
+		}
+	}
+
+	return p.createInvoice(req)
+}
+
+func (p *PaymentProcessor) createInvoice(req *dto.NewInvoiceRequest) (*db.Invoice, error) {
+	if req.FiatCurrency != "" {
+		if err := p.convertFiatAmount(req); err != nil {
+			return nil, err
+		}
+	}
+	if req.UnderpaymentTolerancePercent > 0 {
+		req.UnderpaymentTolerance = percentOf(req.Amount, req.UnderpaymentTolerancePercent)
+	}
+
 	for _, cp := range p.cryptoProcessors {
 		if cp.supportsCoin(req.Coin) {
-			return cp.handleInvoicePbReq(p.ctx, req)
+			invoice, err := cp.handleInvoicePbReq(p.ctx, req)
+			if err != nil && req.IdempotencyKey != "" && isIdempotencyKeyConflict(err) {
+				// A concurrent call with the same key has created the invoice first.
+				return p.findInvoiceByIdempotencyKey(req)
+			}
+
+			return invoice, err
 		}
 	}
 
 	return nil, unimplementedError
 }
 
-func (p *PaymentProcessor) NewInvoicesChan() <-chan db.Invoice {
-	cn := make(chan db.Invoice)
-	p.newInvoicesCns.Store(uuid.NewString(), cn)
-	return cn
+// HandleNewMultiCoinInvoice creates an invoice per coin option, all sharing one group id.
+// The idempotency key is stored on the first invoice of the group only.
+func (p *PaymentProcessor) HandleNewMultiCoinInvoice(req *dto.NewInvoiceRequest) ([]db.Invoice, error) {
+	if req.IdempotencyKey != "" {
+		req.IdempotencyRequestHash = idempotencyRequestHash(req)
+
+		invoice, err := p.findInvoiceByIdempotencyKey(req)
+		if !errors.Is(err, pgx.ErrNoRows) {
+			if err != nil {
+				return nil, err
+			}
+			return p.findInvoicesByGroupId(invoice.GroupID)
+		}
+	}
+
+	groupId := uuid.New()
+	invoices := make([]db.Invoice, 0, len(req.CoinOptions))
+	for i := 0; i < len(req.CoinOptions); i++ {
+		childReq := *req
+		childReq.Coin = req.CoinOptions[i].Coin
+		childReq.Amount = req.CoinOptions[i].Amount
+		childReq.CoinOptions = nil
+		childReq.GroupId = groupId.String()
+		if i > 0 {
+			childReq.IdempotencyKey = ""
+			childReq.IdempotencyRequestHash = ""
+		}
+
+		invoice, err := p.createInvoice(&childReq)
+		if err == nil && i == 0 && util.PgUUIDToString(invoice.GroupID) != groupId.String() {
+			// A concurrent call with the same key has created the group first.
+			return p.findInvoicesByGroupId(invoice.GroupID)
+		}
+		if err != nil {
+			if len(invoices) > 0 && req.IdempotencyKey != "" {
+				// Otherwise a retry with the same key would be handed the cancelled group.
+				p.clearIdempotencyKey(&invoices[0])
+			}
+			for j := 0; j < len(invoices); j++ {
+				if _, err := p.CancelInvoice(&invoices[j]); err != nil {
+					p.log.Err(err).Str("invoiceId", util.PgUUIDToString(invoices[j].ID)).Msg(util.FailedGroupSiblingCancellationMsg)
+				}
+			}
+			return nil, err
+		}
+
+		invoices = append(invoices, *invoice)
+	}
+
+	return invoices, nil
+}
+
+func (p *PaymentProcessor) CancelInvoice(invoice *db.Invoice) (*db.Invoice, error) {
+	for _, cp := range p.cryptoProcessors {
+		if cp.supportsCoin(invoice.Coin) {
+			return cp.cancelInvoice(p.ctx, invoice)
+		}
+	}
+
+	return nil, unimplementedError
 }
 
-func NewPaymentProcessor(ctx context.Context, dbConnPool *pgxpool.Pool, c *dto.DaemonsConfig, log *zerolog.Logger) (*PaymentProcessor, error) {
+func (p *PaymentProcessor) cancelGroupSiblingInvoice(invoice *db.Invoice) (*db.Invoice, error) {
+	for _, cp := range p.cryptoProcessors {
+		if cp.supportsCoin(invoice.Coin) {
+			return cp.cancelGroupSiblingInvoice(p.ctx, invoice)
+		}
+	}
+
+	return nil, unimplementedError
+}
+
+func (p *PaymentProcessor) clearIdempotencyKey(invoice *db.Invoice) {
+	q, tx, err := util.InitDbQueriesWithTx(p.ctx, p.dbConnPool)
+	if err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return
+	}
+	defer tx.Rollback(p.ctx)
+
+	if _, err := q.ClearIdempotencyKeyById(p.ctx, invoice.ID); err != nil {
+		p.log.Err(err).Str("queryName", "ClearIdempotencyKeyById").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxCommitMsg)
+	}
+}
+
+func (p *PaymentProcessor) PaymentUri(invoice *db.Invoice, label string) (string, error) {
+	for _, cp := range p.cryptoProcessors {
+		if cp.supportsCoin(invoice.Coin) {
+			return cp.paymentUri(invoice, label)
+		}
+	}
+
+	return "", unimplementedError
+}
+
+func (p *PaymentProcessor) FindInvoiceStreamEventsAfterSeq(seq uint64, limit int32) ([]dto.InvoiceStreamEvent, error) {
+	q, tx, err := util.InitDbQueriesWithTx(p.ctx, p.dbConnPool)
+	if err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, err
+	}
+	defer tx.Rollback(p.ctx)
+
+	rows, err := q.FindInvoiceStreamEventsAfterSeq(p.ctx, db.FindInvoiceStreamEventsAfterSeqParams{Seq: int64(seq), Limit: limit})
+	if err != nil {
+		p.log.Err(err).Str("queryName", "FindInvoiceStreamEventsAfterSeq").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	tx.Commit(p.ctx)
+
+	events := make([]dto.InvoiceStreamEvent, 0, len(rows))
+	for i := 0; i < len(rows); i++ {
+		invoice := rows[i].Invoice
+		invoice.Status = rows[i].InvoiceStreamEvent.Status
+		invoice.ActualAmount = rows[i].InvoiceStreamEvent.ActualAmount
+		invoice.TxID = rows[i].InvoiceStreamEvent.TxID
+		invoice.ConfirmedAt = rows[i].InvoiceStreamEvent.ConfirmedAt
+		invoice.Confirmations = rows[i].InvoiceStreamEvent.Confirmations
+
+		events = append(events, dto.InvoiceStreamEvent{Seq: uint64(rows[i].InvoiceStreamEvent.Seq), Invoice: invoice})
+	}
+
+	return events, nil
+}
+
+// NewInvoicesChan subscribes to the invoice updates, the second channel is closed if the subscriber falls behind and is dropped.
+func (p *PaymentProcessor) NewInvoicesChan() (<-chan dto.InvoiceStreamEvent, <-chan struct{}) {
+	sub := invoiceStreamSubscriber{
+		cn:       make(chan dto.InvoiceStreamEvent, util.INVOICE_STREAM_SUBSCRIBER_BUFFER_SIZE),
+		dropped:  make(chan struct{}),
+		dropOnce: new(sync.Once),
+	}
+	p.newInvoicesCns.Store(uuid.NewString(), sub)
+	return sub.cn, sub.dropped
+}
+
+func NewPaymentProcessor(ctx context.Context, dbConnPool *pgxpool.Pool, c *dto.ProcessorConfig, rateProvider rate.RateProvider, log *zerolog.Logger) (*PaymentProcessor, error) {
 	invoiceCn := make(chan db.Invoice)
 	cryptoProcessors := make(map[db.CoinType]cryptoProcessor, 0)
 
-	if c.Xmr.Url != "" {
+	if c.Daemons.Xmr.Url != "" {
 		xmr, err := newXmrProcessor(log, dbConnPool, invoiceCn, c)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[xmr.coin] = xmr
 	}
-	if c.Btc.Url != "" {
+	if c.Daemons.Btc.Url != "" {
 		btc, err := newBtcProcessor(log, dbConnPool, invoiceCn, c)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[btc.coin] = btc
 	}
-	if c.Ltc.Url != "" {
+	if c.Daemons.Ltc.Url != "" {
 		ltc, err := newLtcProcessor(log, dbConnPool, invoiceCn, c)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[ltc.coin] = ltc
 	}
-	if c.Eth.Url != "" {
-		eth, err := newEthProcessor(log, dbConnPool, invoiceCn, c)
+	if c.Daemons.Eth.Url != "" {
+		eth, err := newEthProcessor(ctx, log, dbConnPool, invoiceCn, c)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[eth.coin] = eth
 	}
-	if c.Bnb.Url != "" {
-		bnb, err := newBnbProcessor(log, dbConnPool, invoiceCn, c)
+	if c.Daemons.Bnb.Url != "" {
+		bnb, err := newBnbProcessor(ctx, log, dbConnPool, invoiceCn, c)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[bnb.coin] = bnb
 	}
+	if c.Daemons.Ton.Url != "" {
+		ton, err := newTonProcessor(log, dbConnPool, invoiceCn, c)
+		if err != nil {
+			return nil, err
+		}
+		cryptoProcessors[ton.coin] = ton
+	}
+	if c.Daemons.Trx.Url != "" {
+		trx, err := newTrxProcessor(log, dbConnPool, invoiceCn, c)
+		if err != nil {
+			return nil, err
+		}
+		cryptoProcessors[trx.coin] = trx
+	}
+	for i := 0; i < len(c.Daemons.Evm); i++ {
+		evm, err := newEvmProcessor(ctx, log, dbConnPool, invoiceCn, c, &c.Daemons.Evm[i])
+		if err != nil {
+			return nil, err
+		}
+		cryptoProcessors[evm.coin] = evm
+	}
 
 	pp := &PaymentProcessor{
 		dbConnPool:       dbConnPool,
 		invoiceCn:        invoiceCn,
-		newInvoicesCns:   &util.SyncMapTypeSafe[string, chan db.Invoice]{},
+		newInvoicesCns:   &util.SyncMapTypeSafe[string, invoiceStreamSubscriber]{},
+		publishCn:        make(chan struct{}, 1),
 		cryptoProcessors: cryptoProcessors,
+		rateProvider:     rateProvider,
 		ctx:              ctx,
 		log:              log,
 	}
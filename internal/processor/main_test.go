@@ -0,0 +1,113 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/rate"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentOf(t *testing.T) {
+	assert.Equal(t, big.NewInt(3000), percentOf(big.NewInt(100000), 3))
+	assert.Equal(t, big.NewInt(0), percentOf(big.NewInt(100000), 0))
+	// Rounds down, so the tolerance never exceeds the requested percent.
+	assert.Equal(t, big.NewInt(33), percentOf(big.NewInt(1111), 3))
+}
+
+func TestConvertFiatAmount(t *testing.T) {
+	t.Run("Should Convert Fiat Amount And Lock The Rate", func(t *testing.T) {
+		p := &PaymentProcessor{
+			ctx:          context.Background(),
+			log:          &zerolog.Logger{},
+			rateProvider: rate.NewStaticRateProvider(map[db.CoinType]map[string]float64{db.CoinTypeBTC: {"USD": 30000}}),
+		}
+		req := &dto.NewInvoiceRequest{Coin: db.CoinTypeBTC, FiatAmount: 100, FiatCurrency: "USD", FiatUnderpaymentTolerance: 3}
+
+		assert.NoError(t, p.convertFiatAmount(req))
+		assert.Equal(t, float64(30000), req.ExchangeRate)
+		assert.Equal(t, big.NewInt(333334), req.Amount)
+		assert.Equal(t, big.NewInt(10000), req.UnderpaymentTolerance)
+	})
+
+	t.Run("Should Return FiatInvoicesUnsupportedErr", func(t *testing.T) {
+		p := &PaymentProcessor{ctx: context.Background(), log: &zerolog.Logger{}}
+
+		assert.ErrorIs(t, p.convertFiatAmount(&dto.NewInvoiceRequest{Coin: db.CoinTypeBTC, FiatAmount: 100, FiatCurrency: "USD"}), FiatInvoicesUnsupportedErr)
+	})
+
+	t.Run("Should Return RateNotFoundErr", func(t *testing.T) {
+		p := &PaymentProcessor{
+			ctx:          context.Background(),
+			log:          &zerolog.Logger{},
+			rateProvider: rate.NewStaticRateProvider(map[db.CoinType]map[string]float64{}),
+		}
+
+		assert.ErrorIs(t, p.convertFiatAmount(&dto.NewInvoiceRequest{Coin: db.CoinTypeBTC, FiatAmount: 100, FiatCurrency: "USD"}), rate.RateNotFoundErr)
+	})
+}
+
+func TestIdempotencyRequestHash(t *testing.T) {
+	newReq := func() *dto.NewInvoiceRequest {
+		return &dto.NewInvoiceRequest{UserId: uuid.NewString(), Coin: db.CoinTypeBTC, FiatAmount: 100, FiatCurrency: "USD", Timeout: 600, IdempotencyKey: "key"}
+	}
+
+	t.Run("Should Match For The Same Parameters", func(t *testing.T) {
+		req := newReq()
+		// The user is already a part of the idempotency key scope.
+		req.UserId = uuid.NewString()
+
+		assert.Equal(t, idempotencyRequestHash(newReq()), idempotencyRequestHash(req))
+	})
+
+	t.Run("Should Differ For Different Parameters", func(t *testing.T) {
+		req := newReq()
+		req.FiatAmount = 101
+		assert.NotEqual(t, idempotencyRequestHash(newReq()), idempotencyRequestHash(req))
+
+		req = newReq()
+		req.Metadata = map[string]string{"customerId": "7"}
+		assert.NotEqual(t, idempotencyRequestHash(newReq()), idempotencyRequestHash(req))
+
+		req = newReq()
+		req.CoinOptions = []dto.CoinOption{{Coin: db.CoinTypeXMR}, {Coin: db.CoinTypeBTC}}
+		assert.NotEqual(t, idempotencyRequestHash(newReq()), idempotencyRequestHash(req))
+	})
+}
+
+func TestIsIdempotencyKeyConflict(t *testing.T) {
+	assert.True(t, isIdempotencyKeyConflict(fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: uniqueViolationPgErrCode, ConstraintName: idempotencyKeyUniqueIndex})))
+	assert.False(t, isIdempotencyKeyConflict(&pgconn.PgError{Code: uniqueViolationPgErrCode, ConstraintName: "unique_invoice_id_tx_id"}))
+	assert.False(t, isIdempotencyKeyConflict(errors.New("unique_user_id_idempotency_key")))
+}
+
+func TestSendInvoiceStreamEvent(t *testing.T) {
+	t.Run("Should Hand The Events To Every Subscriber In Order", func(t *testing.T) {
+		p := &PaymentProcessor{
+			ctx:            context.Background(),
+			log:            &zerolog.Logger{},
+			newInvoicesCns: &util.SyncMapTypeSafe[string, invoiceStreamSubscriber]{},
+		}
+		firstCn, _ := p.NewInvoicesChan()
+		secondCn, _ := p.NewInvoicesChan()
+
+		for seq := uint64(1); seq <= 3; seq++ {
+			p.sendInvoiceStreamEvent(dto.InvoiceStreamEvent{Seq: seq})
+		}
+
+		for _, cn := range []<-chan dto.InvoiceStreamEvent{firstCn, secondCn} {
+			for seq := uint64(1); seq <= 3; seq++ {
+				assert.Equal(t, seq, (<-cn).Seq)
+			}
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// addCoinTypeValue adds the coin to coin_type, so a chain or a token needs no migration.
+// ALTER TYPE takes no parameters, so the coin must have been validated by the util registry before.
+func addCoinTypeValue(ctx context.Context, dbConn db.DBTX, coin db.CoinType) error {
+	_, err := dbConn.Exec(ctx, fmt.Sprintf("ALTER TYPE coin_type ADD VALUE IF NOT EXISTS '%v'", coin))
+	return err
+}
+
+func validateEVMTokenConfigs(chain db.CoinType, tokens []dto.EVMTokenConfig) error {
+	symbols := make(map[string]bool, len(tokens))
+
+	for i := 0; i < len(tokens); i++ {
+		token := &tokens[i]
+		if !common.IsHexAddress(token.Contract) {
+			return fmt.Errorf("invalid contract address of the %v token of the %v chain: %v", token.Symbol, chain, token.Contract)
+		}
+		if symbols[token.Symbol] {
+			return fmt.Errorf("duplicate %v token of the %v chain", token.Symbol, chain)
+		}
+
+		symbols[token.Symbol] = true
+	}
+
+	return nil
+}
+
+// registerETHCompatibleTokens adds the tokens of the config to the token registry, then makes the enabled tokens of the chain
+// on the network with the chain ID known to the app and returns their coins.
+func registerETHCompatibleTokens(ctx context.Context, dbConn db.DBTX, chain db.CoinType, chainId uint64, configTokens []dto.EVMTokenConfig) ([]db.CoinType, error) {
+	if err := validateEVMTokenConfigs(chain, configTokens); err != nil {
+		return nil, err
+	}
+
+	q := db.New(dbConn)
+
+	for i := 0; i < len(configTokens); i++ {
+		token := &configTokens[i]
+
+		known := util.IsKnownCoin(util.EVMTokenCoin(token.Symbol, chain))
+		coin, err := util.RegisterEVMToken(token.Symbol, chain, token.Decimals)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v of the %v chain", err, token.Symbol, chain)
+		}
+		if !known {
+			if err := addCoinTypeValue(ctx, dbConn, coin); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := q.UpsertToken(ctx, db.UpsertTokenParams{
+			Chain:           chain,
+			ChainID:         int64(chainId),
+			Symbol:          token.Symbol,
+			Coin:            coin,
+			ContractAddress: common.HexToAddress(token.Contract).Hex(),
+			Decimals:        token.Decimals,
+			Enabled:         !token.Disabled,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := q.FindEnabledTokensByChainAndChainId(ctx, db.FindEnabledTokensByChainAndChainIdParams{Chain: chain, ChainID: int64(chainId)})
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make(map[db.CoinType]tokenData, len(rows))
+	for i := 0; i < len(rows); i++ {
+		if !common.IsHexAddress(rows[i].ContractAddress) {
+			return nil, fmt.Errorf("invalid contract address of the %v token of the %v chain: %v", rows[i].Symbol, chain, rows[i].ContractAddress)
+		}
+
+		// The coin column is trusted rather than rebuilt from the symbol, so the legacy coins like BSC-USD_BEP20 are kept.
+		coin := rows[i].Coin
+		if err := util.RegisterEVMTokenCoin(coin, rows[i].Decimals); err != nil {
+			return nil, fmt.Errorf("%w: %v of the %v chain", err, rows[i].Symbol, chain)
+		}
+
+		// verifyETHBasedTxHandler compares the checksummed addresses.
+		tokens[coin] = tokenData{contractAddress: common.HexToAddress(rows[i].ContractAddress).Hex()}
+	}
+	tokenDataETHCompatible.Store(chain, tokens)
+
+	return util.GetMapKeys(tokens), nil
+}
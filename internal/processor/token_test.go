@@ -0,0 +1,44 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEVMTokenConfigs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Accept Tokens", func(t *testing.T) {
+		assert.NoError(t, validateEVMTokenConfigs(db.CoinTypeETH, []dto.EVMTokenConfig{
+			{Symbol: "USDT", Contract: "0xaA8E23Fb1079EA71e0a56F48a2aA51851D8433D0", Decimals: 6},
+			{Symbol: "PYUSD", Contract: "0xcac524bca292aaade2df8a05cc58f0a65b1b3bb9", Decimals: 6},
+		}))
+	})
+
+	t.Run("Should Return Error (invalid contract)", func(t *testing.T) {
+		assert.Error(t, validateEVMTokenConfigs("BASE", []dto.EVMTokenConfig{{Symbol: "USDC", Contract: "0x83"}}))
+	})
+
+	t.Run("Should Return Error (duplicate token)", func(t *testing.T) {
+		assert.Error(t, validateEVMTokenConfigs("BASE", []dto.EVMTokenConfig{
+			{Symbol: "USDC", Contract: "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"},
+			{Symbol: "USDC", Contract: "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"},
+		}))
+	})
+}
+
+func TestFindTokenDataETHCompatible(t *testing.T) {
+	chain := db.CoinType("TESTOPTIMISM")
+	tokenDataETHCompatible.Store(chain, map[db.CoinType]tokenData{"USDC_TESTOPTIMISM": {contractAddress: "0x0b2C639c533813f4Aa9D7837CAf62653d097Ff85"}})
+	defer tokenDataETHCompatible.Delete(chain)
+
+	token, ok := findTokenDataETHCompatible("USDC_TESTOPTIMISM")
+	assert.True(t, ok)
+	assert.Equal(t, "0x0b2C639c533813f4Aa9D7837CAf62653d097Ff85", token.contractAddress)
+
+	_, ok = findTokenDataETHCompatible("USDT_TESTOPTIMISM")
+	assert.False(t, ok)
+}
@@ -0,0 +1,144 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+var (
+	// The jetton masters are of the mainnet, see supportedTONTokens.
+	tokenDataTON map[db.CoinType]tokenData = map[db.CoinType]tokenData{
+		db.CoinTypeUSDTTON: {contractAddress: "EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs"},
+	}
+)
+
+type tonProcessor struct {
+	baseCryptoProcessor[listener.TONTx, listener.TONBlock]
+}
+
+func verifyTONTxHandler(client *listener.SharedTONDaemonRpcClient) func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.TONTx]) (*big.Int, error) {
+	return func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.TONTx]) (*big.Int, error) {
+		amount := new(big.Int)
+
+		inMsg := data.tx.InMsg
+		// The external messages (i.e. without a source) carry no value.
+		if data.tx.IsDoubleSpendSeen() || inMsg == nil || inMsg.Source == "" || !data.invoice.Memo.Valid {
+			return amount, nil
+		}
+
+		if data.invoice.Coin == db.CoinTypeTON {
+			if !util.EqualTONAddresses(data.tx.Account, data.invoice.CryptoAddress) {
+				return amount, nil
+			}
+
+			comment, err := util.ParseTONTextComment(inMsg.MessageContent.Body)
+			if err != nil || comment != data.invoice.Memo.String {
+				return amount, nil
+			}
+
+			value, ok := new(big.Int).SetString(inMsg.Value, 10)
+			if !ok {
+				return amount, errors.New("invalid TON message value")
+			}
+
+			return value, nil
+		}
+
+		token, ok := tokenDataTON[data.invoice.Coin]
+		if !ok {
+			return amount, nil
+		}
+
+		// The jetton wallet of the merchant receives the internal transfer, which is rejected unless sent by another wallet of the same jetton.
+		transfer, err := util.ParseTONJettonTransfer(inMsg.MessageContent.Body)
+		if err != nil || transfer.Comment != data.invoice.Memo.String {
+			return amount, nil
+		}
+
+		wallet, err := client.GetJettonWallet(data.tx.Account)
+		if err != nil {
+			return amount, err
+		}
+		if !util.EqualTONAddresses(wallet.Owner, data.invoice.CryptoAddress) || !util.EqualTONAddresses(wallet.Jetton, token.contractAddress) {
+			return amount, nil
+		}
+
+		return amount.Set(transfer.Amount), nil
+	}
+}
+
+// generateNextTONAddressHandler occupies the next memo of the merchant wallet, as all the TON invoices of the user share it.
+func generateNextTONAddressHandler(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+	var addr db.CryptoAddress
+
+	cd, err := q.FindCryptoDataByUserId(ctx, data.userId)
+	if err != nil {
+		return addr, err
+	}
+
+	wallet, err := q.FindWalletAndLockTONCryptoDataById(ctx, cd.TonID)
+	if err != nil {
+		return addr, err
+	}
+
+	wallet.LastMemoIndex++
+
+	addr, err = createCryptoAddressHelper(ctx, q, db.CreateCryptoAddressParams{
+		Address:    wallet.WalletAddress,
+		Coin:       db.CoinTypeTON,
+		IsOccupied: true,
+		UserID:     data.userId,
+		Memo:       pgtype.Text{String: strconv.Itoa(int(wallet.LastMemoIndex)), Valid: true},
+	})
+	if err != nil && !errors.Is(err, RetiredCryptoAddressOccupiedErr) {
+		return addr, err
+	}
+
+	if _, err := q.UpdateMemoIndexTONCryptoDataById(ctx, db.UpdateMemoIndexTONCryptoDataByIdParams{ID: cd.TonID, LastMemoIndex: wallet.LastMemoIndex}); err != nil {
+		return addr, err
+	}
+
+	return addr, err
+}
+
+// supportedTONTokens returns the jettons payable on the network.
+// The jetton invoices are rejected on the testnet rather than never paid there.
+func supportedTONTokens(network listener.NetworkType) []db.CoinType {
+	if network != listener.MainnetTON {
+		return nil
+	}
+
+	return util.GetMapKeys(tokenDataTON)
+}
+
+func newTonProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.ProcessorConfig) (*tonProcessor, error) {
+	client := listener.NewSharedTONDaemonRpcClient(&http.Client{Timeout: util.TON_DAEMON_TIMEOUT}, c.Daemons.Ton.Url, c.Daemons.Ton.Pass)
+
+	base, err := newBaseCryptoProcessor(
+		log,
+		dbConnPool,
+		invoiceCn,
+		client,
+		verifyTONTxHandler(client),
+		generateNextTONAddressHandler,
+		nil,
+		c.LatePaymentGraceWindow,
+	)
+	if err != nil {
+		return nil, err
+	}
+	base.supportedTokens = util.SliceToSet(supportedTONTokens(base.network))
+
+	return &tonProcessor{baseCryptoProcessor: *base}, nil
+}
@@ -0,0 +1,100 @@
+package processor
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tonTestWallet       string = "UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI"
+	tonTestWalletRaw    string = "0:83DFD552E63729B472FCBCC8C45EBCC6691702558B68EC7527E1BA403A0F31A8"
+	tonTestUSDTMaster   string = "0:B113A994B5024A16719F69139328EB759596C38A25F59028B146FECDC3621DFE"
+	tonTestJettonWallet string = "0:EE"
+	tonTestFakeWallet   string = "0:FF"
+
+	// Text comments "7" and "8".
+	tonTestComment7Body string = "te6ccgEBAQEABwAACgAAAAA3"
+	tonTestComment8Body string = "te6ccgEBAQEABwAACgAAAAA4"
+	// Jetton internal_transfer of 12340000 units with the "7" comment.
+	tonTestJettonTransferBody string = "te6ccgEBAQEAOQAAbReNRRkAAAAAAAAAADvEsggBB7+qpcxuU2jl+XmRiL15jNIuBKsW0djqT8N0gHQeY1AAAAAAAN4="
+)
+
+func newTONTestTx(account string, source string, value string, body string, aborted bool) listener.TONTx {
+	tx := listener.TONTx{Account: account, Hash: "tx", InMsg: &listener.TONMessage{Source: source, Destination: account, Value: value}}
+	tx.Description.Aborted = aborted
+	tx.InMsg.MessageContent.Body = body
+
+	return tx
+}
+
+func TestVerifyTONTxHandler(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("address") {
+		case tonTestJettonWallet:
+			w.Write([]byte(`{"jetton_wallets":[{"address":"0:EE","owner":"` + tonTestWalletRaw + `","jetton":"` + tonTestUSDTMaster + `"}]}`))
+		case tonTestFakeWallet:
+			w.Write([]byte(`{"jetton_wallets":[{"address":"0:FF","owner":"` + tonTestWalletRaw + `","jetton":"0:DD"}]}`))
+		default:
+			w.Write([]byte(`{"jetton_wallets":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	verify := verifyTONTxHandler(listener.NewSharedTONDaemonRpcClient(server.Client(), server.URL, ""))
+	memo := pgtype.Text{String: "7", Valid: true}
+	tonInvoice := db.Invoice{Coin: db.CoinTypeTON, CryptoAddress: tonTestWallet, Memo: memo}
+	usdtInvoice := db.Invoice{Coin: db.CoinTypeUSDTTON, CryptoAddress: tonTestWallet, Memo: memo}
+
+	cases := []struct {
+		name     string
+		invoice  db.Invoice
+		tx       listener.TONTx
+		expected *big.Int
+	}{
+		{name: "Should Count TON Transfer With Memo", invoice: tonInvoice, tx: newTONTestTx(tonTestWalletRaw, "0:BB", "1500000000", tonTestComment7Body, false), expected: big.NewInt(1500000000)},
+		{name: "Should Skip TON Transfer With Other Memo", invoice: tonInvoice, tx: newTONTestTx(tonTestWalletRaw, "0:BB", "1500000000", tonTestComment8Body, false), expected: big.NewInt(0)},
+		{name: "Should Skip TON Transfer To Other Account", invoice: tonInvoice, tx: newTONTestTx("0:AA", "0:BB", "1500000000", tonTestComment7Body, false), expected: big.NewInt(0)},
+		{name: "Should Skip Aborted TON Transfer", invoice: tonInvoice, tx: newTONTestTx(tonTestWalletRaw, "0:BB", "1500000000", tonTestComment7Body, true), expected: big.NewInt(0)},
+		{name: "Should Skip External Message", invoice: tonInvoice, tx: newTONTestTx(tonTestWalletRaw, "", "0", tonTestComment7Body, false), expected: big.NewInt(0)},
+		{name: "Should Count Jetton Transfer With Memo", invoice: usdtInvoice, tx: newTONTestTx(tonTestJettonWallet, "0:CC", "1", tonTestJettonTransferBody, false), expected: big.NewInt(12340000)},
+		{name: "Should Skip Jetton Transfer Of Other Jetton", invoice: usdtInvoice, tx: newTONTestTx(tonTestFakeWallet, "0:CC", "1", tonTestJettonTransferBody, false), expected: big.NewInt(0)},
+		{name: "Should Skip Plain TON Transfer For Jetton Invoice", invoice: usdtInvoice, tx: newTONTestTx(tonTestWalletRaw, "0:BB", "1500000000", tonTestComment7Body, false), expected: big.NewInt(0)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			amount, err := verify(context.Background(), nil, &verifyTxHandlerData[listener.TONTx]{invoice: c.invoice, tx: c.tx})
+			assert.NoError(t, err)
+			assert.Equal(t, 0, c.expected.Cmp(amount), amount.String())
+		})
+	}
+
+	t.Run("Should Return Error (unknown jetton wallet)", func(t *testing.T) {
+		_, err := verify(context.Background(), nil, &verifyTxHandlerData[listener.TONTx]{invoice: usdtInvoice, tx: newTONTestTx("0:AB", "0:CC", "1", tonTestJettonTransferBody, false)})
+		assert.Error(t, err)
+	})
+}
+
+func TestSupportedTONTokens(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []db.CoinType{db.CoinTypeUSDTTON}, supportedTONTokens(listener.MainnetTON))
+	assert.Empty(t, supportedTONTokens(listener.TestnetTON))
+}
+
+func TestInvoiceSlotKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "addr", invoiceSlotKey(&db.Invoice{CryptoAddress: "addr"}))
+	assert.Equal(t, "addr#7", invoiceSlotKey(&db.Invoice{CryptoAddress: "addr", Memo: pgtype.Text{String: "7", Valid: true}}))
+}
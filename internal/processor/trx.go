@@ -0,0 +1,137 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const (
+	transferContractTypeTRX string = "TransferContract"
+)
+
+var (
+	// TRC20. The contracts are of the mainnet, so the token invoices are never paid on the testnets.
+	tokenDataTRX map[db.CoinType]tokenData = map[db.CoinType]tokenData{
+		db.CoinTypeUSDTTRC20: {contractAddress: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"},
+		db.CoinTypeUSDCTRC20: {contractAddress: "TEkxiTehnzSmSe2XqrBj4w32RUN966rdz8"},
+	}
+)
+
+type trxProcessor struct {
+	baseCryptoProcessor[listener.TRXTx, listener.TRXBlock]
+}
+
+func verifyTRXTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.TRXTx]) (*big.Int, error) {
+	amount := new(big.Int)
+
+	if data.tx.IsDoubleSpendSeen() {
+		return amount, nil
+	}
+
+	if token, ok := tokenDataTRX[data.invoice.Coin]; ok {
+		contract, err := util.ParseTRXAddress(token.contractAddress)
+		if err != nil {
+			return amount, err
+		}
+		contractHex := hex.EncodeToString(contract)
+
+		// The TRC20 Transfer event is the same as the ERC20 one.
+		for i := 0; i < len(data.tx.Info.Log); i++ {
+			log := data.tx.Info.Log[i]
+			if len(log.Topics) < 3 ||
+				!strings.EqualFold(log.Topics[0], strings.TrimPrefix(transferMethodSignatureETHCompatible, "0x")) ||
+				!strings.EqualFold(log.Address, contractHex) {
+				continue
+			}
+
+			if util.TRXAddressFromBytes(common.HexToAddress(log.Topics[2]).Bytes()) == data.invoice.CryptoAddress {
+				amount.Add(amount, new(big.Int).SetBytes(common.FromHex(log.Data)))
+			}
+		}
+
+		return amount, nil
+	}
+
+	if data.invoice.Coin != db.CoinTypeTRX {
+		return amount, nil
+	}
+
+	for i := 0; i < len(data.tx.RawData.Contract); i++ {
+		contract := &data.tx.RawData.Contract[i]
+		if contract.Type == transferContractTypeTRX && contract.Parameter.Value.ToAddress == data.invoice.CryptoAddress {
+			amount.Add(amount, big.NewInt(contract.Parameter.Value.Amount))
+		}
+	}
+
+	return amount, nil
+}
+
+func generateNextTRXAddressHandler(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+	var addr db.CryptoAddress
+
+	cd, err := q.FindCryptoDataByUserId(ctx, data.userId)
+	if err != nil {
+		return addr, err
+	}
+
+	indices, err := q.FindIndicesAndLockTRXCryptoDataById(ctx, cd.TrxID)
+	if err != nil {
+		return addr, err
+	}
+
+	mPubStr, err := q.FindKeysAndLockTRXCryptoDataById(ctx, cd.TrxID)
+	if err != nil {
+		return addr, err
+	}
+
+	i := db.FindIndicesAndLockETHCryptoDataByIdRow(indices)
+	pubKey, err := deriveNextETHBasedECPubKeyHelper(&i, mPubStr)
+	if err != nil {
+		return addr, err
+	}
+
+	// A TRON address is the ETH one of the same key, just with another prefix and encoding.
+	address := util.TRXAddressFromBytes(crypto.PubkeyToAddress(*pubKey.ToECDSA()).Bytes())
+
+	addr, err = createCryptoAddressHelper(ctx, q, db.CreateCryptoAddressParams{Address: address, Coin: db.CoinTypeTRX, IsOccupied: true, UserID: data.userId, DerivationPath: derivationPath(i.LastMajorIndex, i.LastMinorIndex)})
+	if err != nil && !errors.Is(err, RetiredCryptoAddressOccupiedErr) {
+		return addr, err
+	}
+
+	if _, err := q.UpdateIndicesTRXCryptoDataById(ctx, db.UpdateIndicesTRXCryptoDataByIdParams{ID: cd.TrxID, LastMajorIndex: i.LastMajorIndex, LastMinorIndex: i.LastMinorIndex}); err != nil {
+		return addr, err
+	}
+
+	return addr, err
+}
+
+func newTrxProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.ProcessorConfig) (*trxProcessor, error) {
+	base, err := newBaseCryptoProcessor(
+		log,
+		dbConnPool,
+		invoiceCn,
+		listener.NewSharedTRXDaemonRpcClient(&http.Client{Timeout: util.TRX_DAEMON_TIMEOUT}, c.Daemons.Trx.Url, c.Daemons.Trx.Pass),
+		verifyTRXTxHandler,
+		generateNextTRXAddressHandler,
+		util.GetMapKeys(tokenDataTRX),
+		c.LatePaymentGraceWindow,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trxProcessor{baseCryptoProcessor: *base}, nil
+}
@@ -0,0 +1,85 @@
+package processor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	trxTestRecipientHex string = "5c7f2f2a86b0b3c1a1dfd7a8d0c2f54ea8a3e0a1"
+	trxTestUSDTHex      string = "a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	trxTestTransferData string = "0000000000000000000000000000000000000000000000000000000000bc4b20"
+)
+
+func newTRXTestTransferTx(to string, amount int64, ret string) listener.TRXTx {
+	var contract listener.TRXContract
+	contract.Type = transferContractTypeTRX
+	contract.Parameter.Value.Amount = amount
+	contract.Parameter.Value.ToAddress = to
+
+	tx := listener.TRXTx{TxID: "tx"}
+	tx.RawData.Contract = []listener.TRXContract{contract}
+	tx.Ret = append(tx.Ret, struct {
+		ContractRet string `json:"contractRet"`
+	}{ContractRet: ret})
+
+	return tx
+}
+
+func newTRXTestTRC20Tx(contractHex string, toHex string, receipt string) listener.TRXTx {
+	tx := listener.TRXTx{TxID: "tx"}
+	tx.Info.Receipt.Result = receipt
+	tx.Info.Log = []listener.TRXLog{{
+		Address: contractHex,
+		Topics: []string{
+			"ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+			"000000000000000000000000" + trxTestUSDTHex,
+			"000000000000000000000000" + toHex,
+		},
+		Data: trxTestTransferData,
+	}}
+
+	return tx
+}
+
+func TestVerifyTRXTxHandler(t *testing.T) {
+	t.Parallel()
+
+	recipient, err := util.TRXAddressFromHex(trxTestRecipientHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trxInvoice := db.Invoice{Coin: db.CoinTypeTRX, CryptoAddress: recipient}
+	usdtInvoice := db.Invoice{Coin: db.CoinTypeUSDTTRC20, CryptoAddress: recipient}
+	usdcInvoice := db.Invoice{Coin: db.CoinTypeUSDCTRC20, CryptoAddress: recipient}
+
+	cases := []struct {
+		name     string
+		invoice  db.Invoice
+		tx       listener.TRXTx
+		expected *big.Int
+	}{
+		{name: "Should Count TRX Transfer", invoice: trxInvoice, tx: newTRXTestTransferTx(recipient, 1500000, "SUCCESS"), expected: big.NewInt(1500000)},
+		{name: "Should Skip TRX Transfer To Other Address", invoice: trxInvoice, tx: newTRXTestTransferTx("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", 1500000, "SUCCESS"), expected: big.NewInt(0)},
+		{name: "Should Skip Failed TRX Transfer", invoice: trxInvoice, tx: newTRXTestTransferTx(recipient, 1500000, "OUT_OF_ENERGY"), expected: big.NewInt(0)},
+		{name: "Should Count TRC20 Transfer", invoice: usdtInvoice, tx: newTRXTestTRC20Tx(trxTestUSDTHex, trxTestRecipientHex, "SUCCESS"), expected: big.NewInt(12340000)},
+		{name: "Should Skip TRC20 Transfer To Other Address", invoice: usdtInvoice, tx: newTRXTestTRC20Tx(trxTestUSDTHex, trxTestUSDTHex, "SUCCESS"), expected: big.NewInt(0)},
+		{name: "Should Skip TRC20 Transfer Of Other Token", invoice: usdcInvoice, tx: newTRXTestTRC20Tx(trxTestUSDTHex, trxTestRecipientHex, "SUCCESS"), expected: big.NewInt(0)},
+		{name: "Should Skip Reverted TRC20 Transfer", invoice: usdtInvoice, tx: newTRXTestTRC20Tx(trxTestUSDTHex, trxTestRecipientHex, "REVERT"), expected: big.NewInt(0)},
+		{name: "Should Skip TRX Transfer For Token Invoice", invoice: usdtInvoice, tx: newTRXTestTransferTx(recipient, 1500000, "SUCCESS"), expected: big.NewInt(0)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			amount, err := verifyTRXTxHandler(context.Background(), nil, &verifyTxHandlerData[listener.TRXTx]{invoice: c.invoice, tx: c.tx})
+			assert.NoError(t, err)
+			assert.Equal(t, 0, c.expected.Cmp(amount), amount.String())
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+)
+
+var (
+	paymentUriUnsupportedCoinErr error = errors.New("payment uri is unsupported for the coin")
+
+	chainIdsETHCompatible map[listener.NetworkType]uint64 = map[listener.NetworkType]uint64{
+		listener.MainnetETH: 1,
+		listener.GoerliETH:  5,
+		listener.SepoliaETH: 11155111,
+		listener.PrivateETH: 1337,
+		listener.MainnetBNB: 56,
+		listener.TestnetBNB: 97,
+		listener.PrivateBNB: 714,
+	}
+)
+
+// escapeUriParam escapes spaces as %20 rather than +, since BIP21 and EIP-681 wallets don't treat + as a space.
+func escapeUriParam(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func ethCompatibleChainIdSuffix(network listener.NetworkType) string {
+	chainId, ok := chainIdsETHCompatible[network]
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("@%v", chainId)
+}
+
+func ethCompatibleNativePaymentUri(address string, chainIdSuffix string, units *big.Int) string {
+	return fmt.Sprintf("ethereum:%v%v?value=%v", address, chainIdSuffix, units)
+}
+
+func ethCompatibleTokenPaymentUri(contractAddress string, chainIdSuffix string, address string, units *big.Int) string {
+	return fmt.Sprintf("ethereum:%v%v/transfer?address=%v&uint256=%v", contractAddress, chainIdSuffix, address, units)
+}
+
+// buildEVMPaymentUri builds the EIP-681 URI for an EVM chain declared in the config, the chain ID of which comes from the config too.
+func buildEVMPaymentUri(chain db.CoinType, chainId uint64, invoice *db.Invoice) (string, error) {
+	units := util.PgNumericToBigInt(invoice.RequiredAmount)
+	chainIdSuffix := fmt.Sprintf("@%v", chainId)
+
+	if invoice.Coin == chain {
+		return ethCompatibleNativePaymentUri(invoice.CryptoAddress, chainIdSuffix, units), nil
+	}
+	if tokens, ok := tokenDataETHCompatible.Load(chain); ok {
+		if token, ok := tokens[invoice.Coin]; ok {
+			return ethCompatibleTokenPaymentUri(token.contractAddress, chainIdSuffix, invoice.CryptoAddress, units), nil
+		}
+	}
+
+	return "", paymentUriUnsupportedCoinErr
+}
+
+func buildPaymentUri(network listener.NetworkType, invoice *db.Invoice, label string) (string, error) {
+	units := util.PgNumericToBigInt(invoice.RequiredAmount)
+	amount := util.FormatAtomicUnits(units, invoice.Coin)
+
+	switch invoice.Coin {
+	case db.CoinTypeBTC, db.CoinTypeLTC:
+		scheme := "bitcoin"
+		if invoice.Coin == db.CoinTypeLTC {
+			scheme = "litecoin"
+		}
+
+		uri := fmt.Sprintf("%v:%v?amount=%v", scheme, invoice.CryptoAddress, amount)
+		if label != "" {
+			uri += "&label=" + escapeUriParam(label)
+		}
+		return uri, nil
+	case db.CoinTypeXMR:
+		uri := fmt.Sprintf("monero:%v?tx_amount=%v", invoice.CryptoAddress, amount)
+		if label != "" {
+			uri += "&recipient_name=" + escapeUriParam(label)
+		}
+		return uri, nil
+	case db.CoinTypeETH, db.CoinTypeBNB:
+		return ethCompatibleNativePaymentUri(invoice.CryptoAddress, ethCompatibleChainIdSuffix(network), units), nil
+	case db.CoinTypeTON:
+		return fmt.Sprintf("ton://transfer/%v?amount=%v&text=%v", invoice.CryptoAddress, units, escapeUriParam(invoice.Memo.String)), nil
+	case db.CoinTypeTRX:
+		return fmt.Sprintf("tron:%v?amount=%v", invoice.CryptoAddress, amount), nil
+	}
+
+	if token, ok := tokenDataTRX[invoice.Coin]; ok {
+		return fmt.Sprintf("tron:%v?token=%v&amount=%v", invoice.CryptoAddress, token.contractAddress, amount), nil
+	}
+
+	if token, ok := tokenDataTON[invoice.Coin]; ok {
+		return fmt.Sprintf(
+			"ton://transfer/%v?jetton=%v&amount=%v&text=%v",
+			invoice.CryptoAddress,
+			token.contractAddress,
+			units,
+			escapeUriParam(invoice.Memo.String),
+		), nil
+	}
+
+	if token, ok := findTokenDataETHCompatible(invoice.Coin); ok {
+		return ethCompatibleTokenPaymentUri(token.contractAddress, ethCompatibleChainIdSuffix(network), invoice.CryptoAddress, units), nil
+	}
+
+	return "", paymentUriUnsupportedCoinErr
+}
@@ -0,0 +1,108 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPaymentUri(t *testing.T) {
+	t.Parallel()
+
+	// The Sepolia USDT of the token registry.
+	tokenDataETHCompatible.Store(db.CoinTypeETH, map[db.CoinType]tokenData{db.CoinTypeUSDTERC20: {contractAddress: "0xaA8E23Fb1079EA71e0a56F48a2aA51851D8433D0"}})
+	defer tokenDataETHCompatible.Delete(db.CoinTypeETH)
+
+	t.Run("Should Build Payment Uri", func(t *testing.T) {
+		cases := []struct {
+			network  listener.NetworkType
+			invoice  db.Invoice
+			label    string
+			expected string
+		}{
+			{
+				network:  listener.MainnetBTC,
+				invoice:  db.Invoice{Coin: db.CoinTypeBTC, CryptoAddress: "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", RequiredAmount: pgAmountOrFatal("0.0015", db.CoinTypeBTC)},
+				label:    "Order #42",
+				expected: "bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=0.0015&label=Order%20%2342",
+			},
+			{
+				network:  listener.MainnetLTC,
+				invoice:  db.Invoice{Coin: db.CoinTypeLTC, CryptoAddress: "ltc1qg82tu4zqgg4mrqnygwhvpmfkmhvqcrn8xjarmz", RequiredAmount: pgAmountOrFatal("2", db.CoinTypeLTC)},
+				expected: "litecoin:ltc1qg82tu4zqgg4mrqnygwhvpmfkmhvqcrn8xjarmz?amount=2",
+			},
+			{
+				network:  listener.MainnetXMR,
+				invoice:  db.Invoice{Coin: db.CoinTypeXMR, CryptoAddress: "4AdUndXHHZ6cfufTMvppY6JwXNouMBzSkbLYfpAV5Usx", RequiredAmount: pgAmountOrFatal("0.123456789012", db.CoinTypeXMR)},
+				label:    "Shop",
+				expected: "monero:4AdUndXHHZ6cfufTMvppY6JwXNouMBzSkbLYfpAV5Usx?tx_amount=0.123456789012&recipient_name=Shop",
+			},
+			{
+				network:  listener.MainnetETH,
+				invoice:  db.Invoice{Coin: db.CoinTypeETH, CryptoAddress: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", RequiredAmount: pgAmountOrFatal("0.1", db.CoinTypeETH)},
+				label:    "ignored",
+				expected: "ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359@1?value=100000000000000000",
+			},
+			{
+				network:  listener.MainnetBNB,
+				invoice:  db.Invoice{Coin: db.CoinTypeBNB, CryptoAddress: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", RequiredAmount: pgAmountOrFatal("1.5", db.CoinTypeBNB)},
+				expected: "ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359@56?value=1500000000000000000",
+			},
+			{
+				network:  listener.SepoliaETH,
+				invoice:  db.Invoice{Coin: db.CoinTypeUSDTERC20, CryptoAddress: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", RequiredAmount: pgAmountOrFatal("12.34", db.CoinTypeUSDTERC20)},
+				expected: "ethereum:0xaA8E23Fb1079EA71e0a56F48a2aA51851D8433D0@11155111/transfer?address=0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359&uint256=12340000",
+			},
+			{
+				network:  listener.MainnetTON,
+				invoice:  db.Invoice{Coin: db.CoinTypeTON, CryptoAddress: "UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI", RequiredAmount: pgAmountOrFatal("1.5", db.CoinTypeTON), Memo: pgtype.Text{String: "42", Valid: true}},
+				label:    "ignored",
+				expected: "ton://transfer/UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI?amount=1500000000&text=42",
+			},
+			{
+				network:  listener.MainnetTON,
+				invoice:  db.Invoice{Coin: db.CoinTypeUSDTTON, CryptoAddress: "UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI", RequiredAmount: pgAmountOrFatal("12.34", db.CoinTypeUSDTTON), Memo: pgtype.Text{String: "43", Valid: true}},
+				expected: "ton://transfer/UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI?jetton=EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs&amount=12340000&text=43",
+			},
+			{
+				network:  listener.MainnetTRX,
+				invoice:  db.Invoice{Coin: db.CoinTypeTRX, CryptoAddress: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", RequiredAmount: pgAmountOrFatal("1.5", db.CoinTypeTRX)},
+				label:    "ignored",
+				expected: "tron:TJRabPrwbZy45sbavfcjinPJC18kjpRTv8?amount=1.5",
+			},
+			{
+				network:  listener.MainnetTRX,
+				invoice:  db.Invoice{Coin: db.CoinTypeUSDTTRC20, CryptoAddress: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", RequiredAmount: pgAmountOrFatal("12.34", db.CoinTypeUSDTTRC20)},
+				expected: "tron:TJRabPrwbZy45sbavfcjinPJC18kjpRTv8?token=TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t&amount=12.34",
+			},
+		}
+
+		for i := 0; i < len(cases); i++ {
+			uri, err := buildPaymentUri(cases[i].network, &cases[i].invoice, cases[i].label)
+			assert.NoError(t, err)
+			assert.Equal(t, cases[i].expected, uri)
+		}
+	})
+
+	t.Run("Should Pair Token Contract With Its Network Chain Id", func(t *testing.T) {
+		// The mainnet USDT goes with the mainnet chain ID only, the Sepolia one is covered above.
+		tokenDataETHCompatible.Store(db.CoinTypeETH, map[db.CoinType]tokenData{db.CoinTypeUSDTERC20: {contractAddress: "0xdAC17F958D2ee523a2206206994597C13D831ec7"}})
+
+		invoice := db.Invoice{Coin: db.CoinTypeUSDTERC20, CryptoAddress: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", RequiredAmount: pgAmountOrFatal("12.34", db.CoinTypeUSDTERC20)}
+
+		uri, err := buildPaymentUri(listener.MainnetETH, &invoice, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "ethereum:0xdAC17F958D2ee523a2206206994597C13D831ec7@1/transfer?address=0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359&uint256=12340000", uri)
+	})
+
+	t.Run("Should Keep Amounts Beyond Float Precision Exact", func(t *testing.T) {
+		invoice := db.Invoice{Coin: db.CoinTypeETH, CryptoAddress: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", RequiredAmount: pgAmountOrFatal("445540.848667137766512794", db.CoinTypeETH)}
+
+		uri, err := buildPaymentUri(listener.MainnetETH, &invoice, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359@1?value=445540848667137766512794", uri)
+	})
+}
@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"errors"
+	"math/big"
 	"net/url"
 
 	"github.com/chekist32/go-monero/daemon"
@@ -10,6 +11,7 @@ import (
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
 	"github.com/chekist32/goipay/internal/listener"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
@@ -18,31 +20,47 @@ type xmrProcessor struct {
 	baseCryptoProcessor[listener.XMRTx, listener.XMRBlock]
 }
 
-func verifyXMRTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.XMRTx]) (float64, error) {
-	cryptoData, err := q.FindCryptoDataByUserId(ctx, data.invoice.UserID)
+// findXMRPrivViewKey returns the view key of the key version the invoice address has been derived from,
+// so the invoices pending on a rotated key can still be paid. The addresses without a recorded version fall back to the current key.
+func findXMRPrivViewKey(ctx context.Context, q *db.Queries, invoice *db.Invoice) (string, error) {
+	privViewKey, err := q.FindXMRPrivViewKeyByCryptoAddress(ctx, invoice.CryptoAddress)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return privViewKey, err
+	}
+
+	cryptoData, err := q.FindCryptoDataByUserId(ctx, invoice.UserID)
 	if err != nil {
-		return 0, err
+		return "", err
 	}
 
 	xmrKeys, err := q.FindKeysAndLockXMRCryptoDataById(ctx, cryptoData.XmrID)
 	if err != nil {
-		return 0, err
+		return "", err
 	}
 
-	privView, err := utils.NewPrivateKey(xmrKeys.PrivViewKey)
+	return xmrKeys.PrivViewKey, nil
+}
+
+func verifyXMRTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.XMRTx]) (*big.Int, error) {
+	privViewKey, err := findXMRPrivViewKey(ctx, q, &data.invoice)
 	if err != nil {
-		return 0, errors.New("error occurred while creating the XMR private view key")
+		return nil, err
+	}
+
+	privView, err := utils.NewPrivateKey(privViewKey)
+	if err != nil {
+		return nil, errors.New("error occurred while creating the XMR private view key")
 	}
 
 	addr, err := utils.NewAddress(data.invoice.CryptoAddress)
 	if err != nil {
-		return 0, errors.New("error occurred while generating a new XMR subaddress")
+		return nil, errors.New("error occurred while generating a new XMR subaddress")
 	}
 	pubSpend := addr.PublicSpendKey()
 
 	txPub, err := utils.GetTxPublicKeyFromExtra(data.tx.TxInfo.Extra)
 	if err != nil {
-		return 0, errors.New("error occurred while extracting the tx public key from the extra field")
+		return nil, errors.New("error occurred while extracting the tx public key from the extra field")
 	}
 
 	var amount uint64 = 0
@@ -52,36 +70,38 @@ func verifyXMRTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandle
 
 		outKey, err := utils.NewPublicKey(out.Target.TaggedKey.Key)
 		if err != nil {
-			return 0, errors.New("error occurred while creating the XMR tx outKey")
+			return nil, errors.New("error occurred while creating the XMR tx outKey")
 		}
 
 		res, am, err := utils.DecryptOutputPublicSpendKey(pubSpend, uint32(i), outKey, ecdh.Amount, txPub, privView)
 		if err != nil {
-			return 0, errors.New("error occurred while decrypting the XMR tx output")
+			return nil, errors.New("error occurred while decrypting the XMR tx output")
 		}
 		if res {
 			amount += am
 		}
 	}
 
-	return utils.XMRToFloat64(amount), nil
+	return new(big.Int).SetUint64(amount), nil
+}
+
+func xmrNetworkType(network listener.NetworkType) (utils.NetworkType, error) {
+	switch network {
+	case listener.MainnetXMR:
+		return utils.Mainnet, nil
+	case listener.StagenetXMR:
+		return utils.Stagenet, nil
+	case listener.TestnetXMR:
+		return utils.Testnet, nil
+	default:
+		return 255, errors.New("invalid XMR network type")
+	}
 }
 
 func generateNextXMRAddressHandler(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 	var addr db.CryptoAddress
 
-	net, err := func() (utils.NetworkType, error) {
-		switch data.network {
-		case listener.MainnetXMR:
-			return utils.Mainnet, nil
-		case listener.StagenetXMR:
-			return utils.Stagenet, nil
-		case listener.TestnetXMR:
-			return utils.Testnet, nil
-		default:
-			return 255, errors.New("invalid XMR network type")
-		}
-	}()
+	net, err := xmrNetworkType(data.network)
 	if err != nil {
 		return addr, err
 	}
@@ -122,8 +142,8 @@ func generateNextXMRAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	addr, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: subAddr.Address(), Coin: db.CoinTypeXMR, IsOccupied: true, UserID: data.userId})
-	if err != nil {
+	addr, err = createCryptoAddressHelper(ctx, q, db.CreateCryptoAddressParams{Address: subAddr.Address(), Coin: db.CoinTypeXMR, IsOccupied: true, UserID: data.userId, DerivationPath: derivationPath(indices.LastMajorIndex, indices.LastMinorIndex)})
+	if err != nil && !errors.Is(err, RetiredCryptoAddressOccupiedErr) {
 		return addr, err
 	}
 
@@ -131,11 +151,11 @@ func generateNextXMRAddressHandler(ctx context.Context, q *db.Queries, data *gen
 		return addr, err
 	}
 
-	return addr, nil
+	return addr, err
 }
 
-func newXmrProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*xmrProcessor, error) {
-	u, err := url.Parse(c.Xmr.Url)
+func newXmrProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.ProcessorConfig) (*xmrProcessor, error) {
+	u, err := url.Parse(c.Daemons.Xmr.Url)
 	if err != nil {
 		return nil, err
 	}
@@ -144,10 +164,11 @@ func newXmrProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn ch
 		log,
 		dbConnPool,
 		invoiceCn,
-		listener.NewSharedXMRDaemonRpcClient(daemon.NewDaemonRpcClient(daemon.NewRpcConnection(u, c.Xmr.User, c.Xmr.Pass))),
+		listener.NewSharedXMRDaemonRpcClient(daemon.NewDaemonRpcClient(daemon.NewRpcConnection(u, c.Daemons.Xmr.User, c.Daemons.Xmr.Pass))),
 		verifyXMRTxHandler,
 		generateNextXMRAddressHandler,
 		nil,
+		c.LatePaymentGraceWindow,
 	)
 	if err != nil {
 		return nil, err
@@ -13,6 +13,7 @@ import (
 	"github.com/chekist32/go-monero/daemon"
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
 	"github.com/chekist32/goipay/test"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -108,6 +109,59 @@ func TestGenerateNextXmrAddressHandler(t *testing.T) {
 		})
 	}
 
+	t.Run("Should Skip Retired Address Still Occupied After Switching Back To The Key", func(t *testing.T) {
+		test.RunInTransaction(t, dbConn, func(t *testing.T, tx pgx.Tx) {
+			// Given
+			q := db.New(dbConn).WithTx(tx)
+			userId, cd, xmrData := createUserWithXmrData(ctx, q)
+			if _, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeXMR, PublicKey: xmrData.PubSpendKey, PrivViewKey: pgtype.Text{String: xmrData.PrivViewKey, Valid: true}}); err != nil {
+				log.Fatal(err)
+			}
+
+			// Occupied by an invoice pending on the first version
+			occupiedAddr, err := generateNextXMRAddressHandler(ctx, q, &generateNextAddressHandlerData{userId: userId, network: listener.StagenetXMR})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			// Rotated to another key and back, which resets the indices
+			for i := 0; i < 2; i++ {
+				if _, err := q.RetireAllCryptoAddressesByUserIdAndCoin(ctx, db.RetireAllCryptoAddressesByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeXMR}); err != nil {
+					log.Fatal(err)
+				}
+				if _, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeXMR, PublicKey: xmrData.PubSpendKey, PrivViewKey: pgtype.Text{String: xmrData.PrivViewKey, Valid: true}}); err != nil {
+					log.Fatal(err)
+				}
+			}
+			if _, err := q.UpdateIndicesXMRCryptoDataById(ctx, db.UpdateIndicesXMRCryptoDataByIdParams{ID: cd.XmrID, LastMajorIndex: 0, LastMinorIndex: 0}); err != nil {
+				log.Fatal(err)
+			}
+
+			// When
+			_, err = generateNextXMRAddressHandler(ctx, q, &generateNextAddressHandlerData{userId: userId, network: listener.StagenetXMR})
+
+			// Assert
+			assert.ErrorIs(t, err, RetiredCryptoAddressOccupiedErr)
+
+			// When
+			addr, err := generateNextXMRAddressHandler(ctx, q, &generateNextAddressHandlerData{userId: userId, network: listener.StagenetXMR})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.NotEqual(t, occupiedAddr.Address, addr.Address)
+			assert.Equal(t, "0/2", addr.DerivationPath.String)
+			assert.EqualValues(t, 3, addr.KeyVersion.Int32)
+
+			indices, err := q.FindIndicesAndLockXMRCryptoDataById(ctx, cd.XmrID)
+			assert.NoError(t, err)
+			assert.EqualValues(t, 2, indices.LastMinorIndex)
+
+			// The occupied address is left to the pending invoice
+			retired, err := q.ExistsRetiredCryptoAddress(ctx, db.ExistsRetiredCryptoAddressParams{Address: occupiedAddr.Address})
+			assert.NoError(t, err)
+			assert.True(t, retired)
+		})
+	})
 }
 
 func TestVerifyXMRTxHandler(t *testing.T) {
@@ -131,7 +185,64 @@ func TestVerifyXMRTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeXMR,
 				CryptoAddress:         "74xhb5sXRsnDZv8RKFEv7LAMfUq5AmGEEB77SVvsUJf8bLvFMSEfc8YYyJHF6xNNnjAZQmgqZp76AjT8bD6qKkLZLeR42oi",
-				RequiredAmount:        0.00101,
+				RequiredAmount:        pgAmountOrFatal("0.00101", db.CoinTypeXMR),
+				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+				ConfirmationsRequired: 0,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			txs, err := daemon.GetTransactions([]string{expectedTxId})
+			if err != nil {
+				log.Fatal(err)
+			} else if len(txs) < 1 {
+				log.Fatalf("Invalid Tx Id: %v", expectedTxId)
+			}
+
+			// When
+			amount, err := verifyXMRTxHandler(ctx, q, &verifyTxHandlerData[listener.XMRTx]{invoice: expectedInvoice, tx: txs[0]})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, util.PgNumericToBigInt(expectedInvoice.RequiredAmount), amount)
+		})
+	})
+
+	t.Run("Should Return Right Amount (Address Of A Rotated Key)", func(t *testing.T) {
+		test.RunInTransaction(t, dbConn, func(t *testing.T, tx pgx.Tx) {
+			// Given
+			q := db.New(dbConn).WithTx(tx)
+			userId, cd, xmrData := createUserWithXmrData(ctx, q)
+			if _, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeXMR, PublicKey: xmrData.PubSpendKey, PrivViewKey: pgtype.Text{String: xmrData.PrivViewKey, Valid: true}}); err != nil {
+				log.Fatal(err)
+			}
+			expectedAddress := "74xhb5sXRsnDZv8RKFEv7LAMfUq5AmGEEB77SVvsUJf8bLvFMSEfc8YYyJHF6xNNnjAZQmgqZp76AjT8bD6qKkLZLeR42oi"
+			if _, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: expectedAddress, Coin: db.CoinTypeXMR, IsOccupied: true, UserID: userId}); err != nil {
+				log.Fatal(err)
+			}
+
+			// The view key of the current keys can't decode the payments to the addresses of the previous ones
+			const (
+				newPrivViewKey string = "0100000000000000000000000000000000000000000000000000000000000000"
+				newPubSpendKey string = "5866666666666666666666666666666666666666666666666666666666666666"
+			)
+			if _, err := q.RetireAllCryptoAddressesByUserIdAndCoin(ctx, db.RetireAllCryptoAddressesByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeXMR}); err != nil {
+				log.Fatal(err)
+			}
+			if _, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeXMR, PublicKey: newPubSpendKey, PrivViewKey: pgtype.Text{String: newPrivViewKey, Valid: true}}); err != nil {
+				log.Fatal(err)
+			}
+			if _, err := q.UpdateKeysXMRCryptoDataById(ctx, db.UpdateKeysXMRCryptoDataByIdParams{ID: cd.XmrID, PrivViewKey: newPrivViewKey, PubSpendKey: newPubSpendKey}); err != nil {
+				log.Fatal(err)
+			}
+
+			expectedTxId := "eae833d591cf3333c1002c10ac4e8e74e65328a93933b404d6e40437911bf1cc"
+			expectedInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+				UserID:                userId,
+				Coin:                  db.CoinTypeXMR,
+				CryptoAddress:         expectedAddress,
+				RequiredAmount:        pgAmountOrFatal("0.00101", db.CoinTypeXMR),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -151,7 +262,7 @@ func TestVerifyXMRTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, expectedInvoice.RequiredAmount, amount)
+			assert.Equal(t, util.PgNumericToBigInt(expectedInvoice.RequiredAmount), amount)
 		})
 	})
 
@@ -166,7 +277,7 @@ func TestVerifyXMRTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeXMR,
 				CryptoAddress:         "74xhb5sXRsnDZv8RKFEv7LAMfUq5AmGEEB77SVvsUJf8bLvFMSEfc8YYyJHF6xNNnjAZQmgqZp76AjT8bD6qKkLZLeR42oi",
-				RequiredAmount:        0.00101,
+				RequiredAmount:        pgAmountOrFatal("0.00101", db.CoinTypeXMR),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -186,7 +297,7 @@ func TestVerifyXMRTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, float64(0), amount)
+			assert.Equal(t, 0, amount.Sign())
 		})
 	})
 }
@@ -0,0 +1,80 @@
+package rate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chekist32/goipay/internal/db"
+)
+
+// HttpJsonRateProvider fetches the rate from an HTTP endpoint returning JSON.
+// The {coin} and {currency} placeholders are substituted both in the url and in the dot-separated path
+// pointing to the rate inside the response body, e.g. "https://example.com/price?symbol={coin}&convert={currency}" and "data.{coin}.{currency}".
+type HttpJsonRateProvider struct {
+	client *http.Client
+	url    string
+	path   string
+}
+
+func (p *HttpJsonRateProvider) GetRate(ctx context.Context, coin db.CoinType, currency string) (float64, error) {
+	r := strings.NewReplacer("{coin}", string(coin), "{currency}", currency)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.Replace(p.url), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code of the rate provider response: %v", res.StatusCode)
+	}
+
+	var body any
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return 0, err
+	}
+
+	value := body
+	if p.path != "" {
+		for _, key := range strings.Split(r.Replace(p.path), ".") {
+			obj, ok := value.(map[string]any)
+			if !ok {
+				return 0, RateNotFoundErr
+			}
+			if value, ok = obj[key]; !ok {
+				return 0, RateNotFoundErr
+			}
+		}
+	}
+
+	var rate float64
+	switch v := value.(type) {
+	case float64:
+		rate = v
+	case string:
+		if rate, err = strconv.ParseFloat(v, 64); err != nil {
+			return 0, invalidRateErr
+		}
+	default:
+		return 0, RateNotFoundErr
+	}
+	if rate <= 0 {
+		return 0, invalidRateErr
+	}
+
+	return rate, nil
+}
+
+func NewHttpJsonRateProvider(client *http.Client, url string, path string) *HttpJsonRateProvider {
+	return &HttpJsonRateProvider{client: client, url: url, path: path}
+}
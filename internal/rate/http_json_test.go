@@ -0,0 +1,66 @@
+package rate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpJsonRateProvider(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/number":
+			w.Write([]byte(`{"data":{"` + r.URL.Query().Get("coin") + `":{"` + r.URL.Query().Get("currency") + `":150.5}}}`))
+		case "/string":
+			w.Write([]byte(`{"price":"42.25"}`))
+		case "/zero":
+			w.Write([]byte(`{"price":0}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Should Return Rate (number)", func(t *testing.T) {
+		p := NewHttpJsonRateProvider(server.Client(), server.URL+"/number?coin={coin}&currency={currency}", "data.{coin}.{currency}")
+
+		rate, err := p.GetRate(ctx, db.CoinTypeXMR, "USD")
+		assert.NoError(t, err)
+		assert.Equal(t, 150.5, rate)
+	})
+
+	t.Run("Should Return Rate (string)", func(t *testing.T) {
+		p := NewHttpJsonRateProvider(server.Client(), server.URL+"/string", "price")
+
+		rate, err := p.GetRate(ctx, db.CoinTypeBTC, "EUR")
+		assert.NoError(t, err)
+		assert.Equal(t, 42.25, rate)
+	})
+
+	t.Run("Should Return RateNotFoundErr", func(t *testing.T) {
+		p := NewHttpJsonRateProvider(server.Client(), server.URL+"/string", "data.price")
+
+		_, err := p.GetRate(ctx, db.CoinTypeBTC, "EUR")
+		assert.ErrorIs(t, err, RateNotFoundErr)
+	})
+
+	t.Run("Should Return invalidRateErr", func(t *testing.T) {
+		p := NewHttpJsonRateProvider(server.Client(), server.URL+"/zero", "price")
+
+		_, err := p.GetRate(ctx, db.CoinTypeBTC, "EUR")
+		assert.ErrorIs(t, err, invalidRateErr)
+	})
+
+	t.Run("Should Return Error (unexpected status code)", func(t *testing.T) {
+		p := NewHttpJsonRateProvider(server.Client(), server.URL+"/error", "price")
+
+		_, err := p.GetRate(ctx, db.CoinTypeBTC, "EUR")
+		assert.Error(t, err)
+	})
+}
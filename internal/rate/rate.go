@@ -0,0 +1,19 @@
+package rate
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chekist32/goipay/internal/db"
+)
+
+var (
+	RateNotFoundErr error = errors.New("exchange rate not found")
+
+	invalidRateErr error = errors.New("invalid exchange rate")
+)
+
+type RateProvider interface {
+	// GetRate returns the price of one unit of the coin in the given fiat currency.
+	GetRate(ctx context.Context, coin db.CoinType, currency string) (float64, error)
+}
@@ -0,0 +1,27 @@
+package rate
+
+import (
+	"context"
+
+	"github.com/chekist32/goipay/internal/db"
+)
+
+type StaticRateProvider struct {
+	rates map[db.CoinType]map[string]float64
+}
+
+func (p *StaticRateProvider) GetRate(ctx context.Context, coin db.CoinType, currency string) (float64, error) {
+	rate, ok := p.rates[coin][currency]
+	if !ok {
+		return 0, RateNotFoundErr
+	}
+	if rate <= 0 {
+		return 0, invalidRateErr
+	}
+
+	return rate, nil
+}
+
+func NewStaticRateProvider(rates map[db.CoinType]map[string]float64) *StaticRateProvider {
+	return &StaticRateProvider{rates: rates}
+}
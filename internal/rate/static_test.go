@@ -0,0 +1,32 @@
+package rate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRateProvider(t *testing.T) {
+	ctx := context.Background()
+	p := NewStaticRateProvider(map[db.CoinType]map[string]float64{
+		db.CoinTypeXMR: {"USD": 150, "EUR": 0},
+	})
+
+	t.Run("Should Return Rate", func(t *testing.T) {
+		rate, err := p.GetRate(ctx, db.CoinTypeXMR, "USD")
+		assert.NoError(t, err)
+		assert.Equal(t, float64(150), rate)
+	})
+
+	t.Run("Should Return RateNotFoundErr", func(t *testing.T) {
+		_, err := p.GetRate(ctx, db.CoinTypeBTC, "USD")
+		assert.ErrorIs(t, err, RateNotFoundErr)
+	})
+
+	t.Run("Should Return invalidRateErr", func(t *testing.T) {
+		_, err := p.GetRate(ctx, db.CoinTypeXMR, "EUR")
+		assert.ErrorIs(t, err, invalidRateErr)
+	})
+}
@@ -0,0 +1,178 @@
+package util
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	InvalidDecimalAmountErr error = errors.New("invalid decimal amount")
+	TooPreciseAmountErr     error = errors.New("amount has more decimal places than the coin supports")
+
+	decimalAmountRegexp *regexp.Regexp = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+	// The number of decimal places of the coins, i.e. an amount of 1 is 10^decimals atomic units (satoshis, piconeros, wei...).
+	coinDecimals map[db.CoinType]int32 = map[db.CoinType]int32{
+		db.CoinTypeXMR: 12,
+		db.CoinTypeBTC: 8,
+		db.CoinTypeLTC: 8,
+		db.CoinTypeETH: 18,
+		db.CoinTypeBNB: 18,
+		db.CoinTypeTON: 9,
+
+		// ERC20
+		db.CoinTypeUSDTERC20:  6,
+		db.CoinTypeUSDCERC20:  6,
+		db.CoinTypeDAIERC20:   18,
+		db.CoinTypeWBTCERC20:  8,
+		db.CoinTypeUNIERC20:   18,
+		db.CoinTypeLINKERC20:  18,
+		db.CoinTypeAAVEERC20:  18,
+		db.CoinTypeCRVERC20:   18,
+		db.CoinTypeMATICERC20: 18,
+		db.CoinTypeSHIBERC20:  18,
+		db.CoinTypeBNBERC20:   18,
+		db.CoinTypeATOMERC20:  6,
+		db.CoinTypeARBERC20:   18,
+
+		// BEP20
+		db.CoinTypeBSCUSDBEP20: 18,
+		db.CoinTypeUSDCBEP20:   18,
+		db.CoinTypeDAIBEP20:    18,
+		db.CoinTypeBUSDBEP20:   18,
+		db.CoinTypeWBTCBEP20:   8,
+		db.CoinTypeBTCBBEP20:   18,
+		db.CoinTypeUNIBEP20:    18,
+		db.CoinTypeLINKBEP20:   18,
+		db.CoinTypeAAVEBEP20:   18,
+		db.CoinTypeMATICBEP20:  18,
+		db.CoinTypeSHIBBEP20:   18,
+		db.CoinTypeATOMBEP20:   18,
+		db.CoinTypeARBBEP20:    18,
+		db.CoinTypeETHBEP20:    18,
+		db.CoinTypeXRPBEP20:    18,
+		db.CoinTypeADABEP20:    18,
+		db.CoinTypeTRXBEP20:    6,
+		db.CoinTypeDOGEBEP20:   8,
+		db.CoinTypeLTCBEP20:    18,
+		db.CoinTypeBCHBEP20:    18,
+		db.CoinTypeTWTBEP20:    18,
+		db.CoinTypeAVAXBEP20:   18,
+		db.CoinTypeCAKEBEP20:   18,
+
+		// Jettons
+		db.CoinTypeUSDTTON: 6,
+
+		db.CoinTypeTRX: 6,
+		// TRC20
+		db.CoinTypeUSDTTRC20: 6,
+		db.CoinTypeUSDCTRC20: 6,
+	}
+)
+
+func CoinDecimals(coin db.CoinType) int32 {
+	return coinDecimals[coin]
+}
+
+// IsKnownCoin reports whether the coin is either a built-in one or the one of an EVM chain declared in the config.
+func IsKnownCoin(coin db.CoinType) bool {
+	_, ok := coinDecimals[coin]
+	return ok
+}
+
+// CoinUnit returns the number of atomic units in one coin, i.e. 10^decimals.
+func CoinUnit(coin db.CoinType) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(CoinDecimals(coin))), nil)
+}
+
+// ParseDecimalAmount converts the decimal amount (e.g. "0.1") into the atomic units of the coin without any rounding.
+func ParseDecimalAmount(amount string, coin db.CoinType) (*big.Int, error) {
+	if !decimalAmountRegexp.MatchString(amount) {
+		return nil, InvalidDecimalAmountErr
+	}
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, InvalidDecimalAmountErr
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(CoinUnit(coin)))
+	if !r.IsInt() {
+		return nil, TooPreciseAmountErr
+	}
+
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// FloatToAtomicUnits converts the amount into the atomic units of the coin, rounding up the digits beyond its decimals,
+// so the converted amount never falls short of the original one. The shortest decimal representation of the float is used,
+// so e.g. 0.1 becomes exactly 10000000 satoshis rather than the value of its binary approximation.
+func FloatToAtomicUnits(amount float64, coin db.CoinType) *big.Int {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return new(big.Int)
+	}
+	r.Mul(r, new(big.Rat).SetInt(CoinUnit(coin)))
+
+	units, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		units.Add(units, big.NewInt(1))
+	}
+
+	return units
+}
+
+// FormatAtomicUnits formats the atomic units as a decimal amount of the coin without the trailing zeros, e.g. "0.1".
+func FormatAtomicUnits(units *big.Int, coin db.CoinType) string {
+	decimals := int(CoinDecimals(coin))
+	if decimals == 0 {
+		return units.String()
+	}
+
+	abs := new(big.Int).Abs(units).String()
+	if len(abs) <= decimals {
+		abs = strings.Repeat("0", decimals-len(abs)+1) + abs
+	}
+
+	s := strings.TrimRight(abs[:len(abs)-decimals]+"."+abs[len(abs)-decimals:], "0")
+	s = strings.TrimSuffix(s, ".")
+	if units.Sign() < 0 {
+		s = "-" + s
+	}
+
+	return s
+}
+
+// AtomicUnitsToFloat64 is lossy and only meant for the deprecated floating point fields of the API.
+func AtomicUnitsToFloat64(units *big.Int, coin db.CoinType) float64 {
+	f, _ := new(big.Rat).SetFrac(units, CoinUnit(coin)).Float64()
+	return f
+}
+
+func PgNumericToBigInt(n pgtype.Numeric) *big.Int {
+	if !n.Valid || n.Int == nil {
+		return new(big.Int)
+	}
+
+	v := new(big.Int).Set(n.Int)
+	if n.Exp > 0 {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n.Exp)), nil))
+	} else if n.Exp < 0 {
+		v.Quo(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-n.Exp)), nil))
+	}
+
+	return v
+}
+
+func BigIntToPgNumeric(v *big.Int) pgtype.Numeric {
+	if v == nil {
+		return pgtype.Numeric{Int: new(big.Int), Valid: true}
+	}
+
+	return pgtype.Numeric{Int: new(big.Int).Set(v), Valid: true}
+}
@@ -0,0 +1,121 @@
+package util
+
+import (
+	"log"
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func atomicUnitsOrFatal(amount string, coin db.CoinType) *big.Int {
+	units, err := ParseDecimalAmount(amount, coin)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return units
+}
+
+func TestCoinDecimals(t *testing.T) {
+	assert.Equal(t, int32(12), CoinDecimals(db.CoinTypeXMR))
+	assert.Equal(t, int32(8), CoinDecimals(db.CoinTypeBTC))
+	assert.Equal(t, int32(18), CoinDecimals(db.CoinTypeETH))
+	assert.Equal(t, int32(9), CoinDecimals(db.CoinTypeTON))
+	assert.Equal(t, int32(6), CoinDecimals(db.CoinTypeUSDTERC20))
+	assert.Equal(t, int32(8), CoinDecimals(db.CoinTypeWBTCBEP20))
+
+	t.Run("Should Cover All Coins", func(t *testing.T) {
+		for _, coin := range dbCoins {
+			_, ok := coinDecimals[coin]
+			assert.True(t, ok, coin)
+		}
+	})
+}
+
+func TestParseDecimalAmount(t *testing.T) {
+	t.Run("Should Parse Exact Amounts", func(t *testing.T) {
+		cases := []struct {
+			amount   string
+			coin     db.CoinType
+			expected string
+		}{
+			{amount: "0.1", coin: db.CoinTypeBTC, expected: "10000000"},
+			{amount: "21", coin: db.CoinTypeBTC, expected: "2100000000"},
+			{amount: "0.00000001", coin: db.CoinTypeBTC, expected: "1"},
+			{amount: "0.123456789012", coin: db.CoinTypeXMR, expected: "123456789012"},
+			{amount: "445540.848667137766512794", coin: db.CoinTypeSHIBERC20, expected: "445540848667137766512794"},
+			{amount: "12.34", coin: db.CoinTypeUSDTERC20, expected: "12340000"},
+		}
+
+		for _, c := range cases {
+			units, err := ParseDecimalAmount(c.amount, c.coin)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, units.String())
+		}
+	})
+
+	t.Run("Should Return TooPreciseAmountErr", func(t *testing.T) {
+		_, err := ParseDecimalAmount("0.000000001", db.CoinTypeBTC)
+		assert.ErrorIs(t, err, TooPreciseAmountErr)
+
+		_, err = ParseDecimalAmount("1.0000001", db.CoinTypeUSDTERC20)
+		assert.ErrorIs(t, err, TooPreciseAmountErr)
+	})
+
+	t.Run("Should Return InvalidDecimalAmountErr", func(t *testing.T) {
+		for _, amount := range []string{"", "abc", "1e-8", "0x10", ".5", "1.", "1,5", " 1"} {
+			_, err := ParseDecimalAmount(amount, db.CoinTypeBTC)
+			assert.ErrorIs(t, err, InvalidDecimalAmountErr, amount)
+		}
+	})
+}
+
+func TestFloatToAtomicUnits(t *testing.T) {
+	assert.Equal(t, big.NewInt(10000000), FloatToAtomicUnits(0.1, db.CoinTypeBTC))
+	assert.Equal(t, big.NewInt(300000000000000000), FloatToAtomicUnits(0.3, db.CoinTypeETH))
+	assert.Equal(t, big.NewInt(0), FloatToAtomicUnits(0, db.CoinTypeBTC))
+
+	t.Run("Should Round Up", func(t *testing.T) {
+		assert.Equal(t, big.NewInt(1000001), FloatToAtomicUnits(1.0000001, db.CoinTypeUSDTERC20))
+		assert.Equal(t, big.NewInt(1), FloatToAtomicUnits(0.000000001, db.CoinTypeBTC))
+	})
+}
+
+func TestFormatAtomicUnits(t *testing.T) {
+	cases := []struct {
+		units    *big.Int
+		coin     db.CoinType
+		expected string
+	}{
+		{units: big.NewInt(0), coin: db.CoinTypeBTC, expected: "0"},
+		{units: big.NewInt(1), coin: db.CoinTypeBTC, expected: "0.00000001"},
+		{units: big.NewInt(10000000), coin: db.CoinTypeBTC, expected: "0.1"},
+		{units: big.NewInt(2100000000), coin: db.CoinTypeBTC, expected: "21"},
+		{units: big.NewInt(-150000000), coin: db.CoinTypeBTC, expected: "-1.5"},
+		{units: atomicUnitsOrFatal("445540.848667137766512794", db.CoinTypeETH), coin: db.CoinTypeETH, expected: "445540.848667137766512794"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, FormatAtomicUnits(c.units, c.coin))
+	}
+}
+
+func TestPgNumeric(t *testing.T) {
+	t.Run("Should Convert Both Ways", func(t *testing.T) {
+		units := atomicUnitsOrFatal("445540.848667137766512794", db.CoinTypeETH)
+		assert.Equal(t, units, PgNumericToBigInt(BigIntToPgNumeric(units)))
+	})
+
+	t.Run("Should Apply Exponent", func(t *testing.T) {
+		assert.Equal(t, big.NewInt(1500), PgNumericToBigInt(pgtype.Numeric{Int: big.NewInt(15), Exp: 2, Valid: true}))
+		assert.Equal(t, big.NewInt(15), PgNumericToBigInt(pgtype.Numeric{Int: big.NewInt(1500), Exp: -2, Valid: true}))
+	})
+
+	t.Run("Should Treat Null As Zero", func(t *testing.T) {
+		assert.Equal(t, big.NewInt(0), PgNumericToBigInt(pgtype.Numeric{}))
+		assert.Equal(t, pgtype.Numeric{Int: big.NewInt(0), Valid: true}, BigIntToPgNumeric(nil))
+	})
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"errors"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/chekist32/goipay/internal/db"
+)
+
+const (
+	// version(4) || depth(1) || parent fingerprint(4) || child number(4) || chain code(32) || key(33)
+	extendedKeyPayloadLen int = 78
+)
+
+var (
+	UnknownExtendedKeyVersionErr error = errors.New("unknown version of the extended key")
+
+	// The SLIP-132 versions of the extended public keys, which tell the script type and the network of the account.
+	slip132Versions map[[4]byte]extendedKeyVersion = map[[4]byte]extendedKeyVersion{
+		{0x04, 0x88, 0xb2, 0x1e}: {addressType: db.AddressTypeP2PKH},                     // xpub
+		{0x04, 0x35, 0x87, 0xcf}: {addressType: db.AddressTypeP2PKH, testnet: true},      // tpub
+		{0x01, 0x9d, 0xa4, 0x62}: {addressType: db.AddressTypeP2PKH},                     // Ltub
+		{0x04, 0x36, 0xf6, 0xe1}: {addressType: db.AddressTypeP2PKH, testnet: true},      // ttub
+		{0x04, 0x9d, 0x7c, 0xb2}: {addressType: db.AddressTypeP2SHP2WPKH},                // ypub
+		{0x04, 0x4a, 0x52, 0x62}: {addressType: db.AddressTypeP2SHP2WPKH, testnet: true}, // upub
+		{0x01, 0xb2, 0x6e, 0xf6}: {addressType: db.AddressTypeP2SHP2WPKH},                // Mtub
+		{0x04, 0xb2, 0x47, 0x46}: {addressType: db.AddressTypeP2WPKH},                    // zpub
+		{0x04, 0x5f, 0x1c, 0xf6}: {addressType: db.AddressTypeP2WPKH, testnet: true},     // vpub
+	}
+)
+
+type extendedKeyVersion struct {
+	addressType db.AddressType
+	// Of the testnet, signet and regtest, which share the versions.
+	testnet bool
+}
+
+func findExtendedKeyVersion(key string) (extendedKeyVersion, error) {
+	// The checksum is left to hdkeychain.
+	payload := base58.Decode(key)
+	if len(payload) != extendedKeyPayloadLen+4 {
+		return extendedKeyVersion{}, UnknownExtendedKeyVersionErr
+	}
+
+	version, ok := slip132Versions[[4]byte(payload[:4])]
+	if !ok {
+		return extendedKeyVersion{}, UnknownExtendedKeyVersionErr
+	}
+
+	return version, nil
+}
+
+// ExtendedKeyAddressType returns the address type told by the SLIP-132 prefix of the extended public key.
+func ExtendedKeyAddressType(key string) (db.AddressType, error) {
+	version, err := findExtendedKeyVersion(key)
+	if err != nil {
+		return "", err
+	}
+
+	return version.addressType, nil
+}
+
+// IsTestnetExtendedKey reports whether the SLIP-132 prefix of the extended public key is of a test network (e.g. tpub or vpub).
+func IsTestnetExtendedKey(key string) (bool, error) {
+	version, err := findExtendedKeyVersion(key)
+	if err != nil {
+		return false, err
+	}
+
+	return version.testnet, nil
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtendedKeyAddressType(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Return Address Type Of SLIP-132 Prefix", func(t *testing.T) {
+		cases := []struct {
+			key      string
+			expected db.AddressType
+		}{
+			{key: "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj", expected: db.AddressTypeP2PKH},
+			{key: "tpubDCBWBScQPGv4Xk3JSbhw6wYYpayMjb2eAYyArpbSqQTbLDpphHGAetB6VQgVeftLML8vDSUEWcC2xDi3qJJ3YCDChJDvqVzpgoYSuT52MhJ", expected: db.AddressTypeP2PKH},
+			{key: "Ltub2YEz7qzkZSGcWK8PatiqktKQaGCGVz3ikCarLMHQrzYQKJnvH4upL5zhcF7jnaLM9e1bBzLupY1NN8aZMLYFVgYKvqN5pf6dbLiP3zaCxFw", expected: db.AddressTypeP2PKH},
+			{key: "ypub6Ww3ibxVfGzLrAH1PNcjyAWenMTbbAosGNB6VvmSEgytSER9azLDWCxoJwW7Ke7icmizBMXrzBx9979FfaHxHcrArf3zbeJJJUZPf663zsP", expected: db.AddressTypeP2SHP2WPKH},
+			{key: "Mtub2s5FRWffi7p6McKWRFWTxyQukELiSc3DfK757kBJEzvHNQc9Xj5Nx9eqdT5KnUzGZH8PwTwUHCMvFRC852xGHvDvoB4WQZv7s4n2SVWpEio", expected: db.AddressTypeP2SHP2WPKH},
+			{key: "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs", expected: db.AddressTypeP2WPKH},
+			{key: "vpub5Y9M3sStNdseZMUDKh9bUr2B9XaorxYanhLRwUkXjUwwUjXf7k5xGKBzCxgY8MdFk9NQ6umF4BetVTzK13cn4HPhu25mz9hcZPpkNEQ4Gjp", expected: db.AddressTypeP2WPKH},
+		}
+
+		for i := 0; i < len(cases); i++ {
+			addressType, err := ExtendedKeyAddressType(cases[i].key)
+			assert.NoError(t, err)
+			assert.Equal(t, cases[i].expected, addressType, cases[i].key)
+		}
+	})
+
+	t.Run("Should Return Error (unknown version)", func(t *testing.T) {
+		// xprv
+		_, err := ExtendedKeyAddressType("xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi")
+		assert.ErrorIs(t, err, UnknownExtendedKeyVersionErr)
+
+		_, err = ExtendedKeyAddressType("xpub6BosfCnifzxcFwrSzQiqu2DB")
+		assert.ErrorIs(t, err, UnknownExtendedKeyVersionErr)
+	})
+}
+
+func TestIsTestnetExtendedKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Tell Network Of SLIP-132 Prefix", func(t *testing.T) {
+		cases := []struct {
+			key      string
+			expected bool
+		}{
+			{key: "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj", expected: false},
+			{key: "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs", expected: false},
+			{key: "Mtub2s5FRWffi7p6McKWRFWTxyQukELiSc3DfK757kBJEzvHNQc9Xj5Nx9eqdT5KnUzGZH8PwTwUHCMvFRC852xGHvDvoB4WQZv7s4n2SVWpEio", expected: false},
+			{key: "tpubDCBWBScQPGv4Xk3JSbhw6wYYpayMjb2eAYyArpbSqQTbLDpphHGAetB6VQgVeftLML8vDSUEWcC2xDi3qJJ3YCDChJDvqVzpgoYSuT52MhJ", expected: true},
+			{key: "vpub5Y9M3sStNdseZMUDKh9bUr2B9XaorxYanhLRwUkXjUwwUjXf7k5xGKBzCxgY8MdFk9NQ6umF4BetVTzK13cn4HPhu25mz9hcZPpkNEQ4Gjp", expected: true},
+		}
+
+		for i := 0; i < len(cases); i++ {
+			testnet, err := IsTestnetExtendedKey(cases[i].key)
+			assert.NoError(t, err)
+			assert.Equal(t, cases[i].expected, testnet, cases[i].key)
+		}
+	})
+
+	t.Run("Should Return Error (unknown version)", func(t *testing.T) {
+		_, err := IsTestnetExtendedKey("xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi")
+		assert.ErrorIs(t, err, UnknownExtendedKeyVersionErr)
+	})
+}
@@ -8,27 +8,129 @@ import (
 type contextKey string
 
 const (
-	MIN_SYNC_TIMEOUT     time.Duration = 10 * time.Second
-	SEND_TIMEOUT         time.Duration = 10 * time.Second
-	HEALTH_CHECK_TIEMOUT time.Duration = 5 * time.Second
+	MIN_SYNC_TIMEOUT      time.Duration = 10 * time.Second
+	SEND_TIMEOUT          time.Duration = 10 * time.Second
+	HEALTH_CHECK_TIEMOUT  time.Duration = 5 * time.Second
+	RATE_PROVIDER_TIMEOUT time.Duration = 10 * time.Second
+	TON_DAEMON_TIMEOUT    time.Duration = 10 * time.Second
+	TRX_DAEMON_TIMEOUT    time.Duration = 10 * time.Second
+
+	DEFAULT_LATE_PAYMENT_GRACE_WINDOW time.Duration = 24 * time.Hour
+
+	// How long after the confirmation an invoice is still rechecked on a chain reorganization.
+	REORG_RECHECK_WINDOW time.Duration = 24 * time.Hour
+	// The minimum time left to the payer to get the tx back into the chain after a rollback.
+	REORG_ROLLBACK_MIN_TIMEOUT time.Duration = time.Hour
+
+	WEBHOOK_POLL_INTERVAL    time.Duration = 5 * time.Second
+	WEBHOOK_DELIVERY_TIMEOUT time.Duration = 10 * time.Second
+	WEBHOOK_DELIVERY_LEASE   time.Duration = time.Minute
+	WEBHOOK_RETRY_BASE_DELAY time.Duration = 10 * time.Second
+	WEBHOOK_RETRY_MAX_DELAY  time.Duration = 6 * time.Hour
+)
+
+const (
+	LIST_INVOICES_DEFAULT_LIMIT uint32 = 50
+	LIST_INVOICES_MAX_LIMIT     uint32 = 1000
+
+	INVOICE_STREAM_REPLAY_BATCH_SIZE = 500
+	// How many events a stream subscriber may lag behind before the SEND_TIMEOUT starts counting.
+	INVOICE_STREAM_SUBSCRIBER_BUFFER_SIZE = 256
+
+	FIAT_CONVERSION_MAX_DECIMALS = 8
+
+	IDEMPOTENCY_KEY_MAX_LENGTH = 255
+
+	INVOICE_DESCRIPTION_MAX_LENGTH       = 1024
+	INVOICE_EXTERNAL_ORDER_ID_MAX_LENGTH = 255
+	INVOICE_METADATA_MAX_KEYS            = 50
+	INVOICE_METADATA_KEY_MAX_LENGTH      = 40
+	INVOICE_METADATA_VALUE_MAX_LENGTH    = 500
+
+	// How many of the most recent block hashes are kept per coin, i.e. the deepest detectable reorg.
+	REORG_DETECTION_WINDOW = 100
+
+	WEBHOOK_DELIVERY_BATCH_SIZE = 50
+	WEBHOOK_MAX_ATTEMPTS        = 15
+
+	// The page size of the toncenter API requests.
+	TON_DAEMON_PAGE_LIMIT = 256
+
+	LIST_WEBHOOK_DELIVERIES_DEFAULT_LIMIT uint32 = 50
+	LIST_WEBHOOK_DELIVERIES_MAX_LIMIT     uint32 = 1000
+
+	// The maximum of both the gap limit and the free addresses of an address pool.
+	ADDRESS_POOL_MAX_SIZE = 1000
+	// How many retired addresses still occupied a single derivation may skip, e.g. after switching back to a previous key.
+	MAX_SKIPPED_RETIRED_ADDRESSES = 100
 )
 
 const (
 	DefaultFailedSqlTxInitMsg                    string = "An error occurred while initiating an SQL transaction."
+	DefaultFailedSqlTxCommitMsg                  string = "An error occurred while committing an SQL transaction."
 	DefaultFailedSqlQueryMsg                     string = "An error occurred while executing a SQL query."
 	DefaultFailedScanningToPostgresqlDataTypeMsg string = "An error occurred while scanning the value into a PostgreSQL data type."
 	DefaultFailedFetchingDaemonMsg               string = "An error occurred while fetching."
 
-	FailedStringToPgUUIDMappingMsg string = "An error occurred while converting the string to the PostgreSQL UUID data type."
+	FailedStringToPgUUIDMappingMsg    string = "An error occurred while converting the string to the PostgreSQL UUID data type."
+	FailedWebhookEnqueueMsg           string = "An error occurred while enqueueing the webhook delivery."
+	FailedWebhookSecretGenerationMsg  string = "An error occurred while generating the webhook secret."
+	FailedGroupSiblingCancellationMsg string = "An error occurred while cancelling the sibling invoice of the paid group."
+	ReorgedInvoiceSlotTakenMsg        string = "The address of the rolled back invoice is in use by another invoice, the invoice has been expired."
 
 	InvalidUserIdInvalidUUIDMsg      string = "Invalid userId (invalid UUID)."
 	InvalidUserIdUserExistsMsg       string = "Invalid userId (user exists)."
 	InvalidUserIdUserDoesNotExistMsg string = "Invalid userId (user does not exist)."
 
-	InvoiceAmountBelow0ErrorMsg      string = "Invoice amount can't be below 0."
-	InvoiceErrorWhileHandlingMsg     string = "An error occurred while handling invoice."
-	InvoiceStreamSendingDataErrorMsg string = "An error occured while sending data."
-	InvoiceStreamClosedErrorMsg      string = "Stream has been closed."
+	InvoiceAmountBelow0ErrorMsg         string = "Invoice amount can't be below 0."
+	InvalidExactAmountMsg               string = "Invalid exactAmount (must be a decimal number like 0.1 with no more decimal places than the coin has)."
+	InvalidFiatCurrencyMsg              string = "Invalid fiat currency (must be an ISO 4217 code)."
+	FiatInvoicesUnsupportedMsg          string = "Fiat invoices are not supported (no exchange rate provider is configured)."
+	InvalidUnderpaymentToleranceMsg     string = "Invalid underpayment tolerance (the percentage must be within [0, 100], the amount within [0, invoice amount])."
+	InvalidIdempotencyKeyMsg            string = "Invalid idempotency key (must be non-empty and at most 255 characters long)."
+	IdempotencyKeyReusedMsg             string = "Idempotency key has already been used with different parameters."
+	InvalidInvoiceDescriptionMsg        string = "Invalid description (must be at most 1024 characters long)."
+	InvalidExternalOrderIdMsg           string = "Invalid externalOrderId (must be at most 255 characters long)."
+	InvalidInvoiceMetadataMsg           string = "Invalid metadata (at most 50 keys of at most 40 characters, values of at most 500 characters)."
+	InvalidQrCodeFormatMsg              string = "Invalid QR code format."
+	InvalidCoinOptionsMsg               string = "Invalid coinOptions (each coin must be valid and appear only once, amounts can't be below 0 or more precise than the coin)."
+	InvalidInvoiceGroupIdInvalidUUIDMsg string = "Invalid groupId (invalid UUID)."
+	InvoiceErrorWhileHandlingMsg        string = "An error occurred while handling invoice."
+	PaymentUriErrorWhileBuildingMsg     string = "An error occurred while building payment uri."
+	InvoiceStreamSendingDataErrorMsg    string = "An error occured while sending data."
+	InvoiceStreamClosedErrorMsg         string = "Stream has been closed."
+	InvoiceStreamFellBehindErrorMsg     string = "Stream has fallen behind the invoice updates, resume it from the last received seq."
+
+	InvalidInvoiceIdInvalidUUIDMsg string = "Invalid invoice id (invalid UUID)."
+	InvoiceNotFoundMsg             string = "Invoice not found."
+	InvalidCoinTypeMsg             string = "Invalid coin type."
+	InvalidInvoiceStatusTypeMsg    string = "Invalid invoice status type."
+	InvoiceListLimitExceededMsg    string = "Invoice list limit exceeded."
+	InvoiceNotCancellableMsg       string = "Only pending invoices can be cancelled."
+
+	InvalidTONWalletAddressMsg    string = "Invalid TON wallet address."
+	InvalidEVMChainMsg            string = "Unknown EVM chain (it must be declared in the config)."
+	InvalidAddressTypeMsg         string = "Invalid address type (set it explicitly unless the master public key is an xpub, ypub or zpub)."
+	PrivateMasterKeyMsg           string = "Invalid %v master public key (private extended keys are never accepted, provide the xpub of the account instead)."
+	MasterPubKeyDepthMsg          string = "Invalid %v master public key (must be the account-level key, e.g. of m/84'/0'/0')."
+	MasterPubKeyNetworkMsg        string = "Invalid %v master public key (its version doesn't match the daemon network, testnets take tpub, upub or vpub keys)."
+	UnknownMasterPubKeyVersionMsg string = "Invalid %v master public key (unknown SLIP-132 version, e.g. xpub, ypub or zpub are expected)."
+	XMRPrivViewKeyMsg             string = "Invalid XMR private view key (must be a reduced scalar)."
+	XMRPubSpendKeyMsg             string = "Invalid XMR public spend key (must be a point of the curve)."
+
+	InvalidAddressReusePolicyMsg  string = "Invalid address reuse policy."
+	InvalidAddressPoolConfigMsg   string = "Invalid address pool config (both values must be at most 1000 and minFreeAddresses can't exceed a set gapLimit)."
+	AddressPoolUnsupportedCoinMsg string = "The coin has no address pool (it must derive its addresses and be set up)."
+	AddressGapLimitReachedMsg     string = "The address gap limit has been reached (no address derived after the last funded one is free)."
+	RetiredAddressesOccupiedMsg   string = "Too many addresses derived in a row are still occupied by the invoices of a previous key, retry once they are done."
+
+	InvalidWebhookUrlMsg                   string = "Invalid webhook url (must be an absolute http(s) url)."
+	InvalidWebhookSecretMsg                string = "Invalid webhook secret (must not be empty nor set along with rotateSecret)."
+	WebhookEndpointNotFoundMsg             string = "Webhook endpoint not found."
+	InvalidWebhookDeliveryIdInvalidUUIDMsg string = "Invalid webhook delivery id (invalid UUID)."
+	InvalidWebhookDeliveryStatusTypeMsg    string = "Invalid webhook delivery status type."
+	WebhookDeliveryNotFoundMsg             string = "Webhook delivery not found."
+	WebhookDeliveryListLimitExceededMsg    string = "Webhook delivery list limit exceeded."
 )
 
 const (
@@ -41,9 +143,14 @@ const (
 )
 
 var (
-	invalidProtoBufCoinTypeErr error = errors.New("invalid protoBuf coin type")
-	invalidDbCoinTypeErr       error = errors.New("invalid db coin type")
-	invalidDbStatusTypeErr     error = errors.New("invalid db status type")
+	invalidProtoBufCoinTypeErr    error = errors.New("invalid protoBuf coin type")
+	invalidDbCoinTypeErr          error = errors.New("invalid db coin type")
+	invalidCoinCodeErr            error = errors.New("invalid coin code")
+	invalidDbStatusTypeErr        error = errors.New("invalid db status type")
+	invalidProtoBufStatusTypeErr  error = errors.New("invalid protoBuf status type")
+	invalidProtoBufAddressTypeErr error = errors.New("invalid protoBuf address type")
+
+	invalidProtoBufAddressReusePolicyErr error = errors.New("invalid protoBuf address reuse policy")
 
 	InvalidNetworkTypeErr error = errors.New("invalid network type")
 )
@@ -0,0 +1,85 @@
+package util
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/chekist32/goipay/internal/db"
+)
+
+var (
+	InvalidEVMChainErr       error = errors.New("invalid EVM chain")
+	InvalidEVMTokenErr       error = errors.New("invalid EVM token")
+	CoinAlreadyRegisteredErr error = errors.New("coin is already registered")
+
+	// The chain names and the token symbols have no underscores, so the token coins (e.g. USDC_POLYGON) are unambiguous.
+	evmNameRegexp *regexp.Regexp = regexp.MustCompile(`^[A-Z][A-Z0-9]{0,29}$`)
+
+	// The token standards of the built-in chains, which name their token coins instead of the chain (e.g. USDT_ERC20).
+	evmTokenStandards map[db.CoinType]string = map[db.CoinType]string{
+		db.CoinTypeETH: "ERC20",
+		db.CoinTypeBNB: "BEP20",
+	}
+
+	evmChains map[db.CoinType]bool = map[db.CoinType]bool{}
+)
+
+// EVMTokenCoin returns the coin of the token of an ETH compatible chain, e.g. USDT_ERC20 or USDC_POLYGON.
+func EVMTokenCoin(symbol string, chain db.CoinType) db.CoinType {
+	if standard, ok := evmTokenStandards[chain]; ok {
+		return db.CoinType(symbol + "_" + standard)
+	}
+	return db.CoinType(symbol + "_" + string(chain))
+}
+
+// RegisterEVMChain adds the native coin of an EVM chain declared in the config (named after the chain) to the known coins.
+// It isn't safe for concurrent use, so it must be called before the processing starts.
+func RegisterEVMChain(chain db.CoinType, decimals int32) error {
+	// The atomic units are stored as NUMERIC(78, 0).
+	if !evmNameRegexp.MatchString(string(chain)) || decimals < 0 || decimals > 77 {
+		return InvalidEVMChainErr
+	}
+	if _, ok := evmTokenStandards[chain]; ok || IsKnownCoin(chain) {
+		return CoinAlreadyRegisteredErr
+	}
+
+	coinDecimals[chain] = decimals
+	evmChains[chain] = true
+
+	return nil
+}
+
+// RegisterEVMToken adds the token of an ETH compatible chain from the config to the known coins and returns its coin.
+// The coins predating the naming rules, e.g. BSC-USD_BEP20, are accepted as they are already values of coin_type.
+// It isn't safe for concurrent use, so it must be called before the processing starts.
+func RegisterEVMToken(symbol string, chain db.CoinType, decimals int32) (db.CoinType, error) {
+	coin := EVMTokenCoin(symbol, chain)
+	if !evmNameRegexp.MatchString(symbol) && !IsKnownCoin(coin) {
+		return "", InvalidEVMTokenErr
+	}
+
+	if err := RegisterEVMTokenCoin(coin, decimals); err != nil {
+		return "", err
+	}
+
+	return coin, nil
+}
+
+// RegisterEVMTokenCoin adds the coin of a token of the token registry to the known coins.
+// The decimals of a known token are overridden, since a testnet deployment may differ from the mainnet one.
+// It isn't safe for concurrent use, so it must be called before the processing starts.
+func RegisterEVMTokenCoin(coin db.CoinType, decimals int32) error {
+	// The atomic units are stored as NUMERIC(78, 0).
+	if decimals < 0 || decimals > 77 {
+		return InvalidEVMTokenErr
+	}
+
+	coinDecimals[coin] = decimals
+
+	return nil
+}
+
+// IsEVMChain reports whether the coin is the native one of an EVM chain declared in the config.
+func IsEVMChain(coin db.CoinType) bool {
+	return evmChains[coin]
+}
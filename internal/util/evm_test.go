@@ -0,0 +1,88 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterEVMChain(t *testing.T) {
+	t.Run("Should Register Chain", func(t *testing.T) {
+		chain := db.CoinType("TESTPOLYGON")
+
+		assert.NoError(t, RegisterEVMChain(chain, 18))
+		assert.True(t, IsEVMChain(chain))
+		assert.False(t, IsEVMChain(db.CoinTypeETH))
+		assert.Equal(t, int32(18), CoinDecimals(chain))
+
+		assert.ErrorIs(t, RegisterEVMChain(chain, 18), CoinAlreadyRegisteredErr)
+	})
+
+	t.Run("Should Return Error (built-in coin)", func(t *testing.T) {
+		assert.ErrorIs(t, RegisterEVMChain(db.CoinTypeETH, 18), CoinAlreadyRegisteredErr)
+		assert.ErrorIs(t, RegisterEVMChain(db.CoinTypeTRX, 6), CoinAlreadyRegisteredErr)
+	})
+
+	t.Run("Should Return Error (invalid name or decimals)", func(t *testing.T) {
+		assert.ErrorIs(t, RegisterEVMChain("test_base", 18), InvalidEVMChainErr)
+		assert.ErrorIs(t, RegisterEVMChain("TESTBASE", -1), InvalidEVMChainErr)
+		assert.ErrorIs(t, RegisterEVMChain("TESTBASE", 78), InvalidEVMChainErr)
+		assert.ErrorIs(t, RegisterEVMChain("TESTBASE'", 18), InvalidEVMChainErr)
+		assert.False(t, IsEVMChain("TESTBASE"))
+	})
+}
+
+func TestRegisterEVMTokenCoin(t *testing.T) {
+	t.Run("Should Register Token Coin", func(t *testing.T) {
+		assert.NoError(t, RegisterEVMTokenCoin("TESTUSDT_BEP20", 18))
+		assert.True(t, IsKnownCoin("TESTUSDT_BEP20"))
+		assert.Equal(t, int32(18), CoinDecimals("TESTUSDT_BEP20"))
+	})
+
+	t.Run("Should Return Error (invalid decimals)", func(t *testing.T) {
+		assert.ErrorIs(t, RegisterEVMTokenCoin("TESTUSDT_BEP20", 78), InvalidEVMTokenErr)
+	})
+}
+
+func TestRegisterEVMToken(t *testing.T) {
+	t.Run("Should Register Token", func(t *testing.T) {
+		coin, err := RegisterEVMToken("TESTUSD", db.CoinTypeETH, 6)
+		assert.NoError(t, err)
+		assert.Equal(t, db.CoinType("TESTUSD_ERC20"), coin)
+		assert.True(t, IsKnownCoin(coin))
+		assert.Equal(t, int32(6), CoinDecimals(coin))
+
+		coin, err = RegisterEVMToken("TESTUSD", "TESTGNOSIS", 18)
+		assert.NoError(t, err)
+		assert.Equal(t, db.CoinType("TESTUSD_TESTGNOSIS"), coin)
+		assert.Equal(t, int32(18), CoinDecimals(coin))
+	})
+
+	t.Run("Should Override Decimals Of Known Token", func(t *testing.T) {
+		coin, err := RegisterEVMToken("TESTDAI", db.CoinTypeBNB, 18)
+		assert.NoError(t, err)
+		assert.Equal(t, db.CoinType("TESTDAI_BEP20"), coin)
+
+		_, err = RegisterEVMToken("TESTDAI", db.CoinTypeBNB, 6)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(6), CoinDecimals(coin))
+	})
+
+	t.Run("Should Register Legacy Token", func(t *testing.T) {
+		coin, err := RegisterEVMToken("BSC-USD", db.CoinTypeBNB, 18)
+		assert.NoError(t, err)
+		assert.Equal(t, db.CoinTypeBSCUSDBEP20, coin)
+	})
+
+	t.Run("Should Return Error (invalid symbol or decimals)", func(t *testing.T) {
+		_, err := RegisterEVMToken("TEST-USD", db.CoinTypeBNB, 18)
+		assert.ErrorIs(t, err, InvalidEVMTokenErr)
+		_, err = RegisterEVMToken("USD_C", db.CoinTypeETH, 6)
+		assert.ErrorIs(t, err, InvalidEVMTokenErr)
+		_, err = RegisterEVMToken("usdc", db.CoinTypeETH, 6)
+		assert.ErrorIs(t, err, InvalidEVMTokenErr)
+		_, err = RegisterEVMToken("USDC", db.CoinTypeETH, 78)
+		assert.ErrorIs(t, err, InvalidEVMTokenErr)
+	})
+}
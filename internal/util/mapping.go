@@ -1,7 +1,10 @@
 package util
 
 import (
+	"encoding/json"
 	"math"
+	"math/big"
+	"strings"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
@@ -118,11 +121,37 @@ func PbCoinToDbCoin(coin pb_v1.CoinType) (db.CoinType, error) {
 		return db.CoinTypeAVAXBEP20, nil
 	case pb_v1.CoinType_CAKE_BEP20:
 		return db.CoinTypeCAKEBEP20, nil
+
+	// Jettons
+	case pb_v1.CoinType_USDT_TON:
+		return db.CoinTypeUSDTTON, nil
+
+	case pb_v1.CoinType_TRX:
+		return db.CoinTypeTRX, nil
+	// TRC20
+	case pb_v1.CoinType_USDT_TRC20:
+		return db.CoinTypeUSDTTRC20, nil
+	case pb_v1.CoinType_USDC_TRC20:
+		return db.CoinTypeUSDCTRC20, nil
 	}
 
 	return "", invalidProtoBufCoinTypeErr
 }
 
+// PbCoinOrCodeToDbCoin prefers the coin code if it's set, as the coins of the EVM chains declared in the config have no protoBuf coin type.
+func PbCoinOrCodeToDbCoin(coin pb_v1.CoinType, code *string) (db.CoinType, error) {
+	if code == nil {
+		return PbCoinToDbCoin(coin)
+	}
+
+	dbCoin := db.CoinType(strings.ToUpper(*code))
+	if !IsKnownCoin(dbCoin) {
+		return "", invalidCoinCodeErr
+	}
+
+	return dbCoin, nil
+}
+
 func DbCoinToPbCoin(coin db.CoinType) (pb_v1.CoinType, error) {
 	switch coin {
 	case db.CoinTypeXMR:
@@ -213,6 +242,18 @@ func DbCoinToPbCoin(coin db.CoinType) (pb_v1.CoinType, error) {
 		return pb_v1.CoinType_AVAX_BEP20, nil
 	case db.CoinTypeCAKEBEP20:
 		return pb_v1.CoinType_CAKE_BEP20, nil
+
+	// Jettons
+	case db.CoinTypeUSDTTON:
+		return pb_v1.CoinType_USDT_TON, nil
+
+	case db.CoinTypeTRX:
+		return pb_v1.CoinType_TRX, nil
+	// TRC20
+	case db.CoinTypeUSDTTRC20:
+		return pb_v1.CoinType_USDT_TRC20, nil
+	case db.CoinTypeUSDCTRC20:
+		return pb_v1.CoinType_USDC_TRC20, nil
 	}
 
 	return math.MaxInt32, invalidDbCoinTypeErr
@@ -228,39 +269,266 @@ func DbInvoiceStatusToPbInvoiceStatus(status db.InvoiceStatusType) (pb_v1.Invoic
 		return pb_v1.InvoiceStatusType_CONFIRMED, nil
 	case db.InvoiceStatusTypeEXPIRED:
 		return pb_v1.InvoiceStatusType_EXPIRED, nil
+	case db.InvoiceStatusTypeCANCELLED:
+		return pb_v1.InvoiceStatusType_CANCELLED, nil
+	case db.InvoiceStatusTypePARTIALLYPAID:
+		return pb_v1.InvoiceStatusType_PARTIALLY_PAID, nil
+	case db.InvoiceStatusTypePAIDAFTEREXPIRY:
+		return pb_v1.InvoiceStatusType_PAID_AFTER_EXPIRY, nil
 	}
 
 	return math.MaxInt32, invalidDbStatusTypeErr
 }
 
+func PbInvoiceStatusToDbInvoiceStatus(status pb_v1.InvoiceStatusType) (db.InvoiceStatusType, error) {
+	switch status {
+	case pb_v1.InvoiceStatusType_PENDING:
+		return db.InvoiceStatusTypePENDING, nil
+	case pb_v1.InvoiceStatusType_PENDING_MEMPOOL:
+		return db.InvoiceStatusTypePENDINGMEMPOOL, nil
+	case pb_v1.InvoiceStatusType_CONFIRMED:
+		return db.InvoiceStatusTypeCONFIRMED, nil
+	case pb_v1.InvoiceStatusType_EXPIRED:
+		return db.InvoiceStatusTypeEXPIRED, nil
+	case pb_v1.InvoiceStatusType_CANCELLED:
+		return db.InvoiceStatusTypeCANCELLED, nil
+	case pb_v1.InvoiceStatusType_PARTIALLY_PAID:
+		return db.InvoiceStatusTypePARTIALLYPAID, nil
+	case pb_v1.InvoiceStatusType_PAID_AFTER_EXPIRY:
+		return db.InvoiceStatusTypePAIDAFTEREXPIRY, nil
+	}
+
+	return "", invalidProtoBufStatusTypeErr
+}
+
+func AtomicUnitsToPbAmount(units *big.Int, coin db.CoinType) *pb_v1.Amount {
+	return &pb_v1.Amount{Decimal: FormatAtomicUnits(units, coin), AtomicUnits: units.String()}
+}
+
 func DbInvoiceToPbInvoice(invoice *db.Invoice) *pb_v1.Invoice {
 	coin, _ := DbCoinToPbCoin(invoice.Coin)
 	status, _ := DbInvoiceStatusToPbInvoiceStatus(invoice.Status)
 
-	return &pb_v1.Invoice{
-		Id:                    PgUUIDToString(invoice.ID),
-		CryptoAddress:         invoice.CryptoAddress,
-		Coin:                  coin,
-		RequiredAmount:        invoice.RequiredAmount,
-		ActualAmount:          invoice.ActualAmount.Float64,
-		ConfirmationsRequired: uint32(invoice.ConfirmationsRequired),
-		CreatedAt:             timestamppb.New(invoice.CreatedAt.Time),
-		ConfirmedAt:           timestamppb.New(invoice.ConfirmedAt.Time),
-		Status:                status,
-		ExpiresAt:             timestamppb.New(invoice.ExpiresAt.Time),
-		TxId:                  invoice.TxID.String,
-		UserId:                PgUUIDToString(invoice.UserID),
+	requiredAmount := PgNumericToBigInt(invoice.RequiredAmount)
+	actualAmount := PgNumericToBigInt(invoice.ActualAmount)
+	underpaymentTolerance := PgNumericToBigInt(invoice.UnderpaymentTolerance)
+	overpaidAmount := new(big.Int).Sub(actualAmount, requiredAmount)
+	if overpaidAmount.Sign() < 0 {
+		overpaidAmount.SetInt64(0)
+	}
+
+	pbInvoice := &pb_v1.Invoice{
+		Id:                         PgUUIDToString(invoice.ID),
+		CryptoAddress:              invoice.CryptoAddress,
+		Coin:                       coin,
+		CoinCode:                   string(invoice.Coin),
+		RequiredAmount:             AtomicUnitsToFloat64(requiredAmount, invoice.Coin),
+		ActualAmount:               AtomicUnitsToFloat64(actualAmount, invoice.Coin),
+		ConfirmationsRequired:      uint32(invoice.ConfirmationsRequired),
+		Confirmations:              uint32(invoice.Confirmations),
+		CreatedAt:                  timestamppb.New(invoice.CreatedAt.Time),
+		ConfirmedAt:                timestamppb.New(invoice.ConfirmedAt.Time),
+		Status:                     status,
+		ExpiresAt:                  timestamppb.New(invoice.ExpiresAt.Time),
+		TxId:                       invoice.TxID.String,
+		UserId:                     PgUUIDToString(invoice.UserID),
+		UnderpaymentTolerance:      AtomicUnitsToFloat64(underpaymentTolerance, invoice.Coin),
+		OverpaidAmount:             AtomicUnitsToFloat64(overpaidAmount, invoice.Coin),
+		Description:                invoice.Description.String,
+		ExternalOrderId:            invoice.ExternalOrderID.String,
+		GroupId:                    PgUUIDToString(invoice.GroupID),
+		RequiredAmountExact:        AtomicUnitsToPbAmount(requiredAmount, invoice.Coin),
+		ActualAmountExact:          AtomicUnitsToPbAmount(actualAmount, invoice.Coin),
+		UnderpaymentToleranceExact: AtomicUnitsToPbAmount(underpaymentTolerance, invoice.Coin),
+		OverpaidAmountExact:        AtomicUnitsToPbAmount(overpaidAmount, invoice.Coin),
+	}
+	// The column is NOT NULL and always holds a JSON object of strings.
+	json.Unmarshal(invoice.Metadata, &pbInvoice.Metadata)
+	if invoice.FiatAmount.Valid {
+		pbInvoice.FiatAmount = &invoice.FiatAmount.Float64
+	}
+	if invoice.FiatCurrency.Valid {
+		pbInvoice.FiatCurrency = &invoice.FiatCurrency.String
+	}
+	if invoice.ExchangeRate.Valid {
+		pbInvoice.ExchangeRate = &invoice.ExchangeRate.Float64
+	}
+	if invoice.Memo.Valid {
+		pbInvoice.Memo = &invoice.Memo.String
+	}
+
+	return pbInvoice
+}
+
+// The coin is the one of the invoice the event belongs to, the amount is denominated in it.
+func DbInvoiceEventToPbInvoiceEvent(event *db.InvoiceEvent, coin db.CoinType) *pb_v1.InvoiceEvent {
+	newStatus, _ := DbInvoiceStatusToPbInvoiceStatus(event.NewStatus)
+
+	pbEvent := &pb_v1.InvoiceEvent{
+		NewStatus: newStatus,
+		TxId:      event.TxID.String,
+		CreatedAt: timestamppb.New(event.CreatedAt.Time),
+		Reorg:     event.Reorg,
+	}
+	if event.Amount.Valid {
+		amount := PgNumericToBigInt(event.Amount)
+		pbEvent.Amount = AtomicUnitsToFloat64(amount, coin)
+		pbEvent.AmountExact = AtomicUnitsToPbAmount(amount, coin)
+	}
+	if event.OldStatus.Valid {
+		oldStatus, _ := DbInvoiceStatusToPbInvoiceStatus(event.OldStatus.InvoiceStatusType)
+		pbEvent.OldStatus = &oldStatus
 	}
+	if event.Confirmations.Valid {
+		confirmations := uint64(event.Confirmations.Int64)
+		pbEvent.Confirmations = &confirmations
+	}
+
+	return pbEvent
+}
+
+func DbWebhookDeliveryStatusToPbWebhookDeliveryStatus(status db.WebhookDeliveryStatusType) (pb_v1.WebhookDeliveryStatusType, error) {
+	switch status {
+	case db.WebhookDeliveryStatusTypePENDING:
+		return pb_v1.WebhookDeliveryStatusType_PENDING, nil
+	case db.WebhookDeliveryStatusTypeDELIVERED:
+		return pb_v1.WebhookDeliveryStatusType_DELIVERED, nil
+	case db.WebhookDeliveryStatusTypeDEAD:
+		return pb_v1.WebhookDeliveryStatusType_DEAD, nil
+	}
+
+	return math.MaxInt32, invalidDbStatusTypeErr
+}
+
+func PbWebhookDeliveryStatusToDbWebhookDeliveryStatus(status pb_v1.WebhookDeliveryStatusType) (db.WebhookDeliveryStatusType, error) {
+	switch status {
+	case pb_v1.WebhookDeliveryStatusType_PENDING:
+		return db.WebhookDeliveryStatusTypePENDING, nil
+	case pb_v1.WebhookDeliveryStatusType_DELIVERED:
+		return db.WebhookDeliveryStatusTypeDELIVERED, nil
+	case pb_v1.WebhookDeliveryStatusType_DEAD:
+		return db.WebhookDeliveryStatusTypeDEAD, nil
+	}
+
+	return "", invalidProtoBufStatusTypeErr
+}
+
+// PbAddressTypeToDbAddressType takes the address type from the SLIP-132 prefix of the master public key unless it is set explicitly.
+func PbAddressTypeToDbAddressType(addressType pb_v1.AddressType, masterPubKey string) (db.AddressType, error) {
+	switch addressType {
+	case pb_v1.AddressType_ADDRESS_TYPE_AUTO:
+		return ExtendedKeyAddressType(masterPubKey)
+	case pb_v1.AddressType_ADDRESS_TYPE_P2PKH:
+		return db.AddressTypeP2PKH, nil
+	case pb_v1.AddressType_ADDRESS_TYPE_P2SH_P2WPKH:
+		return db.AddressTypeP2SHP2WPKH, nil
+	case pb_v1.AddressType_ADDRESS_TYPE_P2WPKH:
+		return db.AddressTypeP2WPKH, nil
+	case pb_v1.AddressType_ADDRESS_TYPE_P2TR:
+		return db.AddressTypeP2TR, nil
+	}
+
+	return "", invalidProtoBufAddressTypeErr
+}
+
+func PbAddressReusePolicyToDbAddressReusePolicy(policy pb_v1.AddressReusePolicy) (db.AddressReusePolicy, error) {
+	switch policy {
+	case pb_v1.AddressReusePolicy_ADDRESS_REUSE_POLICY_REUSE:
+		return db.AddressReusePolicyREUSE, nil
+	case pb_v1.AddressReusePolicy_ADDRESS_REUSE_POLICY_NEVER_REUSE:
+		return db.AddressReusePolicyNEVERREUSE, nil
+	case pb_v1.AddressReusePolicy_ADDRESS_REUSE_POLICY_REUSE_UNFUNDED:
+		return db.AddressReusePolicyREUSEUNFUNDED, nil
+	}
+
+	return "", invalidProtoBufAddressReusePolicyErr
+}
+
+func DbWebhookDeliveryToPbWebhookDelivery(delivery *db.WebhookDelivery) *pb_v1.WebhookDelivery {
+	status, _ := DbWebhookDeliveryStatusToPbWebhookDeliveryStatus(delivery.Status)
+
+	return &pb_v1.WebhookDelivery{
+		Id:            PgUUIDToString(delivery.ID),
+		UserId:        PgUUIDToString(delivery.UserID),
+		InvoiceId:     PgUUIDToString(delivery.InvoiceID),
+		Status:        status,
+		Attempts:      uint32(delivery.Attempts),
+		NextAttemptAt: timestamppb.New(delivery.NextAttemptAt.Time),
+		LastError:     delivery.LastError.String,
+		CreatedAt:     timestamppb.New(delivery.CreatedAt.Time),
+		DeliveredAt:   timestamppb.New(delivery.DeliveredAt.Time),
+		Payload:       string(delivery.Payload),
+	}
+}
+
+func PbTimestampToPgTimestamptz(t *timestamppb.Timestamp) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+
+	return pgtype.Timestamptz{Time: t.AsTime(), Valid: true}
+}
+
+// pbAmountToAtomicUnits prefers the exact amount, which is expected to be validated already, over the floating point one.
+func pbAmountToAtomicUnits(amount float64, exactAmount *string, coin db.CoinType) *big.Int {
+	if exactAmount != nil {
+		units, err := ParseDecimalAmount(*exactAmount, coin)
+		if err != nil {
+			return new(big.Int)
+		}
+		return units
+	}
+
+	return FloatToAtomicUnits(amount, coin)
 }
 
 func PbNewInvoiceToProcessorNewInvoice(req *pb_v1.CreateInvoiceRequest) *dto.NewInvoiceRequest {
-	coin, _ := PbCoinToDbCoin(req.Coin)
-
-	return &dto.NewInvoiceRequest{
-		UserId:        req.UserId,
-		Coin:          coin,
-		Amount:        req.Amount,
-		Timeout:       req.Timeout,
-		Confirmations: req.Confirmations,
+	coin, _ := PbCoinOrCodeToDbCoin(req.Coin, req.CoinCode)
+
+	newInvoice := &dto.NewInvoiceRequest{
+		UserId:          req.UserId,
+		Coin:            coin,
+		Amount:          pbAmountToAtomicUnits(req.Amount, req.ExactAmount, coin),
+		Timeout:         req.Timeout,
+		Confirmations:   req.Confirmations,
+		IdempotencyKey:  req.GetIdempotencyKey(),
+		Description:     req.Description,
+		ExternalOrderId: req.ExternalOrderId,
+		Metadata:        req.Metadata,
+	}
+
+	switch tolerance := req.UnderpaymentTolerance.(type) {
+	case *pb_v1.CreateInvoiceRequest_UnderpaymentTolerancePercent:
+		newInvoice.UnderpaymentTolerancePercent = tolerance.UnderpaymentTolerancePercent
+	case *pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount:
+		if req.Fiat != nil {
+			newInvoice.FiatUnderpaymentTolerance = tolerance.UnderpaymentToleranceAmount
+		} else {
+			newInvoice.UnderpaymentTolerance = FloatToAtomicUnits(tolerance.UnderpaymentToleranceAmount, coin)
+		}
+	}
+
+	if len(req.CoinOptions) > 0 {
+		newInvoice.Coin = ""
+		newInvoice.Amount = nil
+		newInvoice.CoinOptions = make([]dto.CoinOption, 0, len(req.CoinOptions))
+		for i := 0; i < len(req.CoinOptions); i++ {
+			optionCoin, _ := PbCoinOrCodeToDbCoin(req.CoinOptions[i].Coin, req.CoinOptions[i].CoinCode)
+			newInvoice.CoinOptions = append(newInvoice.CoinOptions, dto.CoinOption{
+				Coin:   optionCoin,
+				Amount: pbAmountToAtomicUnits(req.CoinOptions[i].Amount, req.CoinOptions[i].ExactAmount, optionCoin),
+			})
+		}
+	}
+
+	if req.Fiat != nil {
+		newInvoice.Amount = nil
+		newInvoice.FiatAmount = req.Fiat.Amount
+		newInvoice.FiatCurrency = strings.ToUpper(req.Fiat.Currency)
+		for i := 0; i < len(newInvoice.CoinOptions); i++ {
+			newInvoice.CoinOptions[i].Amount = nil
+		}
 	}
+
+	return newInvoice
 }
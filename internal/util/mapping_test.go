@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/big"
 	"math/rand"
 	"testing"
 	"time"
@@ -63,6 +64,10 @@ var (
 		pb_v1.CoinType_TWT_BEP20,
 		pb_v1.CoinType_AVAX_BEP20,
 		pb_v1.CoinType_CAKE_BEP20,
+		pb_v1.CoinType_USDT_TON,
+		pb_v1.CoinType_TRX,
+		pb_v1.CoinType_USDT_TRC20,
+		pb_v1.CoinType_USDC_TRC20,
 	}
 	dbCoins []db.CoinType = []db.CoinType{
 		db.CoinTypeXMR,
@@ -109,9 +114,13 @@ var (
 		db.CoinTypeTWTBEP20,
 		db.CoinTypeAVAXBEP20,
 		db.CoinTypeCAKEBEP20,
+		db.CoinTypeUSDTTON,
+		db.CoinTypeTRX,
+		db.CoinTypeUSDTTRC20,
+		db.CoinTypeUSDCTRC20,
 	}
-	dbInvoiceStatuses []db.InvoiceStatusType    = []db.InvoiceStatusType{db.InvoiceStatusTypePENDING, db.InvoiceStatusTypePENDINGMEMPOOL, db.InvoiceStatusTypeEXPIRED, db.InvoiceStatusTypeCONFIRMED}
-	pbInvoiceStatuses []pb_v1.InvoiceStatusType = []pb_v1.InvoiceStatusType{pb_v1.InvoiceStatusType_PENDING, pb_v1.InvoiceStatusType_PENDING_MEMPOOL, pb_v1.InvoiceStatusType_EXPIRED, pb_v1.InvoiceStatusType_CONFIRMED}
+	dbInvoiceStatuses []db.InvoiceStatusType    = []db.InvoiceStatusType{db.InvoiceStatusTypePENDING, db.InvoiceStatusTypePENDINGMEMPOOL, db.InvoiceStatusTypeEXPIRED, db.InvoiceStatusTypeCONFIRMED, db.InvoiceStatusTypeCANCELLED, db.InvoiceStatusTypePARTIALLYPAID, db.InvoiceStatusTypePAIDAFTEREXPIRY}
+	pbInvoiceStatuses []pb_v1.InvoiceStatusType = []pb_v1.InvoiceStatusType{pb_v1.InvoiceStatusType_PENDING, pb_v1.InvoiceStatusType_PENDING_MEMPOOL, pb_v1.InvoiceStatusType_EXPIRED, pb_v1.InvoiceStatusType_CONFIRMED, pb_v1.InvoiceStatusType_CANCELLED, pb_v1.InvoiceStatusType_PARTIALLY_PAID, pb_v1.InvoiceStatusType_PAID_AFTER_EXPIRY}
 )
 
 func TestStringToPgUUID(t *testing.T) {
@@ -178,6 +187,75 @@ func TestPbCoinToDbCoin(t *testing.T) {
 
 }
 
+func TestPbCoinOrCodeToDbCoin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Return PbCoin If Code Is Not Set", func(t *testing.T) {
+		coin, err := PbCoinOrCodeToDbCoin(pb_v1.CoinType_LTC, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, db.CoinTypeLTC, coin)
+	})
+
+	t.Run("Should Prefer Code", func(t *testing.T) {
+		code := "usdt_erc20"
+		coin, err := PbCoinOrCodeToDbCoin(pb_v1.CoinType_LTC, &code)
+		assert.NoError(t, err)
+		assert.Equal(t, db.CoinTypeUSDTERC20, coin)
+	})
+
+	t.Run("Should Return Error (unknown code)", func(t *testing.T) {
+		code := "USDC_NOWHERE"
+		_, err := PbCoinOrCodeToDbCoin(pb_v1.CoinType_LTC, &code)
+		assert.ErrorIs(t, err, invalidCoinCodeErr)
+	})
+}
+
+func TestPbAddressTypeToDbAddressType(t *testing.T) {
+	t.Parallel()
+
+	const zpub string = "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"
+
+	t.Run("Should Take Address Type From Key", func(t *testing.T) {
+		addressType, err := PbAddressTypeToDbAddressType(pb_v1.AddressType_ADDRESS_TYPE_AUTO, zpub)
+		assert.NoError(t, err)
+		assert.Equal(t, db.AddressTypeP2WPKH, addressType)
+	})
+
+	t.Run("Should Prefer Explicit Address Type", func(t *testing.T) {
+		addressType, err := PbAddressTypeToDbAddressType(pb_v1.AddressType_ADDRESS_TYPE_P2TR, zpub)
+		assert.NoError(t, err)
+		assert.Equal(t, db.AddressTypeP2TR, addressType)
+	})
+
+	t.Run("Should Return Error (invalid address type)", func(t *testing.T) {
+		_, err := PbAddressTypeToDbAddressType(pb_v1.AddressType(42), zpub)
+		assert.ErrorIs(t, err, invalidProtoBufAddressTypeErr)
+	})
+}
+
+func TestPbAddressReusePolicyToDbAddressReusePolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Return Valid DbAddressReusePolicy", func(t *testing.T) {
+		cases := map[pb_v1.AddressReusePolicy]db.AddressReusePolicy{
+			pb_v1.AddressReusePolicy_ADDRESS_REUSE_POLICY_REUSE:          db.AddressReusePolicyREUSE,
+			pb_v1.AddressReusePolicy_ADDRESS_REUSE_POLICY_NEVER_REUSE:    db.AddressReusePolicyNEVERREUSE,
+			pb_v1.AddressReusePolicy_ADDRESS_REUSE_POLICY_REUSE_UNFUNDED: db.AddressReusePolicyREUSEUNFUNDED,
+		}
+
+		for pbPolicy, expected := range cases {
+			policy, err := PbAddressReusePolicyToDbAddressReusePolicy(pbPolicy)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, policy)
+		}
+	})
+
+	t.Run("Should Return Error (invalid address reuse policy)", func(t *testing.T) {
+		_, err := PbAddressReusePolicyToDbAddressReusePolicy(pb_v1.AddressReusePolicy(42))
+		assert.ErrorIs(t, err, invalidProtoBufAddressReusePolicyErr)
+	})
+}
+
 func TestDbCoinToPbCoin(t *testing.T) {
 	t.Parallel()
 
@@ -221,9 +299,146 @@ func TestDbInvoiceStatusToPbInvoiceStatus(t *testing.T) {
 	})
 }
 
+func TestPbInvoiceStatusToDbInvoiceStatus(t *testing.T) {
+	t.Run("Should Return Valid DbInvoiceStatus For PbInvoiceStatus", func(t *testing.T) {
+		for i := 0; i < len(pbInvoiceStatuses); i++ {
+			t.Run(fmt.Sprintf("Should Return Valid DbInvoiceStatus For PbInvoiceStatus(%v)", pbInvoiceStatuses[i]), func(t *testing.T) {
+				expectedDbInvoiceStatus := dbInvoiceStatuses[i]
+
+				dbInvoiceStatus, err := PbInvoiceStatusToDbInvoiceStatus(pbInvoiceStatuses[i])
+				assert.NoError(t, err)
+				assert.Equal(t, expectedDbInvoiceStatus, dbInvoiceStatus)
+			})
+		}
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		_, err := PbInvoiceStatusToDbInvoiceStatus(math.MaxInt32)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, invalidProtoBufStatusTypeErr)
+	})
+}
+
+func TestWebhookDeliveryStatusMapping(t *testing.T) {
+	dbStatuses := []db.WebhookDeliveryStatusType{db.WebhookDeliveryStatusTypePENDING, db.WebhookDeliveryStatusTypeDELIVERED, db.WebhookDeliveryStatusTypeDEAD}
+	pbStatuses := []pb_v1.WebhookDeliveryStatusType{pb_v1.WebhookDeliveryStatusType_PENDING, pb_v1.WebhookDeliveryStatusType_DELIVERED, pb_v1.WebhookDeliveryStatusType_DEAD}
+
+	t.Run("Should Map Statuses Both Ways", func(t *testing.T) {
+		for i := 0; i < len(dbStatuses); i++ {
+			pbStatus, err := DbWebhookDeliveryStatusToPbWebhookDeliveryStatus(dbStatuses[i])
+			assert.NoError(t, err)
+			assert.Equal(t, pbStatuses[i], pbStatus)
+
+			dbStatus, err := PbWebhookDeliveryStatusToDbWebhookDeliveryStatus(pbStatuses[i])
+			assert.NoError(t, err)
+			assert.Equal(t, dbStatuses[i], dbStatus)
+		}
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		_, err := DbWebhookDeliveryStatusToPbWebhookDeliveryStatus(db.WebhookDeliveryStatusType(uuid.NewString()))
+		assert.ErrorIs(t, err, invalidDbStatusTypeErr)
+
+		_, err = PbWebhookDeliveryStatusToDbWebhookDeliveryStatus(math.MaxInt32)
+		assert.ErrorIs(t, err, invalidProtoBufStatusTypeErr)
+	})
+}
+
+func TestDbWebhookDeliveryToPbWebhookDelivery(t *testing.T) {
+	idStr := uuid.NewString()
+	id, err := StringToPgUUID(idStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	createdAtTime := time.Now().UTC()
+
+	delivery := &db.WebhookDelivery{
+		ID:        *id,
+		Status:    db.WebhookDeliveryStatusTypeDEAD,
+		Attempts:  3,
+		LastError: pgtype.Text{String: "unexpected status code: 500", Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: createdAtTime, Valid: true},
+		Payload:   []byte(`{"event": "invoice.updated"}`),
+	}
+
+	pbDelivery := DbWebhookDeliveryToPbWebhookDelivery(delivery)
+	assert.Equal(t, idStr, pbDelivery.Id)
+	assert.Equal(t, pb_v1.WebhookDeliveryStatusType_DEAD, pbDelivery.Status)
+	assert.Equal(t, uint32(3), pbDelivery.Attempts)
+	assert.Equal(t, "unexpected status code: 500", pbDelivery.LastError)
+	assert.True(t, createdAtTime.Equal(pbDelivery.CreatedAt.AsTime()))
+	assert.Equal(t, `{"event": "invoice.updated"}`, pbDelivery.Payload)
+}
+
+func TestPbTimestampToPgTimestamptz(t *testing.T) {
+	t.Run("Should Return Valid Timestamptz", func(t *testing.T) {
+		expectedTime := time.Now().UTC()
+
+		ts := PbTimestampToPgTimestamptz(timestamppb.New(expectedTime))
+		assert.True(t, ts.Valid)
+		assert.True(t, expectedTime.Equal(ts.Time))
+	})
+
+	t.Run("Should Return Invalid Timestamptz", func(t *testing.T) {
+		assert.False(t, PbTimestampToPgTimestamptz(nil).Valid)
+	})
+}
+
+func TestDbInvoiceEventToPbInvoiceEvent(t *testing.T) {
+	createdAtTime := time.Now().UTC()
+
+	t.Run("Should Map Transition Event", func(t *testing.T) {
+		event := &db.InvoiceEvent{
+			OldStatus:     db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypePENDING, Valid: true},
+			NewStatus:     db.InvoiceStatusTypePENDINGMEMPOOL,
+			TxID:          pgtype.Text{String: "txId", Valid: true},
+			Amount:        BigIntToPgNumeric(big.NewInt(150000000)),
+			Confirmations: pgtype.Int8{Int64: 2, Valid: true},
+			CreatedAt:     pgtype.Timestamptz{Time: createdAtTime, Valid: true},
+		}
+
+		pbEvent := DbInvoiceEventToPbInvoiceEvent(event, db.CoinTypeBTC)
+		assert.Equal(t, pb_v1.InvoiceStatusType_PENDING, pbEvent.GetOldStatus())
+		assert.Equal(t, pb_v1.InvoiceStatusType_PENDING_MEMPOOL, pbEvent.NewStatus)
+		assert.Equal(t, "txId", pbEvent.TxId)
+		assert.Equal(t, 1.5, pbEvent.Amount)
+		assert.Equal(t, "1.5", pbEvent.AmountExact.GetDecimal())
+		assert.Equal(t, "150000000", pbEvent.AmountExact.GetAtomicUnits())
+		assert.Equal(t, uint64(2), pbEvent.GetConfirmations())
+		assert.True(t, createdAtTime.Equal(pbEvent.CreatedAt.AsTime()))
+	})
+
+	t.Run("Should Leave Unknown Fields Unset", func(t *testing.T) {
+		event := &db.InvoiceEvent{
+			NewStatus: db.InvoiceStatusTypePENDING,
+			CreatedAt: pgtype.Timestamptz{Time: createdAtTime, Valid: true},
+		}
+
+		pbEvent := DbInvoiceEventToPbInvoiceEvent(event, db.CoinTypeBTC)
+		assert.Nil(t, pbEvent.OldStatus)
+		assert.Nil(t, pbEvent.AmountExact)
+		assert.Nil(t, pbEvent.Confirmations)
+		assert.Equal(t, pb_v1.InvoiceStatusType_PENDING, pbEvent.NewStatus)
+		assert.False(t, pbEvent.Reorg)
+	})
+
+	t.Run("Should Map Reorg Event", func(t *testing.T) {
+		event := &db.InvoiceEvent{
+			OldStatus: db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypeCONFIRMED, Valid: true},
+			NewStatus: db.InvoiceStatusTypePENDING,
+			Reorg:     true,
+			CreatedAt: pgtype.Timestamptz{Time: createdAtTime, Valid: true},
+		}
+
+		assert.True(t, DbInvoiceEventToPbInvoiceEvent(event, db.CoinTypeBTC).Reorg)
+	})
+}
+
 func TestDbInvoiceToPbInvoice(t *testing.T) {
 	idStr := uuid.NewString()
-	actualAmountFloat64 := rand.Float64()
+	requiredAmount := big.NewInt(rand.Int63n(math.MaxInt32))
+	actualAmount := big.NewInt(rand.Int63n(math.MaxInt32))
+	underpaymentTolerance := big.NewInt(rand.Int63n(math.MaxInt32))
 	createdAtTime := time.Now().UTC()
 	expiresAtTime := createdAtTime.Add(time.Duration(rand.Intn(math.MaxInt)+1) * time.Minute)
 	txIdStr := uuid.NewString()
@@ -233,10 +448,6 @@ func TestDbInvoiceToPbInvoice(t *testing.T) {
 	if err := id.Scan(idStr); err != nil {
 		log.Fatal(err)
 	}
-	var actualAmount pgtype.Float8
-	if err := actualAmount.Scan(actualAmountFloat64); err != nil {
-		log.Fatal(err)
-	}
 	var createdAt pgtype.Timestamptz
 	if err := createdAt.Scan(createdAtTime); err != nil {
 		log.Fatal(err)
@@ -258,38 +469,101 @@ func TestDbInvoiceToPbInvoice(t *testing.T) {
 		ID:                    id,
 		CryptoAddress:         uuid.NewString(),
 		Coin:                  db.CoinTypeBTC,
-		RequiredAmount:        rand.Float64(),
-		ActualAmount:          actualAmount,
+		RequiredAmount:        BigIntToPgNumeric(requiredAmount),
+		ActualAmount:          BigIntToPgNumeric(actualAmount),
 		ConfirmationsRequired: int16(rand.Intn(math.MaxInt16)),
+		Confirmations:         int32(rand.Intn(math.MaxInt16)),
 		CreatedAt:             createdAt,
 		ConfirmedAt:           pgtype.Timestamptz{},
 		Status:                db.InvoiceStatusTypePENDING,
 		ExpiresAt:             expiresAt,
 		TxID:                  txId,
 		UserID:                userId,
+		UnderpaymentTolerance: BigIntToPgNumeric(underpaymentTolerance),
+	}
+
+	overpaidAmount := new(big.Int).Sub(actualAmount, requiredAmount)
+	if overpaidAmount.Sign() < 0 {
+		overpaidAmount.SetInt64(0)
 	}
 
 	expectedPbInvoice := pb_v1.Invoice{
-		Id:                    idStr,
-		CryptoAddress:         dbInv.CryptoAddress,
-		Coin:                  pb_v1.CoinType_BTC,
-		RequiredAmount:        dbInv.RequiredAmount,
-		ActualAmount:          actualAmountFloat64,
-		ConfirmationsRequired: uint32(dbInv.ConfirmationsRequired),
-		CreatedAt:             timestamppb.New(createdAtTime),
-		ConfirmedAt:           timestamppb.New(dbInv.ConfirmedAt.Time),
-		Status:                pb_v1.InvoiceStatusType_PENDING,
-		ExpiresAt:             timestamppb.New(expiresAtTime),
-		TxId:                  txIdStr,
-		UserId:                userIdStr,
+		Id:                         idStr,
+		CryptoAddress:              dbInv.CryptoAddress,
+		Coin:                       pb_v1.CoinType_BTC,
+		CoinCode:                   string(db.CoinTypeBTC),
+		RequiredAmount:             AtomicUnitsToFloat64(requiredAmount, db.CoinTypeBTC),
+		ActualAmount:               AtomicUnitsToFloat64(actualAmount, db.CoinTypeBTC),
+		ConfirmationsRequired:      uint32(dbInv.ConfirmationsRequired),
+		Confirmations:              uint32(dbInv.Confirmations),
+		CreatedAt:                  timestamppb.New(createdAtTime),
+		ConfirmedAt:                timestamppb.New(dbInv.ConfirmedAt.Time),
+		Status:                     pb_v1.InvoiceStatusType_PENDING,
+		ExpiresAt:                  timestamppb.New(expiresAtTime),
+		TxId:                       txIdStr,
+		UserId:                     userIdStr,
+		UnderpaymentTolerance:      AtomicUnitsToFloat64(underpaymentTolerance, db.CoinTypeBTC),
+		OverpaidAmount:             AtomicUnitsToFloat64(overpaidAmount, db.CoinTypeBTC),
+		RequiredAmountExact:        AtomicUnitsToPbAmount(requiredAmount, db.CoinTypeBTC),
+		ActualAmountExact:          AtomicUnitsToPbAmount(actualAmount, db.CoinTypeBTC),
+		UnderpaymentToleranceExact: AtomicUnitsToPbAmount(underpaymentTolerance, db.CoinTypeBTC),
+		OverpaidAmountExact:        AtomicUnitsToPbAmount(overpaidAmount, db.CoinTypeBTC),
 	}
 
 	assert.Equal(t, expectedPbInvoice, *DbInvoiceToPbInvoice(&dbInv))
+
+	t.Run("Should Map Exact Amounts", func(t *testing.T) {
+		exactInv := dbInv
+		exactInv.Coin = db.CoinTypeETH
+		exactInv.RequiredAmount = BigIntToPgNumeric(atomicUnitsOrFatal("445540.848667137766512794", db.CoinTypeETH))
+		exactInv.ActualAmount = BigIntToPgNumeric(atomicUnitsOrFatal("445540.848667137766512795", db.CoinTypeETH))
+
+		pbInvoice := DbInvoiceToPbInvoice(&exactInv)
+		assert.Equal(t, "445540.848667137766512794", pbInvoice.RequiredAmountExact.GetDecimal())
+		assert.Equal(t, "445540848667137766512794", pbInvoice.RequiredAmountExact.GetAtomicUnits())
+		assert.Equal(t, "0.000000000000000001", pbInvoice.OverpaidAmountExact.GetDecimal())
+		assert.Equal(t, "1", pbInvoice.OverpaidAmountExact.GetAtomicUnits())
+	})
+
+	t.Run("Should Map Fiat Fields", func(t *testing.T) {
+		fiatInv := dbInv
+		fiatInv.FiatAmount = pgtype.Float8{Float64: 100, Valid: true}
+		fiatInv.FiatCurrency = pgtype.Text{String: "USD", Valid: true}
+		fiatInv.ExchangeRate = pgtype.Float8{Float64: 150, Valid: true}
+
+		pbInvoice := DbInvoiceToPbInvoice(&fiatInv)
+		assert.Equal(t, float64(100), pbInvoice.GetFiatAmount())
+		assert.Equal(t, "USD", pbInvoice.GetFiatCurrency())
+		assert.Equal(t, float64(150), pbInvoice.GetExchangeRate())
+	})
+
+	t.Run("Should Map Merchant Fields", func(t *testing.T) {
+		merchantInv := dbInv
+		merchantInv.Description = pgtype.Text{String: "2x Coffee", Valid: true}
+		merchantInv.ExternalOrderID = pgtype.Text{String: "order-42", Valid: true}
+		merchantInv.Metadata = []byte(`{"customerId": "7"}`)
+
+		pbInvoice := DbInvoiceToPbInvoice(&merchantInv)
+		assert.Equal(t, "2x Coffee", pbInvoice.Description)
+		assert.Equal(t, "order-42", pbInvoice.ExternalOrderId)
+		assert.Equal(t, map[string]string{"customerId": "7"}, pbInvoice.Metadata)
+	})
+
+	t.Run("Should Map Group Id", func(t *testing.T) {
+		groupId := uuid.NewString()
+		groupInv := dbInv
+		pgGroupId, err := StringToPgUUID(groupId)
+		assert.NoError(t, err)
+		groupInv.GroupID = *pgGroupId
+
+		assert.Equal(t, groupId, DbInvoiceToPbInvoice(&groupInv).GroupId)
+		assert.Empty(t, DbInvoiceToPbInvoice(&dbInv).GroupId)
+	})
 }
 
 func TestPbNewInvoiceToProcessorNewInvoice(t *testing.T) {
 	userId := uuid.NewString()
-	amount := rand.Float64()
+	amount := float64(rand.Int63n(math.MaxInt32)) / 1e8
 	timeout := rand.Uint64()
 	confirmations := rand.Uint32()
 
@@ -304,10 +578,99 @@ func TestPbNewInvoiceToProcessorNewInvoice(t *testing.T) {
 	expectedProcessorNewInvoice := dto.NewInvoiceRequest{
 		UserId:        userId,
 		Coin:          db.CoinTypeBTC,
-		Amount:        amount,
+		Amount:        FloatToAtomicUnits(amount, db.CoinTypeBTC),
 		Timeout:       timeout,
 		Confirmations: confirmations,
 	}
 
 	assert.Equal(t, expectedProcessorNewInvoice, *PbNewInvoiceToProcessorNewInvoice(&newInv))
+
+	t.Run("Should Map Underpayment Tolerance Percent", func(t *testing.T) {
+		req := pb_v1.CreateInvoiceRequest{
+			Amount:                2,
+			UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentTolerancePercent{UnderpaymentTolerancePercent: 5},
+		}
+
+		newInvoice := PbNewInvoiceToProcessorNewInvoice(&req)
+		assert.Equal(t, float64(5), newInvoice.UnderpaymentTolerancePercent)
+		assert.Nil(t, newInvoice.UnderpaymentTolerance)
+	})
+
+	t.Run("Should Keep Underpayment Tolerance Amount", func(t *testing.T) {
+		req := pb_v1.CreateInvoiceRequest{
+			Coin:                  pb_v1.CoinType_BTC,
+			Amount:                2,
+			UnderpaymentTolerance: &pb_v1.CreateInvoiceRequest_UnderpaymentToleranceAmount{UnderpaymentToleranceAmount: 0.3},
+		}
+
+		assert.Equal(t, big.NewInt(30000000), PbNewInvoiceToProcessorNewInvoice(&req).UnderpaymentTolerance)
+
+		req.Fiat = &pb_v1.FiatAmount{Currency: "usd", Amount: 100}
+		newInvoice := PbNewInvoiceToProcessorNewInvoice(&req)
+		assert.Equal(t, 0.3, newInvoice.FiatUnderpaymentTolerance)
+		assert.Nil(t, newInvoice.UnderpaymentTolerance)
+	})
+
+	t.Run("Should Map Fiat Amount", func(t *testing.T) {
+		req := pb_v1.CreateInvoiceRequest{
+			Amount: 2,
+			Fiat:   &pb_v1.FiatAmount{Currency: "usd", Amount: 100},
+		}
+
+		newInvoice := PbNewInvoiceToProcessorNewInvoice(&req)
+		assert.Nil(t, newInvoice.Amount)
+		assert.Equal(t, float64(100), newInvoice.FiatAmount)
+		assert.Equal(t, "USD", newInvoice.FiatCurrency)
+	})
+
+	t.Run("Should Map Merchant Fields", func(t *testing.T) {
+		req := pb_v1.CreateInvoiceRequest{
+			Amount:          2,
+			Description:     "2x Coffee",
+			ExternalOrderId: "order-42",
+			Metadata:        map[string]string{"customerId": "7"},
+		}
+
+		newInvoice := PbNewInvoiceToProcessorNewInvoice(&req)
+		assert.Equal(t, "2x Coffee", newInvoice.Description)
+		assert.Equal(t, "order-42", newInvoice.ExternalOrderId)
+		assert.Equal(t, map[string]string{"customerId": "7"}, newInvoice.Metadata)
+	})
+
+	t.Run("Should Map Idempotency Key", func(t *testing.T) {
+		key := uuid.NewString()
+		req := pb_v1.CreateInvoiceRequest{Amount: 2, IdempotencyKey: &key}
+
+		assert.Equal(t, key, PbNewInvoiceToProcessorNewInvoice(&req).IdempotencyKey)
+	})
+
+	t.Run("Should Map Coin Options", func(t *testing.T) {
+		req := pb_v1.CreateInvoiceRequest{
+			Coin:   pb_v1.CoinType_BTC,
+			Amount: 2,
+			CoinOptions: []*pb_v1.CoinOption{
+				{Coin: pb_v1.CoinType_XMR, Amount: 1.5},
+				{Coin: pb_v1.CoinType_USDT_ERC20, Amount: 300},
+			},
+		}
+
+		newInvoice := PbNewInvoiceToProcessorNewInvoice(&req)
+		assert.Equal(t, db.CoinType(""), newInvoice.Coin)
+		assert.Nil(t, newInvoice.Amount)
+		assert.Equal(t, []dto.CoinOption{{Coin: db.CoinTypeXMR, Amount: big.NewInt(1500000000000)}, {Coin: db.CoinTypeUSDTERC20, Amount: big.NewInt(300000000)}}, newInvoice.CoinOptions)
+
+		req.Fiat = &pb_v1.FiatAmount{Currency: "usd", Amount: 100}
+		newInvoice = PbNewInvoiceToProcessorNewInvoice(&req)
+		assert.Equal(t, []dto.CoinOption{{Coin: db.CoinTypeXMR}, {Coin: db.CoinTypeUSDTERC20}}, newInvoice.CoinOptions)
+	})
+	t.Run("Should Prefer Exact Amounts", func(t *testing.T) {
+		exactAmount := "0.123456789012"
+		exactOptionAmount := "0.00000001"
+		req := pb_v1.CreateInvoiceRequest{Coin: pb_v1.CoinType_XMR, Amount: 2, ExactAmount: &exactAmount}
+
+		assert.Equal(t, big.NewInt(123456789012), PbNewInvoiceToProcessorNewInvoice(&req).Amount)
+
+		req.CoinOptions = []*pb_v1.CoinOption{{Coin: pb_v1.CoinType_BTC, Amount: 2, ExactAmount: &exactOptionAmount}}
+		assert.Equal(t, []dto.CoinOption{{Coin: db.CoinTypeBTC, Amount: big.NewInt(1)}}, PbNewInvoiceToProcessorNewInvoice(&req).CoinOptions)
+	})
 }
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+
+	"rsc.io/qr"
+)
+
+// QR_CODE_QUIET_ZONE is the width of the blank border around the code in modules.
+const QR_CODE_QUIET_ZONE int = 4
+
+func QrCodePNG(text string) ([]byte, error) {
+	code, err := qr.Encode(text, qr.M)
+	if err != nil {
+		return nil, err
+	}
+
+	return code.PNG(), nil
+}
+
+func QrCodeSVG(text string) ([]byte, error) {
+	code, err := qr.Encode(text, qr.M)
+	if err != nil {
+		return nil, err
+	}
+
+	size := code.Size + 2*QR_CODE_QUIET_ZONE
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, size, size)
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if code.Black(x, y) {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x+QR_CODE_QUIET_ZONE, y+QR_CODE_QUIET_ZONE)
+			}
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,26 @@
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQrCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Encode PNG", func(t *testing.T) {
+		png, err := QrCodePNG("bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=0.0015")
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(png, []byte("\x89PNG\r\n\x1a\n")))
+	})
+
+	t.Run("Should Encode SVG", func(t *testing.T) {
+		svg, err := QrCodeSVG("bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=0.0015")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(svg), "<svg"))
+		assert.True(t, strings.HasSuffix(string(svg), "</svg>"))
+	})
+}
@@ -0,0 +1,517 @@
+package util
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	tonFriendlyAddressLen int = 36
+
+	tonBounceableTag    byte = 0x11
+	tonNonBounceableTag byte = 0x51
+	tonTestnetFlag      byte = 0x80
+
+	tonCommentOp                uint32 = 0x00000000
+	tonJettonInternalTransferOp uint32 = 0x178d4519
+
+	// The maximum depth of the snake cells followed while reading a comment.
+	tonMaxSnakeDepth int = 16
+)
+
+var (
+	InvalidTONAddressErr error = errors.New("invalid TON address")
+	InvalidTONBocErr     error = errors.New("invalid TON bag of cells")
+	UnexpectedTONOpErr   error = errors.New("unexpected TON message op")
+
+	tonBocMagic []byte = []byte{0xb5, 0xee, 0x9c, 0x72}
+)
+
+// TONAddress is a standard (addr_std) TON account address.
+type TONAddress struct {
+	Workchain int8
+	Hash      [32]byte
+}
+
+// Raw returns the address in the "workchain:hex" form, as used by the toncenter API.
+func (a TONAddress) Raw() string {
+	return strconv.Itoa(int(a.Workchain)) + ":" + strings.ToUpper(hex.EncodeToString(a.Hash[:]))
+}
+
+// Friendly returns the url safe base64 form of the address.
+func (a TONAddress) Friendly(bounceable bool, testnet bool) string {
+	buf := make([]byte, 0, tonFriendlyAddressLen)
+
+	tag := tonNonBounceableTag
+	if bounceable {
+		tag = tonBounceableTag
+	}
+	if testnet {
+		tag |= tonTestnetFlag
+	}
+	buf = append(buf, tag, byte(a.Workchain))
+	buf = append(buf, a.Hash[:]...)
+	buf = binary.BigEndian.AppendUint16(buf, crc16XModem(buf))
+
+	return base64.URLEncoding.EncodeToString(buf)
+}
+
+func crc16XModem(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+func parseRawTONAddress(addr string) (TONAddress, error) {
+	var res TONAddress
+
+	wc, hash, ok := strings.Cut(addr, ":")
+	if !ok {
+		return res, InvalidTONAddressErr
+	}
+
+	workchain, err := strconv.ParseInt(wc, 10, 8)
+	if err != nil {
+		return res, InvalidTONAddressErr
+	}
+	hashBytes, err := hex.DecodeString(hash)
+	if err != nil || len(hashBytes) != len(res.Hash) {
+		return res, InvalidTONAddressErr
+	}
+
+	res.Workchain = int8(workchain)
+	copy(res.Hash[:], hashBytes)
+
+	return res, nil
+}
+
+func parseFriendlyTONAddress(addr string) (TONAddress, error) {
+	var res TONAddress
+
+	buf, err := base64.URLEncoding.DecodeString(strings.NewReplacer("+", "-", "/", "_").Replace(addr))
+	if err != nil || len(buf) != tonFriendlyAddressLen {
+		return res, InvalidTONAddressErr
+	}
+
+	if tag := buf[0] &^ tonTestnetFlag; tag != tonBounceableTag && tag != tonNonBounceableTag {
+		return res, InvalidTONAddressErr
+	}
+	if crc16XModem(buf[:34]) != binary.BigEndian.Uint16(buf[34:]) {
+		return res, InvalidTONAddressErr
+	}
+
+	res.Workchain = int8(buf[1])
+	copy(res.Hash[:], buf[2:34])
+
+	return res, nil
+}
+
+// ParseTONAddress accepts both the raw ("0:hex") and the user-friendly (base64 or base64url) forms.
+func ParseTONAddress(addr string) (TONAddress, error) {
+	if strings.Contains(addr, ":") {
+		return parseRawTONAddress(addr)
+	}
+
+	return parseFriendlyTONAddress(addr)
+}
+
+// EqualTONAddresses compares the addresses regardless of their forms.
+func EqualTONAddresses(a string, b string) bool {
+	addrA, err := ParseTONAddress(a)
+	if err != nil {
+		return false
+	}
+	addrB, err := ParseTONAddress(b)
+	if err != nil {
+		return false
+	}
+
+	return addrA == addrB
+}
+
+type tonCell struct {
+	data []byte
+	bits int
+	refs []*tonCell
+}
+
+type tonCellSlice struct {
+	cell *tonCell
+	pos  int
+	ref  int
+}
+
+func (s *tonCellSlice) remainingBits() int {
+	return s.cell.bits - s.pos
+}
+
+func (s *tonCellSlice) loadBit() (bool, error) {
+	if s.remainingBits() < 1 {
+		return false, InvalidTONBocErr
+	}
+	bit := s.cell.data[s.pos/8]>>(7-s.pos%8)&1 == 1
+	s.pos++
+
+	return bit, nil
+}
+
+func (s *tonCellSlice) loadBigUint(n int) (*big.Int, error) {
+	if s.remainingBits() < n {
+		return nil, InvalidTONBocErr
+	}
+
+	res := new(big.Int)
+	for i := 0; i < n; i++ {
+		bit, _ := s.loadBit()
+		res.Lsh(res, 1)
+		if bit {
+			res.SetBit(res, 0, 1)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *tonCellSlice) loadUint(n int) (uint64, error) {
+	v, err := s.loadBigUint(n)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.Uint64(), nil
+}
+
+func (s *tonCellSlice) loadBytes(n int) ([]byte, error) {
+	if s.remainingBits() < n*8 {
+		return nil, InvalidTONBocErr
+	}
+
+	res := make([]byte, n)
+	for i := 0; i < n; i++ {
+		b, _ := s.loadUint(8)
+		res[i] = byte(b)
+	}
+
+	return res, nil
+}
+
+// loadCoins reads a VarUInteger 16.
+func (s *tonCellSlice) loadCoins() (*big.Int, error) {
+	l, err := s.loadUint(4)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadBigUint(int(l) * 8)
+}
+
+// loadAddress returns nil for addr_none.
+func (s *tonCellSlice) loadAddress() (*TONAddress, error) {
+	tag, err := s.loadUint(2)
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case 0b00:
+		return nil, nil
+	case 0b10:
+	default:
+		// Neither the external nor the variable length addresses are used by the wallets and jettons.
+		return nil, InvalidTONBocErr
+	}
+
+	if anycast, err := s.loadBit(); err != nil || anycast {
+		return nil, InvalidTONBocErr
+	}
+
+	wc, err := s.loadUint(8)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := s.loadBytes(32)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := &TONAddress{Workchain: int8(wc)}
+	copy(addr.Hash[:], hash)
+
+	return addr, nil
+}
+
+func (s *tonCellSlice) loadRef() (*tonCellSlice, error) {
+	if s.ref >= len(s.cell.refs) {
+		return nil, InvalidTONBocErr
+	}
+	ref := s.cell.refs[s.ref]
+	s.ref++
+
+	return &tonCellSlice{cell: ref}, nil
+}
+
+func readBocUint(buf []byte, pos *int, size int) (int, error) {
+	if size > 8 || *pos+size > len(buf) {
+		return 0, InvalidTONBocErr
+	}
+
+	var res int
+	for i := 0; i < size; i++ {
+		res = res<<8 | int(buf[*pos+i])
+	}
+	*pos += size
+
+	return res, nil
+}
+
+// parseTONBoc returns the first root cell of the serialized bag of cells.
+func parseTONBoc(boc []byte) (*tonCell, error) {
+	if len(boc) < 6 || !bytes.Equal(boc[:4], tonBocMagic) {
+		return nil, InvalidTONBocErr
+	}
+
+	hasIdx := boc[4]&0x80 != 0
+	refSize := int(boc[4] & 0x07)
+	offSize := int(boc[5])
+	if refSize < 1 || refSize > 4 || offSize < 1 || offSize > 8 {
+		return nil, InvalidTONBocErr
+	}
+
+	pos := 6
+	cellsCount, err := readBocUint(boc, &pos, refSize)
+	if err != nil {
+		return nil, err
+	}
+	rootsCount, err := readBocUint(boc, &pos, refSize)
+	if err != nil || rootsCount < 1 {
+		return nil, InvalidTONBocErr
+	}
+	if _, err := readBocUint(boc, &pos, refSize); err != nil {
+		return nil, err
+	}
+	if _, err := readBocUint(boc, &pos, offSize); err != nil {
+		return nil, err
+	}
+
+	rootIdx, err := readBocUint(boc, &pos, refSize)
+	if err != nil || rootIdx >= cellsCount {
+		return nil, InvalidTONBocErr
+	}
+	pos += (rootsCount - 1) * refSize
+	if hasIdx {
+		pos += cellsCount * offSize
+	}
+	if pos > len(boc) || cellsCount > len(boc) {
+		return nil, InvalidTONBocErr
+	}
+
+	cells := make([]*tonCell, cellsCount)
+	refIdxs := make([][]int, cellsCount)
+	for i := 0; i < cellsCount; i++ {
+		if pos+2 > len(boc) {
+			return nil, InvalidTONBocErr
+		}
+		d1, d2 := boc[pos], int(boc[pos+1])
+		pos += 2
+
+		refsCount := int(d1 & 0x07)
+		if refsCount > 4 || d1&0x08 != 0 {
+			// The exotic cells don't appear in the message bodies.
+			return nil, InvalidTONBocErr
+		}
+
+		dataLen := (d2 + 1) / 2
+		if pos+dataLen > len(boc) {
+			return nil, InvalidTONBocErr
+		}
+		cell := &tonCell{data: boc[pos : pos+dataLen], bits: dataLen * 8}
+		pos += dataLen
+
+		// An odd d2 means the last byte is padded with a 1 bit followed by zeros.
+		if d2%2 == 1 {
+			last := cell.data[dataLen-1]
+			if last == 0 {
+				return nil, InvalidTONBocErr
+			}
+			for last&1 == 0 {
+				last >>= 1
+				cell.bits--
+			}
+			cell.bits--
+		}
+
+		for j := 0; j < refsCount; j++ {
+			idx, err := readBocUint(boc, &pos, refSize)
+			if err != nil {
+				return nil, err
+			}
+			if idx <= i || idx >= cellsCount {
+				return nil, InvalidTONBocErr
+			}
+			refIdxs[i] = append(refIdxs[i], idx)
+		}
+
+		cells[i] = cell
+	}
+
+	for i := cellsCount - 1; i >= 0; i-- {
+		for _, idx := range refIdxs[i] {
+			cells[i].refs = append(cells[i].refs, cells[idx])
+		}
+	}
+
+	return cells[rootIdx], nil
+}
+
+func parseTONBocBase64(boc string) (*tonCellSlice, error) {
+	buf, err := base64.StdEncoding.DecodeString(boc)
+	if err != nil {
+		return nil, InvalidTONBocErr
+	}
+
+	root, err := parseTONBoc(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tonCellSlice{cell: root}, nil
+}
+
+// loadSnakeText reads the rest of the slice and the chain of the first refs after it.
+func (s *tonCellSlice) loadSnakeText() (string, error) {
+	var sb strings.Builder
+
+	for depth := 0; ; depth++ {
+		if depth >= tonMaxSnakeDepth {
+			return "", InvalidTONBocErr
+		}
+
+		b, err := s.loadBytes(s.remainingBits() / 8)
+		if err != nil {
+			return "", err
+		}
+		sb.Write(b)
+
+		if s.ref >= len(s.cell.refs) {
+			break
+		}
+		if s, err = s.loadRef(); err != nil {
+			return "", err
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// loadTextComment returns UnexpectedTONOpErr if the payload isn't a text comment.
+func (s *tonCellSlice) loadTextComment() (string, error) {
+	op, err := s.loadUint(32)
+	if err != nil {
+		return "", UnexpectedTONOpErr
+	}
+	if uint32(op) != tonCommentOp {
+		return "", UnexpectedTONOpErr
+	}
+
+	return s.loadSnakeText()
+}
+
+// loadForwardPayloadComment reads the forward_payload:(Either Cell ^Cell) of the jetton messages.
+func (s *tonCellSlice) loadForwardPayloadComment() (string, error) {
+	isRef, err := s.loadBit()
+	if err != nil {
+		return "", err
+	}
+
+	payload := s
+	if isRef {
+		if payload, err = s.loadRef(); err != nil {
+			return "", err
+		}
+	}
+	if payload.remainingBits() == 0 && payload.ref >= len(payload.cell.refs) {
+		return "", nil
+	}
+
+	comment, err := payload.loadTextComment()
+	if errors.Is(err, UnexpectedTONOpErr) {
+		return "", nil
+	}
+
+	return comment, err
+}
+
+// ParseTONTextComment extracts the text comment from the base64 encoded message body.
+func ParseTONTextComment(body string) (string, error) {
+	s, err := parseTONBocBase64(body)
+	if err != nil {
+		return "", err
+	}
+
+	return s.loadTextComment()
+}
+
+type TONJettonTransfer struct {
+	QueryId uint64
+	Amount  *big.Int
+	// The owner of the sending jetton wallet.
+	From    *TONAddress
+	Comment string
+}
+
+// ParseTONJettonTransfer extracts the transfer from the base64 encoded body of the internal_transfer message received by a jetton wallet.
+// Unlike the transfer_notification sent to the owner, it's there even if the sender has attached no forward TON amount.
+func ParseTONJettonTransfer(body string) (*TONJettonTransfer, error) {
+	s, err := parseTONBocBase64(body)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.loadUint(32)
+	if err != nil {
+		return nil, UnexpectedTONOpErr
+	}
+	if uint32(op) != tonJettonInternalTransferOp {
+		return nil, UnexpectedTONOpErr
+	}
+
+	transfer := &TONJettonTransfer{}
+	if transfer.QueryId, err = s.loadUint(64); err != nil {
+		return nil, err
+	}
+	if transfer.Amount, err = s.loadCoins(); err != nil {
+		return nil, err
+	}
+	if transfer.From, err = s.loadAddress(); err != nil {
+		return nil, err
+	}
+
+	// response_address and forward_ton_amount
+	if _, err := s.loadAddress(); err != nil {
+		return nil, err
+	}
+	if _, err := s.loadCoins(); err != nil {
+		return nil, err
+	}
+
+	if transfer.Comment, err = s.loadForwardPayloadComment(); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,260 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tonCellBuilder struct {
+	data []byte
+	bits int
+	refs []*tonCellBuilder
+}
+
+func (b *tonCellBuilder) storeUint(v uint64, n int) *tonCellBuilder {
+	return b.storeBigUint(new(big.Int).SetUint64(v), n)
+}
+
+func (b *tonCellBuilder) storeBigUint(v *big.Int, n int) *tonCellBuilder {
+	for i := n - 1; i >= 0; i-- {
+		if b.bits%8 == 0 {
+			b.data = append(b.data, 0)
+		}
+		if v.Bit(i) == 1 {
+			b.data[b.bits/8] |= 1 << (7 - b.bits%8)
+		}
+		b.bits++
+	}
+
+	return b
+}
+
+func (b *tonCellBuilder) storeBytes(v []byte) *tonCellBuilder {
+	for _, c := range v {
+		b.storeUint(uint64(c), 8)
+	}
+
+	return b
+}
+
+func (b *tonCellBuilder) storeCoins(v *big.Int) *tonCellBuilder {
+	l := (v.BitLen() + 7) / 8
+	b.storeUint(uint64(l), 4)
+
+	return b.storeBigUint(v, l*8)
+}
+
+func (b *tonCellBuilder) storeAddress(a *TONAddress) *tonCellBuilder {
+	if a == nil {
+		return b.storeUint(0, 2)
+	}
+	b.storeUint(0b100, 3)
+	b.storeUint(uint64(uint8(a.Workchain)), 8)
+
+	return b.storeBytes(a.Hash[:])
+}
+
+func (b *tonCellBuilder) storeRef(ref *tonCellBuilder) *tonCellBuilder {
+	b.refs = append(b.refs, ref)
+	return b
+}
+
+// toBoc serializes the tree of cells without an index, the children always following their parents.
+func (b *tonCellBuilder) toBoc() string {
+	var cells []*tonCellBuilder
+	var walk func(c *tonCellBuilder)
+	walk = func(c *tonCellBuilder) {
+		cells = append(cells, c)
+		for _, r := range c.refs {
+			walk(r)
+		}
+	}
+	walk(b)
+
+	idx := make(map[*tonCellBuilder]int, len(cells))
+	for i, c := range cells {
+		idx[c] = i
+	}
+
+	var data []byte
+	for _, c := range cells {
+		d2 := byte(c.bits/8 + (c.bits+7)/8)
+		payload := append([]byte(nil), c.data...)
+		if c.bits%8 != 0 {
+			payload[len(payload)-1] |= 1 << (7 - c.bits%8)
+		}
+		data = append(data, byte(len(c.refs)), d2)
+		data = append(data, payload...)
+		for _, r := range c.refs {
+			data = append(data, byte(idx[r]))
+		}
+	}
+
+	boc := append([]byte(nil), tonBocMagic...)
+	boc = append(boc, 0x01, 0x01, byte(len(cells)), 0x01, 0x00, byte(len(data)), 0x00)
+	boc = append(boc, data...)
+
+	return base64.StdEncoding.EncodeToString(boc)
+}
+
+func tonAddressOrFatal(t *testing.T, addr string) TONAddress {
+	res, err := ParseTONAddress(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func TestParseTONAddress(t *testing.T) {
+	hash, _ := hex.DecodeString("83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8")
+	expected := TONAddress{Workchain: 0}
+	copy(expected.Hash[:], hash)
+
+	t.Run("Should Parse All Forms", func(t *testing.T) {
+		for _, addr := range []string{
+			"EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N",
+			"UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI",
+			"0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8",
+			"0:83DFD552E63729B472FCBCC8C45EBCC6691702558B68EC7527E1BA403A0F31A8",
+		} {
+			res, err := ParseTONAddress(addr)
+			assert.NoError(t, err, addr)
+			assert.Equal(t, expected, res, addr)
+		}
+	})
+
+	t.Run("Should Format Both Ways", func(t *testing.T) {
+		assert.Equal(t, "EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N", expected.Friendly(true, false))
+		assert.Equal(t, "UQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqEBI", expected.Friendly(false, false))
+		assert.Equal(t, expected, tonAddressOrFatal(t, expected.Friendly(true, true)))
+		assert.Equal(t, expected, tonAddressOrFatal(t, expected.Raw()))
+	})
+
+	t.Run("Should Return InvalidTONAddressErr", func(t *testing.T) {
+		for _, addr := range []string{
+			"",
+			"EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2M",
+			"EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8x",
+			"0:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31",
+			"abc:83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8",
+			"0x83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8",
+		} {
+			_, err := ParseTONAddress(addr)
+			assert.ErrorIs(t, err, InvalidTONAddressErr, addr)
+		}
+	})
+
+	t.Run("Should Compare Different Forms", func(t *testing.T) {
+		assert.True(t, EqualTONAddresses("EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N", "0:83DFD552E63729B472FCBCC8C45EBCC6691702558B68EC7527E1BA403A0F31A8"))
+		assert.False(t, EqualTONAddresses("EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N", "-1:83DFD552E63729B472FCBCC8C45EBCC6691702558B68EC7527E1BA403A0F31A8"))
+		assert.False(t, EqualTONAddresses("", ""))
+	})
+}
+
+func TestParseTONTextComment(t *testing.T) {
+	t.Run("Should Parse Comment", func(t *testing.T) {
+		body := new(tonCellBuilder).storeUint(0, 32).storeBytes([]byte("42")).toBoc()
+
+		comment, err := ParseTONTextComment(body)
+		assert.NoError(t, err)
+		assert.Equal(t, "42", comment)
+	})
+
+	t.Run("Should Follow Snake Cells", func(t *testing.T) {
+		tail := new(tonCellBuilder).storeBytes([]byte(" world"))
+		body := new(tonCellBuilder).storeUint(0, 32).storeBytes([]byte("hello")).storeRef(tail).toBoc()
+
+		comment, err := ParseTONTextComment(body)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", comment)
+	})
+
+	t.Run("Should Return UnexpectedTONOpErr", func(t *testing.T) {
+		body := new(tonCellBuilder).storeUint(uint64(tonJettonInternalTransferOp), 32).storeUint(0, 64).toBoc()
+
+		_, err := ParseTONTextComment(body)
+		assert.ErrorIs(t, err, UnexpectedTONOpErr)
+	})
+
+	t.Run("Should Return InvalidTONBocErr", func(t *testing.T) {
+		for _, body := range []string{"", "not base64", base64.StdEncoding.EncodeToString([]byte{0xb5, 0xee, 0x9c, 0x72, 0x01})} {
+			_, err := ParseTONTextComment(body)
+			assert.ErrorIs(t, err, InvalidTONBocErr, body)
+		}
+	})
+}
+
+func TestParseTONJettonTransfer(t *testing.T) {
+	sender := tonAddressOrFatal(t, "EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N")
+	amount := big.NewInt(12340000)
+
+	t.Run("Should Parse Internal Transfer With Inline Comment", func(t *testing.T) {
+		body := new(tonCellBuilder).
+			storeUint(uint64(tonJettonInternalTransferOp), 32).
+			storeUint(7, 64).
+			storeCoins(amount).
+			storeAddress(&sender).
+			storeAddress(&sender).
+			storeCoins(big.NewInt(1)).
+			storeUint(0, 1).
+			storeUint(0, 32).
+			storeBytes([]byte("15")).
+			toBoc()
+
+		transfer, err := ParseTONJettonTransfer(body)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), transfer.QueryId)
+		assert.Equal(t, amount, transfer.Amount)
+		assert.Equal(t, &sender, transfer.From)
+		assert.Equal(t, "15", transfer.Comment)
+	})
+
+	t.Run("Should Parse Internal Transfer With Ref Comment", func(t *testing.T) {
+		payload := new(tonCellBuilder).storeUint(0, 32).storeBytes([]byte("16"))
+		body := new(tonCellBuilder).
+			storeUint(uint64(tonJettonInternalTransferOp), 32).
+			storeUint(0, 64).
+			storeCoins(amount).
+			storeAddress(&sender).
+			storeAddress(nil).
+			storeCoins(big.NewInt(0)).
+			storeUint(1, 1).
+			storeRef(payload).
+			toBoc()
+
+		transfer, err := ParseTONJettonTransfer(body)
+		assert.NoError(t, err)
+		assert.Equal(t, amount, transfer.Amount)
+		assert.Equal(t, "16", transfer.Comment)
+	})
+
+	t.Run("Should Leave Comment Empty", func(t *testing.T) {
+		body := new(tonCellBuilder).
+			storeUint(uint64(tonJettonInternalTransferOp), 32).
+			storeUint(0, 64).
+			storeCoins(amount).
+			storeAddress(nil).
+			storeAddress(nil).
+			storeCoins(big.NewInt(0)).
+			storeUint(0, 1).
+			toBoc()
+
+		transfer, err := ParseTONJettonTransfer(body)
+		assert.NoError(t, err)
+		assert.Nil(t, transfer.From)
+		assert.Equal(t, "", transfer.Comment)
+	})
+
+	t.Run("Should Return UnexpectedTONOpErr", func(t *testing.T) {
+		// transfer_notification
+		body := new(tonCellBuilder).storeUint(0x7362d09c, 32).storeUint(0, 64).storeCoins(amount).storeAddress(&sender).storeUint(0, 1).toBoc()
+
+		_, err := ParseTONJettonTransfer(body)
+		assert.ErrorIs(t, err, UnexpectedTONOpErr)
+	})
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+)
+
+const (
+	trxAddressVersion byte = 0x41
+	trxAddressLen     int  = 20
+)
+
+var (
+	InvalidTRXAddressErr error = errors.New("invalid TRX address")
+)
+
+// TRXAddressFromBytes encodes the 20 bytes account id (the same as of the ETH address of the key) into the base58 TRON address.
+func TRXAddressFromBytes(addr []byte) string {
+	return base58.CheckEncode(addr, trxAddressVersion)
+}
+
+// TRXAddressFromHex accepts both the "41" prefixed form of the full node API and the plain 20 bytes hex of the event logs.
+func TRXAddressFromHex(addr string) (string, error) {
+	buf, err := hex.DecodeString(strings.TrimPrefix(addr, "0x"))
+	if err != nil {
+		return "", InvalidTRXAddressErr
+	}
+	if len(buf) == trxAddressLen+1 && buf[0] == trxAddressVersion {
+		buf = buf[1:]
+	}
+	if len(buf) != trxAddressLen {
+		return "", InvalidTRXAddressErr
+	}
+
+	return TRXAddressFromBytes(buf), nil
+}
+
+// ParseTRXAddress returns the 20 bytes account id of the base58 TRON address.
+func ParseTRXAddress(addr string) ([]byte, error) {
+	buf, version, err := base58.CheckDecode(addr)
+	if err != nil || version != trxAddressVersion || len(buf) != trxAddressLen {
+		return nil, InvalidTRXAddressErr
+	}
+
+	return buf, nil
+}
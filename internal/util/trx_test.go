@@ -0,0 +1,41 @@
+package util
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTRXAddress(t *testing.T) {
+	const (
+		usdtContract    string = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+		usdtContractHex string = "a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	)
+
+	t.Run("Should Convert Hex To Base58", func(t *testing.T) {
+		for _, addr := range []string{usdtContractHex, "41" + usdtContractHex, "0x" + usdtContractHex} {
+			res, err := TRXAddressFromHex(addr)
+			assert.NoError(t, err, addr)
+			assert.Equal(t, usdtContract, res, addr)
+		}
+	})
+
+	t.Run("Should Parse Base58", func(t *testing.T) {
+		res, err := ParseTRXAddress(usdtContract)
+		assert.NoError(t, err)
+		assert.Equal(t, usdtContractHex, hex.EncodeToString(res))
+		assert.Equal(t, usdtContract, TRXAddressFromBytes(res))
+	})
+
+	t.Run("Should Return InvalidTRXAddressErr", func(t *testing.T) {
+		for _, addr := range []string{"", "zz", "42" + usdtContractHex, usdtContractHex[2:]} {
+			_, err := TRXAddressFromHex(addr)
+			assert.ErrorIs(t, err, InvalidTRXAddressErr, addr)
+		}
+		for _, addr := range []string{"", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"} {
+			_, err := ParseTRXAddress(addr)
+			assert.ErrorIs(t, err, InvalidTRXAddressErr, addr)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"context"
+	"math"
 	"os"
 
 	"github.com/chekist32/goipay/internal/db"
@@ -60,3 +61,9 @@ func GetMapKeys[K comparable, V any](m map[K]V) []K {
 
 	return s
 }
+
+// RoundUpToDecimals rounds the value up, so the rounded amount never falls short of the original one.
+func RoundUpToDecimals(v float64, decimals int) float64 {
+	pow := math.Pow10(decimals)
+	return math.Ceil(v*pow) / pow
+}
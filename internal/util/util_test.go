@@ -0,0 +1,13 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundUpToDecimals(t *testing.T) {
+	assert.Equal(t, 0.12345679, RoundUpToDecimals(0.123456781, 8))
+	assert.Equal(t, 0.5, RoundUpToDecimals(0.5, 8))
+	assert.Equal(t, 1.0, RoundUpToDecimals(0.0000001, 0))
+}
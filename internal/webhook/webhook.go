@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	SignatureHeader  string = "X-Goipay-Signature"
+	DeliveryIdHeader string = "X-Goipay-Delivery-Id"
+
+	InvoiceUpdatedEvent string = "invoice.updated"
+	// Sent instead of InvoiceUpdatedEvent when a chain reorganization rolls the invoice back.
+	InvoiceReorgedEvent string = "invoice.reorged"
+)
+
+type Payload struct {
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Invoice   json.RawMessage `json:"invoice"`
+}
+
+// Sign returns the value of the SignatureHeader in the form of t=<unix timestamp>,v1=<signature>,
+// where the signature is the hex encoded HMAC-SHA256 of "<unix timestamp>.<body>".
+// Receivers should recompute it and reject stale timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%d,v1=%v", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// EnqueueInvoiceEvent stores the invoice event in the outbox if the invoice owner has a webhook endpoint.
+// It's meant to be called within the transaction changing the invoice, so the event can't get lost.
+func EnqueueInvoiceEvent(ctx context.Context, q *db.Queries, event string, invoice *db.Invoice) error {
+	if _, err := q.FindWebhookEndpointByUserId(ctx, invoice.UserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	invoiceJson, err := protojson.Marshal(util.DbInvoiceToPbInvoice(invoice))
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(Payload{Event: event, CreatedAt: time.Now().UTC(), Invoice: invoiceJson})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{UserID: invoice.UserID, InvoiceID: invoice.ID, Payload: payload})
+	return err
+}
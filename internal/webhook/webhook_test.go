@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Sign Timestamp And Body", func(t *testing.T) {
+		assert.Equal(
+			t,
+			"t=1700000000,v1=1a21874b5ec8012357317b583dbc128f2a29c9a58d51963e3716db35df19096a",
+			Sign("secret", 1700000000, []byte(`{"event":"invoice.updated"}`)),
+		)
+	})
+
+	t.Run("Should Depend On Secret And Timestamp", func(t *testing.T) {
+		body := []byte(`{"event":"invoice.updated"}`)
+		assert.NotEqual(t, Sign("secret", 1700000000, body), Sign("another secret", 1700000000, body))
+		assert.NotEqual(t, Sign("secret", 1700000000, body), Sign("secret", 1700000001, body))
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+
+	secret1, err := GenerateSecret()
+	assert.NoError(t, err)
+	secret2, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	assert.Len(t, secret1, 64)
+	assert.NotEqual(t, secret1, secret2)
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, util.WEBHOOK_RETRY_BASE_DELAY, retryDelay(1))
+	assert.Equal(t, 2*util.WEBHOOK_RETRY_BASE_DELAY, retryDelay(2))
+	assert.Equal(t, 8*util.WEBHOOK_RETRY_BASE_DELAY, retryDelay(4))
+	assert.Equal(t, util.WEBHOOK_RETRY_MAX_DELAY, retryDelay(util.WEBHOOK_MAX_ATTEMPTS))
+	assert.LessOrEqual(t, retryDelay(1000), 6*time.Hour)
+}
@@ -0,0 +1,198 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+var webhookEndpointNotFoundErr error = errors.New("webhook endpoint not found")
+
+// retryDelay returns the delay before the next attempt, doubling it with every failed attempt.
+func retryDelay(attempts int32) time.Duration {
+	d := util.WEBHOOK_RETRY_BASE_DELAY
+	for i := int32(1); i < attempts && d < util.WEBHOOK_RETRY_MAX_DELAY; i++ {
+		d *= 2
+	}
+
+	return min(d, util.WEBHOOK_RETRY_MAX_DELAY)
+}
+
+type Worker struct {
+	dbConnPool *pgxpool.Pool
+	client     *http.Client
+	log        *zerolog.Logger
+}
+
+func (w *Worker) send(ctx context.Context, endpoint *db.WebhookEndpoint, delivery *db.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryIdHeader, util.PgUUIDToString(delivery.ID))
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, time.Now().Unix(), delivery.Payload))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %v", res.StatusCode)
+	}
+
+	return nil
+}
+
+func (w *Worker) findEndpoint(ctx context.Context, userId pgtype.UUID) (*db.WebhookEndpoint, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, w.dbConnPool)
+	if err != nil {
+		w.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	endpoint, err := q.FindWebhookEndpointByUserId(ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, webhookEndpointNotFoundErr
+		}
+		w.log.Err(err).Str("queryName", "FindWebhookEndpointByUserId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	tx.Commit(ctx)
+
+	return &endpoint, nil
+}
+
+func (w *Worker) deliver(ctx context.Context, delivery *db.WebhookDelivery) {
+	endpoint, err := w.findEndpoint(ctx, delivery.UserID)
+	if err != nil && !errors.Is(err, webhookEndpointNotFoundErr) {
+		// The delivery will be picked up again once its lease expires.
+		return
+	}
+	if err == nil {
+		err = w.send(ctx, endpoint, delivery)
+	}
+
+	q, tx, txErr := util.InitDbQueriesWithTx(ctx, w.dbConnPool)
+	if txErr != nil {
+		w.log.Err(txErr).Msg(util.DefaultFailedSqlTxInitMsg)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	if err == nil {
+		if _, err := q.MarkWebhookDeliveryDeliveredById(ctx, delivery.ID); err != nil {
+			w.log.Err(err).Str("queryName", "MarkWebhookDeliveryDeliveredById").Msg(util.DefaultFailedSqlQueryMsg)
+			return
+		}
+
+		tx.Commit(ctx)
+
+		w.log.Debug().Str("deliveryId", util.PgUUIDToString(delivery.ID)).Msg("Webhook delivered")
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+	status := db.WebhookDeliveryStatusTypePENDING
+	if attempts >= util.WEBHOOK_MAX_ATTEMPTS || errors.Is(err, webhookEndpointNotFoundErr) {
+		status = db.WebhookDeliveryStatusTypeDEAD
+	}
+
+	if _, err := q.FailWebhookDeliveryById(ctx, db.FailWebhookDeliveryByIdParams{
+		ID:            delivery.ID,
+		Status:        status,
+		LastError:     pgtype.Text{String: err.Error(), Valid: true},
+		NextAttemptAt: pgtype.Timestamptz{Time: time.Now().UTC().Add(retryDelay(attempts)), Valid: true},
+	}); err != nil {
+		w.log.Err(err).Str("queryName", "FailWebhookDeliveryById").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+
+	tx.Commit(ctx)
+
+	w.log.Warn().Err(err).Str("deliveryId", util.PgUUIDToString(delivery.ID)).Int32("attempts", attempts).Msgf("Webhook delivery failed (%v)", status)
+}
+
+// deliverDue claims and delivers a batch of due deliveries returning the number of them.
+func (w *Worker) deliverDue(ctx context.Context) int {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, w.dbConnPool)
+	if err != nil {
+		w.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return 0
+	}
+	defer tx.Rollback(ctx)
+
+	deliveries, err := q.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: pgtype.Timestamptz{Time: time.Now().UTC().Add(util.WEBHOOK_DELIVERY_LEASE), Valid: true},
+		Limit:      util.WEBHOOK_DELIVERY_BATCH_SIZE,
+	})
+	if err != nil {
+		w.log.Err(err).Str("queryName", "ClaimDueWebhookDeliveries").Msg(util.DefaultFailedSqlQueryMsg)
+		return 0
+	}
+
+	tx.Commit(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < len(deliveries); i++ {
+		wg.Add(1)
+		go func(delivery *db.WebhookDelivery) {
+			defer wg.Done()
+			w.deliver(ctx, delivery)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+// drainDue delivers the due deliveries batch by batch, until a batch comes out short or the context is done.
+func (w *Worker) drainDue(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if w.deliverDue(ctx) < util.WEBHOOK_DELIVERY_BATCH_SIZE {
+			return
+		}
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	go func() {
+		for {
+			w.drainDue(ctx)
+
+			select {
+			case <-time.After(util.WEBHOOK_POLL_INTERVAL):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func NewWorker(dbConnPool *pgxpool.Pool, client *http.Client, log *zerolog.Logger) *Worker {
+	return &Worker{dbConnPool: dbConnPool, client: client, log: log}
+}
@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/chekist32/goipay/test"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestInvoice(ctx context.Context, q *db.Queries, userId pgtype.UUID) db.Invoice {
+	invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+		CryptoAddress:         uuid.NewString(),
+		Coin:                  db.CoinTypeXMR,
+		RequiredAmount:        pgtype.Numeric{Int: big.NewInt(1000000000000), Valid: true},
+		ConfirmationsRequired: 0,
+		ExpiresAt:             pgtype.Timestamptz{Time: time.Now().UTC().Add(time.Hour), Valid: true},
+		UserID:                userId,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return invoice
+}
+
+func TestWorker(t *testing.T) {
+	ctx := context.Background()
+
+	dbConn, _, close := test.SpinUpPostgresContainerAndGetPgxpool(fmt.Sprintf("%v/../../sql/migrations", os.Getenv("PWD")))
+	defer close(ctx)
+
+	var statusCode atomic.Int32
+	received := make(chan *http.Request, 1)
+	receivedBody := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		receivedBody <- body
+		w.WriteHeader(int(statusCode.Load()))
+	}))
+	defer server.Close()
+
+	worker := NewWorker(dbConn, server.Client(), &zerolog.Logger{})
+	q := db.New(dbConn)
+
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := q.UpsertWebhookEndpoint(ctx, db.UpsertWebhookEndpointParams{UserID: userId, Url: server.URL, Secret: "secret"}); err != nil {
+		log.Fatal(err)
+	}
+
+	t.Run("Should Deliver Signed Payload", func(t *testing.T) {
+		statusCode.Store(http.StatusOK)
+		invoice := createTestInvoice(ctx, q, userId)
+		assert.NoError(t, EnqueueInvoiceEvent(ctx, q, InvoiceUpdatedEvent, &invoice))
+
+		assert.Equal(t, 1, worker.deliverDue(ctx))
+
+		req, body := <-received, <-receivedBody
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+		signature := req.Header.Get(SignatureHeader)
+		timestamp, err := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, Sign("secret", timestamp, body), signature)
+
+		var payload Payload
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, InvoiceUpdatedEvent, payload.Event)
+		assert.Contains(t, string(payload.Invoice), util.PgUUIDToString(invoice.ID))
+
+		deliveryId, err := util.StringToPgUUID(req.Header.Get(DeliveryIdHeader))
+		assert.NoError(t, err)
+		delivery, err := q.FindWebhookDeliveryById(ctx, *deliveryId)
+		assert.NoError(t, err)
+		assert.Equal(t, db.WebhookDeliveryStatusTypeDELIVERED, delivery.Status)
+		assert.True(t, delivery.DeliveredAt.Valid)
+	})
+
+	t.Run("Should Reschedule Failed Delivery", func(t *testing.T) {
+		statusCode.Store(http.StatusInternalServerError)
+		invoice := createTestInvoice(ctx, q, userId)
+		assert.NoError(t, EnqueueInvoiceEvent(ctx, q, InvoiceUpdatedEvent, &invoice))
+
+		assert.Equal(t, 1, worker.deliverDue(ctx))
+		req := <-received
+		<-receivedBody
+
+		deliveryId, err := util.StringToPgUUID(req.Header.Get(DeliveryIdHeader))
+		assert.NoError(t, err)
+		delivery, err := q.FindWebhookDeliveryById(ctx, *deliveryId)
+		assert.NoError(t, err)
+		assert.Equal(t, db.WebhookDeliveryStatusTypePENDING, delivery.Status)
+		assert.Equal(t, int32(1), delivery.Attempts)
+		assert.Equal(t, "unexpected status code: 500", delivery.LastError.String)
+		assert.True(t, delivery.NextAttemptAt.Time.After(time.Now()))
+
+		// Not due yet
+		assert.Equal(t, 0, worker.deliverDue(ctx))
+	})
+
+	t.Run("Should Not Enqueue Without Endpoint", func(t *testing.T) {
+		anotherUserId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		invoice := createTestInvoice(ctx, q, anotherUserId)
+
+		assert.NoError(t, EnqueueInvoiceEvent(ctx, q, InvoiceUpdatedEvent, &invoice))
+
+		deliveries, err := q.FindWebhookDeliveriesByFilter(ctx, db.FindWebhookDeliveriesByFilterParams{UserID: anotherUserId, Limit: 10})
+		assert.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+}
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE invoice_status_type ADD VALUE 'CANCELLED';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE invoices SET status = 'EXPIRED' WHERE status = 'CANCELLED';
+-- +goose StatementEnd
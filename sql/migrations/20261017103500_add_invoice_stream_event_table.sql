@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS invoice_stream_events(
+    seq BIGSERIAL PRIMARY KEY,
+    invoice_id UUID NOT NULL REFERENCES invoices (id),
+    status invoice_status_type NOT NULL,
+    actual_amount DOUBLE PRECISION,
+    tx_id TEXT,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE invoice_stream_events;
+-- +goose StatementEnd
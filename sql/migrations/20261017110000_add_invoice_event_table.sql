@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS invoice_events(
+    id BIGSERIAL PRIMARY KEY,
+    invoice_id UUID NOT NULL REFERENCES invoices (id),
+    old_status invoice_status_type,
+    new_status invoice_status_type NOT NULL,
+    tx_id TEXT,
+    amount DOUBLE PRECISION,
+    confirmations BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', clock_timestamp())
+);
+
+CREATE INDEX invoice_events_invoice_id_idx ON invoice_events (invoice_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE invoice_events;
+-- +goose StatementEnd
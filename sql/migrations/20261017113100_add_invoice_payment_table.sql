@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS invoice_payments(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID NOT NULL REFERENCES invoices (id),
+    tx_id TEXT NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT unique_invoice_id_tx_id UNIQUE (invoice_id, tx_id)
+);
+
+INSERT INTO invoice_payments(invoice_id, tx_id, amount, created_at, confirmed_at)
+SELECT id, tx_id, COALESCE(actual_amount, 0), created_at, confirmed_at FROM invoices
+WHERE tx_id IS NOT NULL;
+
+ALTER TABLE invoices DROP CONSTRAINT unique_tx_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoices ADD CONSTRAINT unique_tx_id UNIQUE (tx_id);
+
+DROP TABLE invoice_payments;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invoices ADD COLUMN underpayment_tolerance DOUBLE PRECISION NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoices DROP COLUMN underpayment_tolerance;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE invoice_status_type ADD VALUE 'PAID_AFTER_EXPIRY';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE invoices SET status = 'EXPIRED' WHERE status = 'PAID_AFTER_EXPIRY';
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invoices
+    ADD COLUMN fiat_amount DOUBLE PRECISION,
+    ADD COLUMN fiat_currency TEXT,
+    ADD COLUMN exchange_rate DOUBLE PRECISION;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoices
+    DROP COLUMN fiat_amount,
+    DROP COLUMN fiat_currency,
+    DROP COLUMN exchange_rate;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invoices
+    ADD COLUMN idempotency_key TEXT,
+    ADD COLUMN idempotency_request_hash TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_user_id_idempotency_key ON invoices (user_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX unique_user_id_idempotency_key;
+
+ALTER TABLE invoices
+    DROP COLUMN idempotency_key,
+    DROP COLUMN idempotency_request_hash;
+-- +goose StatementEnd
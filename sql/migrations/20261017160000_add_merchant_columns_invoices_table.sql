@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invoices
+    ADD COLUMN description TEXT,
+    ADD COLUMN external_order_id TEXT,
+    ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS invoices_user_id_external_order_id_idx ON invoices (user_id, external_order_id) WHERE external_order_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX invoices_user_id_external_order_id_idx;
+
+ALTER TABLE invoices
+    DROP COLUMN description,
+    DROP COLUMN external_order_id,
+    DROP COLUMN metadata;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invoices ADD COLUMN group_id UUID;
+
+CREATE INDEX IF NOT EXISTS invoices_group_id_idx ON invoices (group_id) WHERE group_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX invoices_group_id_idx;
+
+ALTER TABLE invoices DROP COLUMN group_id;
+-- +goose StatementEnd
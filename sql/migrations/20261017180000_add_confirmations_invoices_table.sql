@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invoices ADD COLUMN confirmations INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE invoice_stream_events ADD COLUMN confirmations INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoice_stream_events DROP COLUMN confirmations;
+
+ALTER TABLE invoices DROP COLUMN confirmations;
+-- +goose StatementEnd
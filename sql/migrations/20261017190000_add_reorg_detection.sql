@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS crypto_block_hashes(
+    coin coin_type NOT NULL REFERENCES crypto_cache (coin) ON DELETE CASCADE,
+    height BIGINT NOT NULL,
+    hash TEXT NOT NULL,
+    PRIMARY KEY (coin, height)
+);
+
+ALTER TABLE invoice_events ADD COLUMN reorg BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoice_events DROP COLUMN reorg;
+
+DROP TABLE crypto_block_hashes;
+-- +goose StatementEnd
@@ -0,0 +1,96 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Only used to convert the stored amounts both ways, the decimals registry itself lives in the application (util.CoinDecimals).
+CREATE FUNCTION atomic_units_migration_coin_decimals(c coin_type) RETURNS INTEGER AS $$
+    SELECT CASE c
+        WHEN 'XMR' THEN 12
+        WHEN 'BTC' THEN 8
+        WHEN 'LTC' THEN 8
+        WHEN 'TON' THEN 9
+        WHEN 'USDT_ERC20' THEN 6
+        WHEN 'USDC_ERC20' THEN 6
+        WHEN 'WBTC_ERC20' THEN 8
+        WHEN 'ATOM_ERC20' THEN 6
+        WHEN 'WBTC_BEP20' THEN 8
+        WHEN 'TRX_BEP20' THEN 6
+        WHEN 'DOGE_BEP20' THEN 8
+        ELSE 18
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE invoices
+    ALTER COLUMN required_amount TYPE NUMERIC(78, 0) USING ROUND(required_amount::NUMERIC * 10::NUMERIC ^ atomic_units_migration_coin_decimals(coin)),
+    ALTER COLUMN actual_amount TYPE NUMERIC(78, 0) USING ROUND(actual_amount::NUMERIC * 10::NUMERIC ^ atomic_units_migration_coin_decimals(coin)),
+    ALTER COLUMN underpayment_tolerance DROP DEFAULT,
+    ALTER COLUMN underpayment_tolerance TYPE NUMERIC(78, 0) USING ROUND(underpayment_tolerance::NUMERIC * 10::NUMERIC ^ atomic_units_migration_coin_decimals(coin)),
+    ALTER COLUMN underpayment_tolerance SET DEFAULT 0;
+
+ALTER TABLE invoice_payments ADD COLUMN amount_atomic NUMERIC(78, 0);
+UPDATE invoice_payments p SET amount_atomic = ROUND(p.amount::NUMERIC * 10::NUMERIC ^ atomic_units_migration_coin_decimals(i.coin))
+    FROM invoices i WHERE i.id = p.invoice_id;
+ALTER TABLE invoice_payments DROP COLUMN amount;
+ALTER TABLE invoice_payments RENAME COLUMN amount_atomic TO amount;
+ALTER TABLE invoice_payments ALTER COLUMN amount SET NOT NULL;
+
+ALTER TABLE invoice_events ADD COLUMN amount_atomic NUMERIC(78, 0);
+UPDATE invoice_events e SET amount_atomic = ROUND(e.amount::NUMERIC * 10::NUMERIC ^ atomic_units_migration_coin_decimals(i.coin))
+    FROM invoices i WHERE i.id = e.invoice_id;
+ALTER TABLE invoice_events DROP COLUMN amount;
+ALTER TABLE invoice_events RENAME COLUMN amount_atomic TO amount;
+
+ALTER TABLE invoice_stream_events ADD COLUMN actual_amount_atomic NUMERIC(78, 0);
+UPDATE invoice_stream_events e SET actual_amount_atomic = ROUND(e.actual_amount::NUMERIC * 10::NUMERIC ^ atomic_units_migration_coin_decimals(i.coin))
+    FROM invoices i WHERE i.id = e.invoice_id;
+ALTER TABLE invoice_stream_events DROP COLUMN actual_amount;
+ALTER TABLE invoice_stream_events RENAME COLUMN actual_amount_atomic TO actual_amount;
+
+DROP FUNCTION atomic_units_migration_coin_decimals;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE FUNCTION atomic_units_migration_coin_decimals(c coin_type) RETURNS INTEGER AS $$
+    SELECT CASE c
+        WHEN 'XMR' THEN 12
+        WHEN 'BTC' THEN 8
+        WHEN 'LTC' THEN 8
+        WHEN 'TON' THEN 9
+        WHEN 'USDT_ERC20' THEN 6
+        WHEN 'USDC_ERC20' THEN 6
+        WHEN 'WBTC_ERC20' THEN 8
+        WHEN 'ATOM_ERC20' THEN 6
+        WHEN 'WBTC_BEP20' THEN 8
+        WHEN 'TRX_BEP20' THEN 6
+        WHEN 'DOGE_BEP20' THEN 8
+        ELSE 18
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE invoice_stream_events ADD COLUMN actual_amount_float DOUBLE PRECISION;
+UPDATE invoice_stream_events e SET actual_amount_float = e.actual_amount / 10::NUMERIC ^ atomic_units_migration_coin_decimals(i.coin)
+    FROM invoices i WHERE i.id = e.invoice_id;
+ALTER TABLE invoice_stream_events DROP COLUMN actual_amount;
+ALTER TABLE invoice_stream_events RENAME COLUMN actual_amount_float TO actual_amount;
+
+ALTER TABLE invoice_events ADD COLUMN amount_float DOUBLE PRECISION;
+UPDATE invoice_events e SET amount_float = e.amount / 10::NUMERIC ^ atomic_units_migration_coin_decimals(i.coin)
+    FROM invoices i WHERE i.id = e.invoice_id;
+ALTER TABLE invoice_events DROP COLUMN amount;
+ALTER TABLE invoice_events RENAME COLUMN amount_float TO amount;
+
+ALTER TABLE invoice_payments ADD COLUMN amount_float DOUBLE PRECISION;
+UPDATE invoice_payments p SET amount_float = p.amount / 10::NUMERIC ^ atomic_units_migration_coin_decimals(i.coin)
+    FROM invoices i WHERE i.id = p.invoice_id;
+ALTER TABLE invoice_payments DROP COLUMN amount;
+ALTER TABLE invoice_payments RENAME COLUMN amount_float TO amount;
+ALTER TABLE invoice_payments ALTER COLUMN amount SET NOT NULL;
+
+ALTER TABLE invoices
+    ALTER COLUMN required_amount TYPE DOUBLE PRECISION USING required_amount / 10::NUMERIC ^ atomic_units_migration_coin_decimals(coin),
+    ALTER COLUMN actual_amount TYPE DOUBLE PRECISION USING actual_amount / 10::NUMERIC ^ atomic_units_migration_coin_decimals(coin),
+    ALTER COLUMN underpayment_tolerance DROP DEFAULT,
+    ALTER COLUMN underpayment_tolerance TYPE DOUBLE PRECISION USING underpayment_tolerance / 10::NUMERIC ^ atomic_units_migration_coin_decimals(coin),
+    ALTER COLUMN underpayment_tolerance SET DEFAULT 0;
+
+DROP FUNCTION atomic_units_migration_coin_decimals;
+-- +goose StatementEnd
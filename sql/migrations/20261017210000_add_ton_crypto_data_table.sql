@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ton_crypto_data(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address TEXT NOT NULL UNIQUE,
+    last_memo_index INTEGER NOT NULL DEFAULT 0
+);
+
+ALTER TABLE crypto_data ADD COLUMN ton_id UUID REFERENCES ton_crypto_data (id);
+
+-- TON invoices share the merchant wallet and are told apart by the comment (memo) attached to the transfer.
+ALTER TABLE crypto_addresses ADD COLUMN memo TEXT;
+ALTER TABLE crypto_addresses DROP CONSTRAINT crypto_addresses_address_key;
+-- Partial indexes instead of UNIQUE NULLS NOT DISTINCT, which needs PostgreSQL 15.
+CREATE UNIQUE INDEX IF NOT EXISTS unique_address_without_memo ON crypto_addresses (address) WHERE memo IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS unique_address_memo ON crypto_addresses (address, memo) WHERE memo IS NOT NULL;
+
+ALTER TABLE invoices ADD COLUMN memo TEXT;
+
+--Jettons
+ALTER TYPE coin_type ADD VALUE 'USDT_TON';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoices DROP COLUMN memo;
+
+DELETE FROM crypto_addresses WHERE memo IS NOT NULL;
+DROP INDEX unique_address_memo;
+DROP INDEX unique_address_without_memo;
+ALTER TABLE crypto_addresses DROP COLUMN memo;
+ALTER TABLE crypto_addresses ADD CONSTRAINT crypto_addresses_address_key UNIQUE (address);
+
+ALTER TABLE crypto_data DROP COLUMN ton_id;
+
+DROP TABLE ton_crypto_data;
+-- +goose StatementEnd
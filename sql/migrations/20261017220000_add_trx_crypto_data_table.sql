@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS trx_crypto_data(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    master_pub_key TEXT NOT NULL UNIQUE,
+    last_major_index INTEGER NOT NULL DEFAULT 0,
+    last_minor_index INTEGER NOT NULL DEFAULT 0
+);
+
+ALTER TABLE crypto_data ADD COLUMN trx_id UUID REFERENCES trx_crypto_data (id);
+
+ALTER TYPE coin_type ADD VALUE 'TRX';
+--TRC20
+ALTER TYPE coin_type ADD VALUE 'USDT_TRC20';
+ALTER TYPE coin_type ADD VALUE 'USDC_TRC20';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE crypto_data DROP COLUMN trx_id;
+
+DROP TABLE trx_crypto_data;
+-- +goose StatementEnd
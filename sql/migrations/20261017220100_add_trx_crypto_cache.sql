@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO crypto_cache(coin) VALUES ('TRX');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM crypto_cache WHERE coin = 'TRX';
+-- +goose StatementEnd
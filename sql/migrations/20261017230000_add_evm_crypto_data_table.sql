@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The keys of the EVM chains declared in the config, whose coins are added to coin_type on the startup.
+CREATE TABLE IF NOT EXISTS evm_crypto_data(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    chain TEXT NOT NULL,
+    master_pub_key TEXT NOT NULL,
+    last_major_index INTEGER NOT NULL DEFAULT 0,
+    last_minor_index INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (user_id, chain)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE evm_crypto_data;
+-- +goose StatementEnd
@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The token registry of the ETH compatible chains. chain is the native coin of the chain and chain_id is the EIP-155 ID
+-- of its network, so the testnet tokens are kept apart from the mainnet ones. The coins of the tokens not known to
+-- coin_type are added to it on the startup.
+CREATE TABLE IF NOT EXISTS tokens(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chain coin_type NOT NULL,
+    chain_id BIGINT NOT NULL,
+    symbol TEXT NOT NULL,
+    coin coin_type NOT NULL,
+    contract_address TEXT NOT NULL,
+    decimals INTEGER NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    UNIQUE (chain, chain_id, symbol)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO tokens(chain, chain_id, symbol, coin, contract_address, decimals) VALUES
+    ('ETH', 1, 'USDT', 'USDT_ERC20', '0xdAC17F958D2ee523a2206206994597C13D831ec7', 6),
+    ('ETH', 1, 'USDC', 'USDC_ERC20', '0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48', 6),
+    ('ETH', 1, 'DAI', 'DAI_ERC20', '0x6B175474E89094C44Da98b954EedeAC495271d0F', 18),
+    ('ETH', 1, 'WBTC', 'WBTC_ERC20', '0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599', 8),
+    ('ETH', 1, 'UNI', 'UNI_ERC20', '0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984', 18),
+    ('ETH', 1, 'LINK', 'LINK_ERC20', '0x514910771AF9Ca656af840dff83E8264EcF986CA', 18),
+    ('ETH', 1, 'AAVE', 'AAVE_ERC20', '0x7Fc66500c84A76Ad7e9c93437bFc5Ac33E2DDaE9', 18),
+    ('ETH', 1, 'CRV', 'CRV_ERC20', '0xD533a949740bb3306d119CC777fa900bA034cd52', 18),
+    ('ETH', 1, 'MATIC', 'MATIC_ERC20', '0x7D1AfA7B718fb893dB30A3aBc0Cfc608AaCfeBB0', 18),
+    ('ETH', 1, 'SHIB', 'SHIB_ERC20', '0x95aD61b0a150d79219dCF64E1E6Cc01f0B64C4cE', 18),
+    ('ETH', 1, 'BNB', 'BNB_ERC20', '0xB8c77482e45F1F44dE1745F52C74426C631bDD52', 18),
+    ('ETH', 1, 'ATOM', 'ATOM_ERC20', '0x8D983cb9388EaC77af0474fA441C4815500Cb7BB', 6),
+    ('ETH', 1, 'ARB', 'ARB_ERC20', '0xB50721BCf8d664c30412Cfbc6cf7a15145234ad1', 18),
+
+    ('BNB', 56, 'BSC-USD', 'BSC-USD_BEP20', '0x55d398326f99059fF775485246999027B3197955', 18),
+    ('BNB', 56, 'USDC', 'USDC_BEP20', '0x8AC76a51cc950d9822D68b83fE1Ad97B32Cd580d', 18),
+    ('BNB', 56, 'DAI', 'DAI_BEP20', '0x1AF3F329e8BE154074D8769D1FFa4eE058B1DBc3', 18),
+    ('BNB', 56, 'WBTC', 'WBTC_BEP20', '0x0555E30da8f98308EdB960aa94C0Db47230d2B9c', 8),
+    ('BNB', 56, 'UNI', 'UNI_BEP20', '0xBf5140A22578168FD562DCcF235E5D43A02ce9B1', 18),
+    ('BNB', 56, 'LINK', 'LINK_BEP20', '0xF8A0BF9cF54Bb92F17374d9e9A321E6a111a51bD', 18),
+    ('BNB', 56, 'AAVE', 'AAVE_BEP20', '0xfb6115445Bff7b52FeB98650C87f44907E58f802', 18),
+    ('BNB', 56, 'MATIC', 'MATIC_BEP20', '0xCC42724C6683B7E57334c4E856f4c9965ED682bD', 18),
+    ('BNB', 56, 'SHIB', 'SHIB_BEP20', '0x2859e4544C4bB03966803b044A93563Bd2D0DD4D', 18),
+    ('BNB', 56, 'BUSD', 'BUSD_BEP20', '0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56', 18),
+    ('BNB', 56, 'ATOM', 'ATOM_BEP20', '0x0Eb3a705fc54725037CC9e008bDede697f62F335', 18),
+    ('BNB', 56, 'ARB', 'ARB_BEP20', '0xa050FFb3eEb8200eEB7F61ce34FF644420FD3522', 18),
+    ('BNB', 56, 'ETH', 'ETH_BEP20', '0x2170Ed0880ac9A755fd29B2688956BD959F933F8', 18),
+    ('BNB', 56, 'XRP', 'XRP_BEP20', '0x1D2F0da169ceB9fC7B3144628dB156f3F6c60dBE', 18),
+    ('BNB', 56, 'ADA', 'ADA_BEP20', '0x3EE2200Efb3400fAbB9AacF31297cBdD1d435D47', 18),
+    ('BNB', 56, 'TRX', 'TRX_BEP20', '0xCE7de646e7208a4Ef112cb6ed5038FA6cC6b12e3', 6),
+    ('BNB', 56, 'DOGE', 'DOGE_BEP20', '0xbA2aE424d960c26247Dd6c32edC70B295c744C43', 8),
+    ('BNB', 56, 'LTC', 'LTC_BEP20', '0x4338665CBB7B2485A8855A139b75D5e34AB0DB94', 18),
+    ('BNB', 56, 'BCH', 'BCH_BEP20', '0x8fF795a6F4D97E7887C79beA79aba5cc76444aDf', 18),
+    ('BNB', 56, 'TWT', 'TWT_BEP20', '0x4B0F1812e5Df2A09796481Ff14017e6005508003', 18),
+    ('BNB', 56, 'AVAX', 'AVAX_BEP20', '0x1CE0c2827e2eF14D5C4f29a091d735A204794041', 18),
+    ('BNB', 56, 'CAKE', 'CAKE_BEP20', '0x0E09FaBB73Bd3Ade0a17ECC321fD13a19e81cE82', 18);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE tokens;
+-- +goose StatementEnd
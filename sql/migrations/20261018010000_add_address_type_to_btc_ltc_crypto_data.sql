@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE address_type AS ENUM ('P2PKH', 'P2SH_P2WPKH', 'P2WPKH', 'P2TR');
+
+-- The addresses used to be P2WPKH regardless of the key, so the existing keys keep them.
+ALTER TABLE btc_crypto_data ADD COLUMN address_type address_type NOT NULL DEFAULT 'P2WPKH';
+ALTER TABLE ltc_crypto_data ADD COLUMN address_type address_type NOT NULL DEFAULT 'P2WPKH';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ltc_crypto_data DROP COLUMN address_type;
+ALTER TABLE btc_crypto_data DROP COLUMN address_type;
+
+DROP TYPE address_type;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Numbers the addresses in the order they were derived.
+ALTER TABLE crypto_addresses ADD COLUMN derivation_seq BIGINT NOT NULL DEFAULT 0;
+
+-- The existing addresses were derived along with their first invoice, so they are numbered in the order of it.
+UPDATE crypto_addresses AS ca
+SET derivation_seq = numbered.seq
+FROM (
+    SELECT a.id, row_number() OVER (PARTITION BY a.user_id, a.coin ORDER BY fi.created_at NULLS LAST, a.id) AS seq
+    FROM crypto_addresses AS a
+    LEFT JOIN LATERAL (
+        SELECT MIN(i.created_at) AS created_at FROM invoices AS i
+        WHERE i.crypto_address = a.address AND i.memo IS NOT DISTINCT FROM a.memo
+    ) AS fi ON TRUE
+) AS numbered
+WHERE ca.id = numbered.id;
+
+CREATE SEQUENCE crypto_addresses_derivation_seq_seq OWNED BY crypto_addresses.derivation_seq;
+SELECT setval('crypto_addresses_derivation_seq_seq', COALESCE((SELECT MAX(derivation_seq) FROM crypto_addresses), 0) + 1, false);
+ALTER TABLE crypto_addresses ALTER COLUMN derivation_seq SET DEFAULT nextval('crypto_addresses_derivation_seq_seq');
+
+CREATE INDEX IF NOT EXISTS crypto_addresses_user_id_coin_idx ON crypto_addresses (user_id, coin);
+CREATE INDEX IF NOT EXISTS invoices_crypto_address_idx ON invoices (crypto_address);
+
+CREATE TABLE IF NOT EXISTS address_pool_configs(
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    coin coin_type NOT NULL,
+    min_free_addresses INTEGER NOT NULL DEFAULT 0,
+    gap_limit INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    PRIMARY KEY (user_id, coin)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE address_pool_configs;
+
+DROP INDEX invoices_crypto_address_idx;
+DROP INDEX crypto_addresses_user_id_coin_idx;
+
+ALTER TABLE crypto_addresses DROP COLUMN derivation_seq;
+-- +goose StatementEnd
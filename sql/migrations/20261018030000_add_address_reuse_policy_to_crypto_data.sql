@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE address_reuse_policy AS ENUM ('REUSE', 'NEVER_REUSE', 'REUSE_UNFUNDED');
+
+-- The released addresses used to be reused regardless, so the existing users keep it.
+ALTER TABLE crypto_data ADD COLUMN address_reuse_policy address_reuse_policy NOT NULL DEFAULT 'REUSE';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE crypto_data DROP COLUMN address_reuse_policy;
+
+DROP TYPE address_reuse_policy;
+-- +goose StatementEnd
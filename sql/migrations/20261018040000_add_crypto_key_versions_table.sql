@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS crypto_key_versions(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    coin coin_type NOT NULL,
+    version INTEGER NOT NULL,
+    -- The master public key, the XMR public spend key or the TON wallet address.
+    public_key TEXT NOT NULL,
+    -- The XMR private view key, as the payments to the subaddresses of a retired version still need it. NULL for the other coins.
+    priv_view_key TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    retired_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (user_id, coin, version)
+);
+
+-- The addresses of the previous key versions are retired rather than deleted, so the invoices pending on them can finish.
+ALTER TABLE crypto_addresses
+    ADD COLUMN key_version INTEGER,
+    -- Relative to the key, e.g. 0/5 (the XMR subaddress major/minor index). NULL for the memo-based coins and the addresses derived before it was recorded.
+    ADD COLUMN derivation_path TEXT,
+    ADD COLUMN is_retired BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The current keys become the first version.
+INSERT INTO crypto_key_versions(user_id, coin, version, public_key, priv_view_key)
+SELECT cd.user_id, 'XMR'::coin_type, 1, x.pub_spend_key, x.priv_view_key FROM crypto_data AS cd JOIN xmr_crypto_data AS x ON x.id = cd.xmr_id
+UNION ALL
+SELECT cd.user_id, 'BTC'::coin_type, 1, b.master_pub_key, NULL FROM crypto_data AS cd JOIN btc_crypto_data AS b ON b.id = cd.btc_id
+UNION ALL
+SELECT cd.user_id, 'LTC'::coin_type, 1, l.master_pub_key, NULL FROM crypto_data AS cd JOIN ltc_crypto_data AS l ON l.id = cd.ltc_id
+UNION ALL
+SELECT cd.user_id, 'ETH'::coin_type, 1, e.master_pub_key, NULL FROM crypto_data AS cd JOIN eth_crypto_data AS e ON e.id = cd.eth_id
+UNION ALL
+SELECT cd.user_id, 'BNB'::coin_type, 1, b.master_pub_key, NULL FROM crypto_data AS cd JOIN bnb_crypto_data AS b ON b.id = cd.bnb_id
+UNION ALL
+SELECT cd.user_id, 'TON'::coin_type, 1, t.wallet_address, NULL FROM crypto_data AS cd JOIN ton_crypto_data AS t ON t.id = cd.ton_id
+UNION ALL
+SELECT cd.user_id, 'TRX'::coin_type, 1, t.master_pub_key, NULL FROM crypto_data AS cd JOIN trx_crypto_data AS t ON t.id = cd.trx_id
+UNION ALL
+SELECT e.user_id, e.chain::coin_type, 1, e.master_pub_key, NULL FROM evm_crypto_data AS e;
+
+UPDATE crypto_addresses AS ca SET key_version = 1
+WHERE EXISTS (SELECT 1 FROM crypto_key_versions AS kv WHERE kv.user_id = ca.user_id AND kv.coin = ca.coin);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM crypto_addresses WHERE is_retired;
+
+ALTER TABLE crypto_addresses
+    DROP COLUMN is_retired,
+    DROP COLUMN derivation_path,
+    DROP COLUMN key_version;
+
+DROP TABLE crypto_key_versions;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- name: UpsertAddressPoolConfig :one
+INSERT INTO address_pool_configs(user_id, coin, min_free_addresses, gap_limit) VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, coin) DO UPDATE
+SET min_free_addresses = EXCLUDED.min_free_addresses, gap_limit = EXCLUDED.gap_limit, updated_at = timezone('UTC', now())
+RETURNING *;
+
+-- name: FindAddressPoolConfigByUserIdAndCoin :one
+SELECT * FROM address_pool_configs
+WHERE user_id = $1 AND coin = $2;
+
+-- name: FindAddressPoolConfigAndLockByUserIdAndCoin :one
+SELECT * FROM address_pool_configs
+WHERE user_id = $1 AND coin = $2
+FOR UPDATE;
+
+-- name: FindAddressPoolStatsByUserIdAndCoin :one
+-- Only the addresses of the current key version count.
+-- gap_addresses are the ones derived after the last address that received funds.
+-- free_addresses are the ones FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin may take under the address reuse policy.
+WITH last_funded AS (
+    SELECT COALESCE(MAX(ca.derivation_seq), 0)::BIGINT AS derivation_seq
+    FROM crypto_addresses AS ca
+    WHERE ca.user_id = $1 AND ca.coin = $2 AND NOT ca.is_retired AND EXISTS (
+        SELECT 1 FROM invoices AS i
+        WHERE i.crypto_address = ca.address AND i.memo IS NOT DISTINCT FROM ca.memo AND i.user_id = ca.user_id AND i.actual_amount > 0
+    )
+)
+SELECT
+    COUNT(ca.id) AS total_addresses,
+    COUNT(ca.id) FILTER (WHERE NOT ca.is_occupied AND (
+        COALESCE(cd.address_reuse_policy, 'REUSE') = 'REUSE' OR NOT EXISTS (
+            SELECT 1 FROM invoices AS i
+            WHERE i.crypto_address = ca.address AND i.memo IS NOT DISTINCT FROM ca.memo AND i.user_id = ca.user_id
+                AND (cd.address_reuse_policy = 'NEVER_REUSE' OR i.actual_amount > 0)
+        )
+    )) AS free_addresses,
+    COUNT(ca.id) FILTER (WHERE ca.derivation_seq > lf.derivation_seq) AS gap_addresses
+FROM last_funded AS lf
+LEFT JOIN crypto_data AS cd ON cd.user_id = $1
+LEFT JOIN crypto_addresses AS ca ON ca.user_id = $1 AND ca.coin = $2 AND NOT ca.is_retired;
@@ -1,13 +1,49 @@
 -- name: CreateCryptoAddress :one
-INSERT INTO crypto_addresses(address, coin, is_occupied, user_id) VALUES ($1, $2, $3, $4)
+-- The address is derived from the current key version of the coin.
+INSERT INTO crypto_addresses(address, coin, is_occupied, user_id, memo, derivation_path, key_version)
+VALUES ($1, $2, $3, $4, $5, $6, (
+    SELECT MAX(kv.version) FROM crypto_key_versions AS kv WHERE kv.user_id = $4 AND kv.coin = $2
+))
+RETURNING *;
+
+-- name: ReviveRetiredCryptoAddress :one
+-- An address derived again from a re-uploaded key takes over its retired row, as the address is unique.
+UPDATE crypto_addresses AS ca
+SET is_occupied = sqlc.arg('is_occupied'),
+    is_retired = false,
+    derivation_path = sqlc.narg('derivation_path'),
+    key_version = (SELECT MAX(kv.version) FROM crypto_key_versions AS kv WHERE kv.user_id = sqlc.arg('user_id') AND kv.coin = sqlc.arg('coin')),
+    derivation_seq = nextval(pg_get_serial_sequence('crypto_addresses', 'derivation_seq'))
+WHERE ca.address = sqlc.arg('address') AND ca.memo IS NOT DISTINCT FROM sqlc.narg('memo') AND ca.user_id = sqlc.arg('user_id') AND ca.coin = sqlc.arg('coin')
+    AND ca.is_retired AND NOT ca.is_occupied
+RETURNING *;
+
+-- name: ExistsRetiredCryptoAddress :one
+SELECT EXISTS (
+    SELECT 1 FROM crypto_addresses
+    WHERE address = $1 AND memo IS NOT DISTINCT FROM sqlc.narg('memo') AND is_retired
+);
+
+-- name: RetireAllCryptoAddressesByUserIdAndCoin :many
+UPDATE crypto_addresses
+SET is_retired = true
+WHERE user_id = $1 AND coin = $2 AND NOT is_retired
 RETURNING *;
 
 -- name: FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin :one
+-- The address reuse policy of the user decides which of the released addresses may take another invoice.
 UPDATE crypto_addresses SET is_occupied = true
-WHERE address = (
-    SELECT address FROM crypto_addresses AS ca
-    WHERE ca.user_id = $1 AND ca.coin = $2 AND ca.is_occupied = false 
-    FOR UPDATE SKIP LOCKED
+WHERE id = (
+    SELECT ca.id FROM crypto_addresses AS ca
+    LEFT JOIN crypto_data AS cd ON cd.user_id = ca.user_id
+    WHERE ca.user_id = $1 AND ca.coin = $2 AND ca.is_occupied = false AND NOT ca.is_retired AND (
+        COALESCE(cd.address_reuse_policy, 'REUSE') = 'REUSE' OR NOT EXISTS (
+            SELECT 1 FROM invoices AS i
+            WHERE i.crypto_address = ca.address AND i.memo IS NOT DISTINCT FROM ca.memo AND i.user_id = ca.user_id
+                AND (cd.address_reuse_policy = 'NEVER_REUSE' OR i.actual_amount > 0)
+        )
+    )
+    FOR UPDATE OF ca SKIP LOCKED
     LIMIT 1
 )
 RETURNING *;
@@ -15,7 +51,7 @@ RETURNING *;
 -- name: UpdateIsOccupiedByCryptoAddress :one
 UPDATE crypto_addresses 
 SET is_occupied = $2
-WHERE address = $1
+WHERE address = $1 AND memo IS NOT DISTINCT FROM sqlc.narg('memo')
 RETURNING *;
 
 -- name: DeleteAllCryptoAddressByUserIdAndCoin :many
@@ -8,4 +8,22 @@ UPDATE crypto_cache
 SET last_synced_block_height = $2,
     synced_timestamp = timezone('UTC', now())
 WHERE coin = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+
+-- name: FindCryptoBlockHashesByCoin :many
+SELECT * FROM crypto_block_hashes
+WHERE coin = $1
+ORDER BY height;
+
+-- name: DeleteCryptoBlockHashesByCoin :exec
+DELETE FROM crypto_block_hashes
+WHERE coin = $1;
+
+-- name: CreateCryptoBlockHashes :exec
+INSERT INTO crypto_block_hashes(coin, height, hash)
+SELECT sqlc.arg(coin)::coin_type, unnest(sqlc.arg(heights)::BIGINT[]), unnest(sqlc.arg(hashes)::TEXT[]);
+
+-- name: CreateCryptoCacheIfNotExists :exec
+INSERT INTO crypto_cache(coin) VALUES ($1)
+ON CONFLICT DO NOTHING;
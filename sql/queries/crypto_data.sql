@@ -6,6 +6,12 @@ RETURNING *;
 SELECT * FROM crypto_data 
 WHERE user_id = $1;
 
+-- name: UpdateAddressReusePolicyCryptoDataByUserId :one
+UPDATE crypto_data
+SET address_reuse_policy = $2
+WHERE user_id = $1
+RETURNING *;
+
 -- name: SetXMRCryptoDataByUserId :one
 UPDATE crypto_data
 SET xmr_id = $2 
@@ -36,6 +42,18 @@ SET bnb_id = $2
 WHERE user_id = $1
 RETURNING *;
 
+-- name: SetTONCryptoDataByUserId :one
+UPDATE crypto_data
+SET ton_id = $2
+WHERE user_id = $1
+RETURNING *;
+
+-- name: SetTRXCryptoDataByUserId :one
+UPDATE crypto_data
+SET trx_id = $2
+WHERE user_id = $1
+RETURNING *;
+
 
 -- XMR
 -- name: CreateXMRCryptoData :one
@@ -76,7 +94,7 @@ INSERT INTO btc_crypto_data(master_pub_key) VALUES ($1)
 RETURNING *;
 
 -- name: FindKeysAndLockBTCCryptoDataById :one
-SELECT master_pub_key
+SELECT master_pub_key, address_type
 FROM btc_crypto_data
 WHERE id = $1
 FOR SHARE;
@@ -89,6 +107,12 @@ SET master_pub_key = $2,
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateAddressTypeBTCCryptoDataByUserId :one
+UPDATE btc_crypto_data
+SET address_type = $2
+WHERE id = (SELECT btc_id FROM crypto_data WHERE user_id = $1)
+RETURNING *;
+
 -- name: FindIndicesAndLockBTCCryptoDataById :one
 SELECT last_major_index, last_minor_index 
 FROM btc_crypto_data
@@ -108,7 +132,7 @@ INSERT INTO ltc_crypto_data(master_pub_key) VALUES ($1)
 RETURNING *;
 
 -- name: FindKeysAndLockLTCCryptoDataById :one
-SELECT master_pub_key
+SELECT master_pub_key, address_type
 FROM ltc_crypto_data
 WHERE id = $1
 FOR SHARE;
@@ -121,6 +145,12 @@ SET master_pub_key = $2,
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateAddressTypeLTCCryptoDataByUserId :one
+UPDATE ltc_crypto_data
+SET address_type = $2
+WHERE id = (SELECT ltc_id FROM crypto_data WHERE user_id = $1)
+RETURNING *;
+
 -- name: FindIndicesAndLockLTCCryptoDataById :one
 SELECT last_major_index, last_minor_index 
 FROM ltc_crypto_data
@@ -196,4 +226,88 @@ UPDATE bnb_crypto_data
 SET last_major_index = $2,
     last_minor_index = $3
 WHERE id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- TON
+-- name: CreateTONCryptoData :one
+INSERT INTO ton_crypto_data(wallet_address) VALUES ($1)
+RETURNING *;
+
+-- name: FindWalletAndLockTONCryptoDataById :one
+SELECT wallet_address, last_memo_index
+FROM ton_crypto_data
+WHERE id = $1
+FOR UPDATE;
+
+-- The memo index isn't reset, so the memos of the invoices still pending on the previous wallet are never reused.
+-- name: UpdateWalletTONCryptoDataById :one
+UPDATE ton_crypto_data
+SET wallet_address = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateMemoIndexTONCryptoDataById :one
+UPDATE ton_crypto_data
+SET last_memo_index = $2
+WHERE id = $1
+RETURNING *;
+
+-- TRX
+-- name: CreateTRXCryptoData :one
+INSERT INTO trx_crypto_data(master_pub_key) VALUES ($1)
+RETURNING *;
+
+-- name: FindKeysAndLockTRXCryptoDataById :one
+SELECT master_pub_key
+FROM trx_crypto_data
+WHERE id = $1
+FOR SHARE;
+
+-- name: UpdateKeysTRXCryptoDataById :one
+UPDATE trx_crypto_data
+SET master_pub_key = $2,
+    last_major_index = 0,
+    last_minor_index = 0
+WHERE id = $1
+RETURNING *;
+
+-- name: FindIndicesAndLockTRXCryptoDataById :one
+SELECT last_major_index, last_minor_index 
+FROM trx_crypto_data
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateIndicesTRXCryptoDataById :one
+UPDATE trx_crypto_data
+SET last_major_index = $2,
+    last_minor_index = $3
+WHERE id = $1
+RETURNING *;
+
+-- EVM
+-- name: UpsertKeysEVMCryptoData :one
+INSERT INTO evm_crypto_data(user_id, chain, master_pub_key) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, chain) DO UPDATE
+SET master_pub_key = EXCLUDED.master_pub_key,
+    last_major_index = 0,
+    last_minor_index = 0
+RETURNING *;
+
+-- name: FindKeysAndLockEVMCryptoDataByUserIdAndChain :one
+SELECT master_pub_key
+FROM evm_crypto_data
+WHERE user_id = $1 AND chain = $2
+FOR SHARE;
+
+-- name: FindIndicesAndLockEVMCryptoDataByUserIdAndChain :one
+SELECT last_major_index, last_minor_index 
+FROM evm_crypto_data
+WHERE user_id = $1 AND chain = $2
+FOR UPDATE;
+
+-- name: UpdateIndicesEVMCryptoDataByUserIdAndChain :one
+UPDATE evm_crypto_data
+SET last_major_index = $3,
+    last_minor_index = $4
+WHERE user_id = $1 AND chain = $2
+RETURNING *;
@@ -0,0 +1,21 @@
+-- name: CreateCryptoKeyVersion :one
+-- Retires the current version of the key of the coin, if any, in favour of the new one.
+WITH retired AS (
+    UPDATE crypto_key_versions SET retired_at = timezone('UTC', now())
+    WHERE user_id = $1 AND coin = $2 AND retired_at IS NULL
+)
+INSERT INTO crypto_key_versions(user_id, coin, version, public_key, priv_view_key)
+VALUES ($1, $2, COALESCE((SELECT MAX(kv.version) FROM crypto_key_versions AS kv WHERE kv.user_id = $1 AND kv.coin = $2), 0) + 1, $3, $4)
+RETURNING *;
+
+-- name: FindCryptoKeyVersionsByUserIdAndCoin :many
+SELECT * FROM crypto_key_versions
+WHERE user_id = $1 AND coin = $2
+ORDER BY version DESC;
+
+-- name: FindXMRPrivViewKeyByCryptoAddress :one
+-- The view key of the version the subaddress has been derived from, which might have been retired since.
+SELECT kv.priv_view_key::TEXT
+FROM crypto_addresses AS ca
+JOIN crypto_key_versions AS kv ON kv.user_id = ca.user_id AND kv.coin = ca.coin AND kv.version = ca.key_version
+WHERE ca.address = $1 AND ca.coin = 'XMR' AND kv.priv_view_key IS NOT NULL;
@@ -5,14 +5,76 @@ INSERT INTO invoices(
     required_amount, 
     confirmations_required,
     expires_at,
-    user_id) 
-VALUES ($1, $2, $3, $4, $5, $6)
+    user_id,
+    underpayment_tolerance,
+    fiat_amount,
+    fiat_currency,
+    exchange_rate,
+    idempotency_key,
+    idempotency_request_hash,
+    description,
+    external_order_id,
+    metadata,
+    group_id,
+    memo) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, COALESCE(sqlc.narg('metadata')::jsonb, '{}'), sqlc.narg('group_id'), sqlc.narg('memo'))
 RETURNING *;
 
 
+-- name: FindInvoiceById :one
+SELECT * FROM invoices
+WHERE id = $1;
+
+-- name: FindInvoiceByUserIdAndIdempotencyKey :one
+SELECT * FROM invoices
+WHERE user_id = $1 AND idempotency_key = $2;
+
+-- name: FindInvoicesByGroupId :many
+SELECT * FROM invoices
+WHERE group_id = $1
+ORDER BY created_at, id;
+
+-- name: FindAllPendingInvoicesWithPaidGroupSibling :many
+SELECT * FROM invoices AS i
+WHERE i.status IN ('PENDING', 'PARTIALLY_PAID') AND i.group_id IS NOT NULL AND EXISTS (
+    SELECT 1 FROM invoices AS s
+    WHERE s.group_id = i.group_id AND s.id <> i.id AND s.status IN ('PENDING_MEMPOOL', 'CONFIRMED')
+);
+
+-- name: FindInvoiceAndLockById :one
+SELECT * FROM invoices
+WHERE id = $1
+FOR UPDATE;
+
+-- name: FindInvoicesByFilter :many
+SELECT * FROM invoices
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'))
+    AND (sqlc.narg('coin')::coin_type IS NULL OR coin = sqlc.narg('coin'))
+    AND (sqlc.narg('status')::invoice_status_type IS NULL OR status = sqlc.narg('status'))
+    AND (sqlc.narg('created_at_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_at_from'))
+    AND (sqlc.narg('created_at_to')::timestamptz IS NULL OR created_at <= sqlc.narg('created_at_to'))
+    AND (sqlc.narg('expires_at_from')::timestamptz IS NULL OR expires_at >= sqlc.narg('expires_at_from'))
+    AND (sqlc.narg('expires_at_to')::timestamptz IS NULL OR expires_at <= sqlc.narg('expires_at_to'))
+    AND (sqlc.narg('external_order_id')::text IS NULL OR external_order_id = sqlc.narg('external_order_id'))
+    AND (sqlc.narg('group_id')::uuid IS NULL OR group_id = sqlc.narg('group_id'))
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+
 -- name: FindAllPendingInvoices :many
 SELECT * FROM invoices
-WHERE status IN ('PENDING', 'PENDING_MEMPOOL');
+WHERE status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL');
+
+
+-- name: FindAllExpiredInvoicesOccupyingCryptoAddress :many
+SELECT i.* FROM invoices i
+JOIN crypto_addresses ca ON ca.address = i.crypto_address AND ca.memo IS NOT DISTINCT FROM i.memo
+WHERE i.status IN ('EXPIRED', 'PAID_AFTER_EXPIRY')
+    AND ca.is_occupied
+    AND NOT EXISTS (
+        SELECT 1 FROM invoices ni
+        WHERE ni.crypto_address = i.crypto_address AND ni.memo IS NOT DISTINCT FROM i.memo AND ni.created_at > i.created_at
+    );
 
 
 -- name: ConfirmInvoiceById :one
@@ -30,14 +92,72 @@ SET actual_amount = $2,
 WHERE id = $1
 RETURNING *;
 
+-- name: PartiallyPayInvoiceById :one
+UPDATE invoices
+SET actual_amount = $2,
+    status = 'PARTIALLY_PAID',
+    tx_id = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: PayInvoiceAfterExpiryById :one
+UPDATE invoices
+SET actual_amount = $2,
+    status = 'PAID_AFTER_EXPIRY',
+    tx_id = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateInvoiceConfirmationsById :one
+UPDATE invoices
+SET confirmations = $2
+WHERE id = $1 AND confirmations < $2
+RETURNING *;
+
+-- name: FindReorgCandidateInvoicesByCoins :many
+SELECT * FROM invoices
+WHERE coin::TEXT = ANY(sqlc.arg(coins)::TEXT[])
+    AND (status = 'PENDING_MEMPOOL' OR (status = 'CONFIRMED' AND confirmed_at >= sqlc.arg(confirmed_at_from)));
+
+-- name: RollbackInvoiceById :one
+UPDATE invoices
+SET status = $2,
+    actual_amount = $3,
+    tx_id = $4,
+    confirmed_at = NULL,
+    confirmations = 0,
+    expires_at = GREATEST(expires_at, sqlc.arg(min_expires_at)::TIMESTAMPTZ)
+WHERE id = $1
+RETURNING *;
+
 -- name: ExpireInvoiceById :one
+-- A cancellation or confirmation committed before the timeout fired is kept.
 UPDATE invoices
 SET status = 'EXPIRED'
+WHERE id = $1 AND status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL')
+RETURNING *;
+
+-- name: CancelInvoiceById :one
+UPDATE invoices
+SET status = 'CANCELLED'
+WHERE id = $1 AND status = 'PENDING'
+RETURNING *;
+
+-- name: CancelGroupSiblingInvoiceById :one
+-- A partially paid sibling is cancelled as well, once its group has been paid through another coin.
+UPDATE invoices
+SET status = 'CANCELLED'
+WHERE id = $1 AND status IN ('PENDING', 'PARTIALLY_PAID')
+RETURNING *;
+
+-- name: ClearIdempotencyKeyById :one
+UPDATE invoices
+SET idempotency_key = NULL, idempotency_request_hash = NULL
 WHERE id = $1
 RETURNING *;
 
 -- name: ShiftExpiresAtForNonConfirmedInvoices :many
 UPDATE invoices
 SET expires_at = timezone('UTC', now()) + INTERVAL '5 minute'
-WHERE status IN ('PENDING', 'PENDING_MEMPOOL') AND (expires_at - timezone('UTC', now()) < INTERVAL '5 minutes')
+WHERE status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL') AND (expires_at - timezone('UTC', now()) < INTERVAL '5 minutes')
 RETURNING *;
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- name: CreateInvoiceEvent :one
+INSERT INTO invoice_events(
+    invoice_id,
+    old_status,
+    new_status,
+    tx_id,
+    amount,
+    confirmations,
+    reorg)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: FindInvoiceEventsByInvoiceId :many
+SELECT * FROM invoice_events
+WHERE invoice_id = $1
+ORDER BY id;
@@ -0,0 +1,33 @@
+-- name: CreateInvoicePayment :one
+INSERT INTO invoice_payments(
+    invoice_id,
+    tx_id,
+    amount)
+VALUES ($1, $2, $3)
+ON CONFLICT (invoice_id, tx_id) DO NOTHING
+RETURNING *;
+
+-- name: FindInvoicePaymentsByInvoiceId :many
+SELECT * FROM invoice_payments
+WHERE invoice_id = $1
+ORDER BY created_at, id;
+
+-- name: SumInvoicePaymentsByInvoiceId :one
+SELECT COALESCE(SUM(amount), 0)::NUMERIC FROM invoice_payments
+WHERE invoice_id = $1;
+
+-- name: ConfirmInvoicePaymentById :one
+UPDATE invoice_payments
+SET confirmed_at = timezone('UTC', now())
+WHERE id = $1
+RETURNING *;
+
+-- name: UnconfirmInvoicePaymentById :one
+UPDATE invoice_payments
+SET confirmed_at = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteInvoicePaymentById :exec
+DELETE FROM invoice_payments
+WHERE id = $1;
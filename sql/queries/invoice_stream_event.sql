@@ -0,0 +1,23 @@
+-- name: CreateInvoiceStreamEvent :one
+INSERT INTO invoice_stream_events(
+    invoice_id,
+    status,
+    actual_amount,
+    tx_id,
+    confirmed_at,
+    confirmations)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: FindInvoiceStreamEventsAfterSeq :many
+SELECT sqlc.embed(e), sqlc.embed(i) FROM invoice_stream_events AS e
+JOIN invoices AS i ON e.invoice_id = i.id
+WHERE e.seq > $1
+ORDER BY e.seq
+LIMIT $2;
+
+-- name: LockInvoiceStreamEvents :exec
+LOCK TABLE invoice_stream_events IN SHARE ROW EXCLUSIVE MODE;
+
+-- name: FindLastInvoiceStreamEventSeq :one
+SELECT COALESCE(MAX(seq), 0)::BIGINT FROM invoice_stream_events;
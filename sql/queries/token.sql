@@ -0,0 +1,13 @@
+-- name: UpsertToken :one
+INSERT INTO tokens(chain, chain_id, symbol, coin, contract_address, decimals, enabled) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (chain, chain_id, symbol) DO UPDATE
+SET coin = EXCLUDED.coin,
+    contract_address = EXCLUDED.contract_address,
+    decimals = EXCLUDED.decimals,
+    enabled = EXCLUDED.enabled
+RETURNING *;
+
+-- name: FindEnabledTokensByChainAndChainId :many
+SELECT * FROM tokens
+WHERE chain = $1 AND chain_id = $2 AND enabled
+ORDER BY symbol;
@@ -0,0 +1,80 @@
+-- name: UpsertWebhookEndpoint :one
+INSERT INTO webhook_endpoints(
+    user_id,
+    url,
+    secret)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET url = EXCLUDED.url,
+    secret = CASE WHEN sqlc.arg('keep_secret')::BOOLEAN THEN webhook_endpoints.secret ELSE EXCLUDED.secret END,
+    updated_at = timezone('UTC', now())
+RETURNING *;
+
+-- name: FindWebhookEndpointByUserId :one
+SELECT * FROM webhook_endpoints
+WHERE user_id = $1;
+
+-- name: DeleteWebhookEndpointByUserId :one
+DELETE FROM webhook_endpoints
+WHERE user_id = $1
+RETURNING *;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries(
+    user_id,
+    invoice_id,
+    payload)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: FindWebhookDeliveryById :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1;
+
+-- name: FindWebhookDeliveriesByFilter :many
+SELECT * FROM webhook_deliveries
+WHERE user_id = sqlc.arg('user_id')
+    AND (sqlc.narg('invoice_id')::uuid IS NULL OR invoice_id = sqlc.narg('invoice_id'))
+    AND (sqlc.narg('status')::webhook_delivery_status_type IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Postpones the due deliveries till lease_until, so neither another worker nor the next poll
+-- picks them up while they are being delivered.
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg('lease_until')
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'PENDING' AND next_attempt_at <= timezone('UTC', now())
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkWebhookDeliveryDeliveredById :one
+UPDATE webhook_deliveries
+SET status = 'DELIVERED',
+    attempts = attempts + 1,
+    last_error = NULL,
+    delivered_at = timezone('UTC', now())
+WHERE id = $1
+RETURNING *;
+
+-- name: FailWebhookDeliveryById :one
+UPDATE webhook_deliveries
+SET status = $2,
+    attempts = attempts + 1,
+    last_error = $3,
+    next_attempt_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: RedeliverWebhookDeliveryById :one
+UPDATE webhook_deliveries
+SET status = 'PENDING',
+    attempts = 0,
+    next_attempt_at = timezone('UTC', now())
+WHERE id = $1
+RETURNING *;
@@ -10,7 +10,7 @@ import (
 )
 
 const findCryptoAddressByAddress = `-- name: FindCryptoAddressByAddress :one
-SELECT id, address, coin, is_occupied, user_id FROM crypto_addresses
+SELECT id, address, coin, is_occupied, user_id, memo, derivation_seq, key_version, derivation_path, is_retired FROM crypto_addresses
 WHERE address = $1
 `
 
@@ -23,6 +23,11 @@ func (q *Queries) FindCryptoAddressByAddress(ctx context.Context, address string
 		&i.Coin,
 		&i.IsOccupied,
 		&i.UserID,
+		&i.Memo,
+		&i.DerivationSeq,
+		&i.KeyVersion,
+		&i.DerivationPath,
+		&i.IsRetired,
 	)
 	return i, err
 }
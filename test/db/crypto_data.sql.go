@@ -12,19 +12,26 @@ import (
 )
 
 const findJoinedCryptoDataByUserId = `-- name: FindJoinedCryptoDataByUserId :one
-SELECT user_id, xmr_id, id, priv_view_key, pub_spend_key, last_major_index, last_minor_index FROM crypto_data as cd
+SELECT user_id, xmr_id, btc_id, ltc_id, eth_id, bnb_id, ton_id, trx_id, address_reuse_policy, id, priv_view_key, pub_spend_key, last_major_index, last_minor_index FROM crypto_data as cd
 JOIN xmr_crypto_data as xcd ON cd.xmr_id = xcd.id
 WHERE user_id = $1
 `
 
 type FindJoinedCryptoDataByUserIdRow struct {
-	UserID         pgtype.UUID
-	XmrID          pgtype.UUID
-	ID             pgtype.UUID
-	PrivViewKey    string
-	PubSpendKey    string
-	LastMajorIndex int32
-	LastMinorIndex int32
+	UserID             pgtype.UUID
+	XmrID              pgtype.UUID
+	BtcID              pgtype.UUID
+	LtcID              pgtype.UUID
+	EthID              pgtype.UUID
+	BnbID              pgtype.UUID
+	TonID              pgtype.UUID
+	TrxID              pgtype.UUID
+	AddressReusePolicy AddressReusePolicy
+	ID                 pgtype.UUID
+	PrivViewKey        string
+	PubSpendKey        string
+	LastMajorIndex     int32
+	LastMinorIndex     int32
 }
 
 func (q *Queries) FindJoinedCryptoDataByUserId(ctx context.Context, userID pgtype.UUID) (FindJoinedCryptoDataByUserIdRow, error) {
@@ -33,6 +40,13 @@ func (q *Queries) FindJoinedCryptoDataByUserId(ctx context.Context, userID pgtyp
 	err := row.Scan(
 		&i.UserID,
 		&i.XmrID,
+		&i.BtcID,
+		&i.LtcID,
+		&i.EthID,
+		&i.BnbID,
+		&i.TonID,
+		&i.TrxID,
+		&i.AddressReusePolicy,
 		&i.ID,
 		&i.PrivViewKey,
 		&i.PubSpendKey,
@@ -12,7 +12,7 @@ import (
 )
 
 const findAllInvoices = `-- name: FindAllInvoices :many
-SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id FROM invoices
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
 `
 
 func (q *Queries) FindAllInvoices(ctx context.Context) ([]Invoice, error) {
@@ -37,6 +37,18 @@ func (q *Queries) FindAllInvoices(ctx context.Context) ([]Invoice, error) {
 			&i.ExpiresAt,
 			&i.TxID,
 			&i.UserID,
+			&i.UnderpaymentTolerance,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.IdempotencyKey,
+			&i.IdempotencyRequestHash,
+			&i.Description,
+			&i.ExternalOrderID,
+			&i.Metadata,
+			&i.GroupID,
+			&i.Confirmations,
+			&i.Memo,
 		); err != nil {
 			return nil, err
 		}
@@ -49,7 +61,7 @@ func (q *Queries) FindAllInvoices(ctx context.Context) ([]Invoice, error) {
 }
 
 const findAllInvoicesByIds = `-- name: FindAllInvoicesByIds :many
-SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id FROM invoices
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
 WHERE id = ANY($1::uuid[])
 `
 
@@ -75,6 +87,18 @@ func (q *Queries) FindAllInvoicesByIds(ctx context.Context, dollar_1 []pgtype.UU
 			&i.ExpiresAt,
 			&i.TxID,
 			&i.UserID,
+			&i.UnderpaymentTolerance,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.IdempotencyKey,
+			&i.IdempotencyRequestHash,
+			&i.Description,
+			&i.ExternalOrderID,
+			&i.Metadata,
+			&i.GroupID,
+			&i.Confirmations,
+			&i.Memo,
 		); err != nil {
 			return nil, err
 		}
@@ -87,7 +111,7 @@ func (q *Queries) FindAllInvoicesByIds(ctx context.Context, dollar_1 []pgtype.UU
 }
 
 const findInvoiceById = `-- name: FindInvoiceById :one
-SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id FROM invoices
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, underpayment_tolerance, fiat_amount, fiat_currency, exchange_rate, idempotency_key, idempotency_request_hash, description, external_order_id, metadata, group_id, confirmations, memo FROM invoices
 WHERE id = $1
 `
 
@@ -107,6 +131,18 @@ func (q *Queries) FindInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice,
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.UnderpaymentTolerance,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.IdempotencyKey,
+		&i.IdempotencyRequestHash,
+		&i.Description,
+		&i.ExternalOrderID,
+		&i.Metadata,
+		&i.GroupID,
+		&i.Confirmations,
+		&i.Memo,
 	)
 	return i, err
 }
@@ -11,14 +11,142 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AddressReusePolicy string
+
+const (
+	AddressReusePolicyREUSE         AddressReusePolicy = "REUSE"
+	AddressReusePolicyNEVERREUSE    AddressReusePolicy = "NEVER_REUSE"
+	AddressReusePolicyREUSEUNFUNDED AddressReusePolicy = "REUSE_UNFUNDED"
+)
+
+func (e *AddressReusePolicy) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AddressReusePolicy(s)
+	case string:
+		*e = AddressReusePolicy(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AddressReusePolicy: %T", src)
+	}
+	return nil
+}
+
+type NullAddressReusePolicy struct {
+	AddressReusePolicy AddressReusePolicy
+	Valid              bool // Valid is true if AddressReusePolicy is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAddressReusePolicy) Scan(value interface{}) error {
+	if value == nil {
+		ns.AddressReusePolicy, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AddressReusePolicy.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAddressReusePolicy) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AddressReusePolicy), nil
+}
+
+type AddressType string
+
+const (
+	AddressTypeP2PKH      AddressType = "P2PKH"
+	AddressTypeP2SHP2WPKH AddressType = "P2SH_P2WPKH"
+	AddressTypeP2WPKH     AddressType = "P2WPKH"
+	AddressTypeP2TR       AddressType = "P2TR"
+)
+
+func (e *AddressType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AddressType(s)
+	case string:
+		*e = AddressType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AddressType: %T", src)
+	}
+	return nil
+}
+
+type NullAddressType struct {
+	AddressType AddressType
+	Valid       bool // Valid is true if AddressType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAddressType) Scan(value interface{}) error {
+	if value == nil {
+		ns.AddressType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AddressType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAddressType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AddressType), nil
+}
+
 type CoinType string
 
 const (
-	CoinTypeXMR CoinType = "XMR"
-	CoinTypeBTC CoinType = "BTC"
-	CoinTypeLTC CoinType = "LTC"
-	CoinTypeETH CoinType = "ETH"
-	CoinTypeTON CoinType = "TON"
+	CoinTypeXMR         CoinType = "XMR"
+	CoinTypeBTC         CoinType = "BTC"
+	CoinTypeLTC         CoinType = "LTC"
+	CoinTypeETH         CoinType = "ETH"
+	CoinTypeTON         CoinType = "TON"
+	CoinTypeUSDTERC20   CoinType = "USDT_ERC20"
+	CoinTypeUSDCERC20   CoinType = "USDC_ERC20"
+	CoinTypeDAIERC20    CoinType = "DAI_ERC20"
+	CoinTypeWBTCERC20   CoinType = "WBTC_ERC20"
+	CoinTypeUNIERC20    CoinType = "UNI_ERC20"
+	CoinTypeLINKERC20   CoinType = "LINK_ERC20"
+	CoinTypeAAVEERC20   CoinType = "AAVE_ERC20"
+	CoinTypeCRVERC20    CoinType = "CRV_ERC20"
+	CoinTypeMATICERC20  CoinType = "MATIC_ERC20"
+	CoinTypeSHIBERC20   CoinType = "SHIB_ERC20"
+	CoinTypeBNBERC20    CoinType = "BNB_ERC20"
+	CoinTypeATOMERC20   CoinType = "ATOM_ERC20"
+	CoinTypeARBERC20    CoinType = "ARB_ERC20"
+	CoinTypeBNB         CoinType = "BNB"
+	CoinTypeBSCUSDBEP20 CoinType = "BSC-USD_BEP20"
+	CoinTypeUSDCBEP20   CoinType = "USDC_BEP20"
+	CoinTypeDAIBEP20    CoinType = "DAI_BEP20"
+	CoinTypeBUSDBEP20   CoinType = "BUSD_BEP20"
+	CoinTypeWBTCBEP20   CoinType = "WBTC_BEP20"
+	CoinTypeBTCBBEP20   CoinType = "BTCB_BEP20"
+	CoinTypeUNIBEP20    CoinType = "UNI_BEP20"
+	CoinTypeLINKBEP20   CoinType = "LINK_BEP20"
+	CoinTypeAAVEBEP20   CoinType = "AAVE_BEP20"
+	CoinTypeMATICBEP20  CoinType = "MATIC_BEP20"
+	CoinTypeSHIBBEP20   CoinType = "SHIB_BEP20"
+	CoinTypeATOMBEP20   CoinType = "ATOM_BEP20"
+	CoinTypeARBBEP20    CoinType = "ARB_BEP20"
+	CoinTypeETHBEP20    CoinType = "ETH_BEP20"
+	CoinTypeXRPBEP20    CoinType = "XRP_BEP20"
+	CoinTypeADABEP20    CoinType = "ADA_BEP20"
+	CoinTypeTRXBEP20    CoinType = "TRX_BEP20"
+	CoinTypeDOGEBEP20   CoinType = "DOGE_BEP20"
+	CoinTypeLTCBEP20    CoinType = "LTC_BEP20"
+	CoinTypeBCHBEP20    CoinType = "BCH_BEP20"
+	CoinTypeTWTBEP20    CoinType = "TWT_BEP20"
+	CoinTypeAVAXBEP20   CoinType = "AVAX_BEP20"
+	CoinTypeCAKEBEP20   CoinType = "CAKE_BEP20"
+	CoinTypeUSDTTON     CoinType = "USDT_TON"
+	CoinTypeTRX         CoinType = "TRX"
+	CoinTypeUSDTTRC20   CoinType = "USDT_TRC20"
+	CoinTypeUSDCTRC20   CoinType = "USDC_TRC20"
 )
 
 func (e *CoinType) Scan(src interface{}) error {
@@ -59,10 +187,13 @@ func (ns NullCoinType) Value() (driver.Value, error) {
 type InvoiceStatusType string
 
 const (
-	InvoiceStatusTypePENDING        InvoiceStatusType = "PENDING"
-	InvoiceStatusTypePENDINGMEMPOOL InvoiceStatusType = "PENDING_MEMPOOL"
-	InvoiceStatusTypeEXPIRED        InvoiceStatusType = "EXPIRED"
-	InvoiceStatusTypeCONFIRMED      InvoiceStatusType = "CONFIRMED"
+	InvoiceStatusTypePENDING         InvoiceStatusType = "PENDING"
+	InvoiceStatusTypePENDINGMEMPOOL  InvoiceStatusType = "PENDING_MEMPOOL"
+	InvoiceStatusTypeEXPIRED         InvoiceStatusType = "EXPIRED"
+	InvoiceStatusTypeCONFIRMED       InvoiceStatusType = "CONFIRMED"
+	InvoiceStatusTypeCANCELLED       InvoiceStatusType = "CANCELLED"
+	InvoiceStatusTypePARTIALLYPAID   InvoiceStatusType = "PARTIALLY_PAID"
+	InvoiceStatusTypePAIDAFTEREXPIRY InvoiceStatusType = "PAID_AFTER_EXPIRY"
 )
 
 func (e *InvoiceStatusType) Scan(src interface{}) error {
@@ -100,12 +231,89 @@ func (ns NullInvoiceStatusType) Value() (driver.Value, error) {
 	return string(ns.InvoiceStatusType), nil
 }
 
+type WebhookDeliveryStatusType string
+
+const (
+	WebhookDeliveryStatusTypePENDING   WebhookDeliveryStatusType = "PENDING"
+	WebhookDeliveryStatusTypeDELIVERED WebhookDeliveryStatusType = "DELIVERED"
+	WebhookDeliveryStatusTypeDEAD      WebhookDeliveryStatusType = "DEAD"
+)
+
+func (e *WebhookDeliveryStatusType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatusType(s)
+	case string:
+		*e = WebhookDeliveryStatusType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatusType: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatusType struct {
+	WebhookDeliveryStatusType WebhookDeliveryStatusType
+	Valid                     bool // Valid is true if WebhookDeliveryStatusType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatusType) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatusType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatusType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatusType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatusType), nil
+}
+
+type AddressPoolConfig struct {
+	UserID           pgtype.UUID
+	Coin             CoinType
+	MinFreeAddresses int32
+	GapLimit         int32
+	UpdatedAt        pgtype.Timestamptz
+}
+
+type BnbCryptoDatum struct {
+	ID             pgtype.UUID
+	MasterPubKey   string
+	LastMajorIndex int32
+	LastMinorIndex int32
+}
+
+type BtcCryptoDatum struct {
+	ID             pgtype.UUID
+	MasterPubKey   string
+	LastMajorIndex int32
+	LastMinorIndex int32
+	AddressType    AddressType
+}
+
 type CryptoAddress struct {
-	ID         pgtype.UUID
-	Address    string
-	Coin       CoinType
-	IsOccupied bool
-	UserID     pgtype.UUID
+	ID             pgtype.UUID
+	Address        string
+	Coin           CoinType
+	IsOccupied     bool
+	UserID         pgtype.UUID
+	Memo           pgtype.Text
+	DerivationSeq  int64
+	KeyVersion     pgtype.Int4
+	DerivationPath pgtype.Text
+	IsRetired      bool
+}
+
+type CryptoBlockHash struct {
+	Coin   CoinType
+	Height int64
+	Hash   string
 }
 
 type CryptoCache struct {
@@ -115,29 +323,160 @@ type CryptoCache struct {
 }
 
 type CryptoDatum struct {
-	UserID pgtype.UUID
-	XmrID  pgtype.UUID
+	UserID             pgtype.UUID
+	XmrID              pgtype.UUID
+	BtcID              pgtype.UUID
+	LtcID              pgtype.UUID
+	EthID              pgtype.UUID
+	BnbID              pgtype.UUID
+	TonID              pgtype.UUID
+	TrxID              pgtype.UUID
+	AddressReusePolicy AddressReusePolicy
+}
+
+type CryptoKeyVersion struct {
+	ID          pgtype.UUID
+	UserID      pgtype.UUID
+	Coin        CoinType
+	Version     int32
+	PublicKey   string
+	PrivViewKey pgtype.Text
+	CreatedAt   pgtype.Timestamptz
+	RetiredAt   pgtype.Timestamptz
+}
+
+type EthCryptoDatum struct {
+	ID             pgtype.UUID
+	MasterPubKey   string
+	LastMajorIndex int32
+	LastMinorIndex int32
+}
+
+type EvmCryptoDatum struct {
+	ID             pgtype.UUID
+	UserID         pgtype.UUID
+	Chain          string
+	MasterPubKey   string
+	LastMajorIndex int32
+	LastMinorIndex int32
 }
 
 type Invoice struct {
-	ID                    pgtype.UUID
-	CryptoAddress         string
-	Coin                  CoinType
-	RequiredAmount        float64
-	ActualAmount          pgtype.Float8
-	ConfirmationsRequired int16
-	CreatedAt             pgtype.Timestamptz
-	ConfirmedAt           pgtype.Timestamptz
-	Status                InvoiceStatusType
-	ExpiresAt             pgtype.Timestamptz
-	TxID                  pgtype.Text
-	UserID                pgtype.UUID
+	ID                     pgtype.UUID
+	CryptoAddress          string
+	Coin                   CoinType
+	RequiredAmount         pgtype.Numeric
+	ActualAmount           pgtype.Numeric
+	ConfirmationsRequired  int16
+	CreatedAt              pgtype.Timestamptz
+	ConfirmedAt            pgtype.Timestamptz
+	Status                 InvoiceStatusType
+	ExpiresAt              pgtype.Timestamptz
+	TxID                   pgtype.Text
+	UserID                 pgtype.UUID
+	UnderpaymentTolerance  pgtype.Numeric
+	FiatAmount             pgtype.Float8
+	FiatCurrency           pgtype.Text
+	ExchangeRate           pgtype.Float8
+	IdempotencyKey         pgtype.Text
+	IdempotencyRequestHash pgtype.Text
+	Description            pgtype.Text
+	ExternalOrderID        pgtype.Text
+	Metadata               []byte
+	GroupID                pgtype.UUID
+	Confirmations          int32
+	Memo                   pgtype.Text
+}
+
+type InvoiceEvent struct {
+	ID            int64
+	InvoiceID     pgtype.UUID
+	OldStatus     NullInvoiceStatusType
+	NewStatus     InvoiceStatusType
+	TxID          pgtype.Text
+	Confirmations pgtype.Int8
+	CreatedAt     pgtype.Timestamptz
+	Reorg         bool
+	Amount        pgtype.Numeric
+}
+
+type InvoicePayment struct {
+	ID          pgtype.UUID
+	InvoiceID   pgtype.UUID
+	TxID        string
+	CreatedAt   pgtype.Timestamptz
+	ConfirmedAt pgtype.Timestamptz
+	Amount      pgtype.Numeric
+}
+
+type InvoiceStreamEvent struct {
+	Seq           int64
+	InvoiceID     pgtype.UUID
+	Status        InvoiceStatusType
+	TxID          pgtype.Text
+	ConfirmedAt   pgtype.Timestamptz
+	CreatedAt     pgtype.Timestamptz
+	Confirmations int32
+	ActualAmount  pgtype.Numeric
+}
+
+type LtcCryptoDatum struct {
+	ID             pgtype.UUID
+	MasterPubKey   string
+	LastMajorIndex int32
+	LastMinorIndex int32
+	AddressType    AddressType
+}
+
+type Token struct {
+	ID              pgtype.UUID
+	Chain           CoinType
+	ChainID         int64
+	Symbol          string
+	Coin            CoinType
+	ContractAddress string
+	Decimals        int32
+	Enabled         bool
+}
+
+type TonCryptoDatum struct {
+	ID            pgtype.UUID
+	WalletAddress string
+	LastMemoIndex int32
+}
+
+type TrxCryptoDatum struct {
+	ID             pgtype.UUID
+	MasterPubKey   string
+	LastMajorIndex int32
+	LastMinorIndex int32
 }
 
 type User struct {
 	ID pgtype.UUID
 }
 
+type WebhookDelivery struct {
+	ID            pgtype.UUID
+	UserID        pgtype.UUID
+	InvoiceID     pgtype.UUID
+	Payload       []byte
+	Status        WebhookDeliveryStatusType
+	Attempts      int32
+	NextAttemptAt pgtype.Timestamptz
+	LastError     pgtype.Text
+	CreatedAt     pgtype.Timestamptz
+	DeliveredAt   pgtype.Timestamptz
+}
+
+type WebhookEndpoint struct {
+	UserID    pgtype.UUID
+	Url       string
+	Secret    string
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
+
 type XmrCryptoDatum struct {
 	ID             pgtype.UUID
 	PrivViewKey    string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/test"
@@ -53,6 +54,30 @@ func TestCreateCryptoAddress(t *testing.T) {
 			assert.Equal(t, "23505", pgErr.Code)
 		})
 	})
+
+	t.Run("Should Tell Memo Addresses Apart", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			addr := uuid.NewString()
+
+			_, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: addr, Coin: db.CoinTypeTON, IsOccupied: true, UserID: userId, Memo: pgtype.Text{String: "1", Valid: true}})
+			assert.NoError(t, err)
+			_, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: addr, Coin: db.CoinTypeTON, IsOccupied: true, UserID: userId, Memo: pgtype.Text{String: "2", Valid: true}})
+			assert.NoError(t, err)
+
+			_, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: addr, Coin: db.CoinTypeTON, IsOccupied: true, UserID: userId, Memo: pgtype.Text{String: "2", Valid: true}})
+			var pgErr *pgconn.PgError
+			assert.ErrorAs(t, err, &pgErr)
+			assert.Equal(t, "23505", pgErr.Code)
+		})
+	})
 }
 
 func TestFindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(t *testing.T) {
@@ -144,6 +169,76 @@ func TestFindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(t *testing.T) {
 		})
 	})
 
+	cases := []struct {
+		name string
+		// Without crypto data the policy defaults to REUSE.
+		policy   db.NullAddressReusePolicy
+		expected []string
+	}{
+		{name: "Should Take Every Released Address (no crypto data)", expected: []string{"unused", "unfunded", "funded"}},
+		{name: "Should Take Every Released Address (REUSE)", policy: db.NullAddressReusePolicy{AddressReusePolicy: db.AddressReusePolicyREUSE, Valid: true}, expected: []string{"unused", "unfunded", "funded"}},
+		{name: "Should Take Unused Address Only (NEVER_REUSE)", policy: db.NullAddressReusePolicy{AddressReusePolicy: db.AddressReusePolicyNEVERREUSE, Valid: true}, expected: []string{"unused"}},
+		{name: "Should Take Unfunded Addresses Only (REUSE_UNFUNDED)", policy: db.NullAddressReusePolicy{AddressReusePolicy: db.AddressReusePolicyREUSEUNFUNDED, Valid: true}, expected: []string{"unused", "unfunded"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+				ctx := context.Background()
+				q := db.New(tx)
+
+				userId, err := q.CreateUser(ctx)
+				if err != nil {
+					log.Fatal(err)
+				}
+				if c.policy.Valid {
+					if _, err := q.CreateCryptoData(ctx, db.CreateCryptoDataParams{UserID: userId}); err != nil {
+						log.Fatal(err)
+					}
+					if _, err := q.UpdateAddressReusePolicyCryptoDataByUserId(ctx, db.UpdateAddressReusePolicyCryptoDataByUserIdParams{UserID: userId, AddressReusePolicy: c.policy.AddressReusePolicy}); err != nil {
+						log.Fatal(err)
+					}
+				}
+
+				var expiresAt pgtype.Timestamptz
+				if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+					log.Fatal(err)
+				}
+				addrs := make(map[string]string, 3)
+				for _, name := range []string{"unused", "unfunded", "funded"} {
+					addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeBTC, IsOccupied: false, UserID: userId})
+					if err != nil {
+						log.Fatal(err)
+					}
+					addrs[addr.Address] = name
+
+					if name == "unused" {
+						continue
+					}
+					inv, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{CryptoAddress: addr.Address, Coin: addr.Coin, RequiredAmount: numeric(2), ExpiresAt: expiresAt, UserID: userId})
+					if err != nil {
+						log.Fatal(err)
+					}
+					if name == "funded" {
+						if _, err := q.PartiallyPayInvoiceById(ctx, db.PartiallyPayInvoiceByIdParams{ID: inv.ID, ActualAmount: numeric(1), TxID: pgtype.Text{String: uuid.NewString(), Valid: true}}); err != nil {
+							log.Fatal(err)
+						}
+					}
+				}
+
+				taken := make([]string, 0, len(addrs))
+				for {
+					addr, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{Coin: db.CoinTypeBTC, UserID: userId})
+					if err != nil {
+						assert.ErrorIs(t, err, pgx.ErrNoRows)
+						break
+					}
+					assert.True(t, addr.IsOccupied)
+					taken = append(taken, addrs[addr.Address])
+				}
+				assert.ElementsMatch(t, c.expected, taken)
+			})
+		})
+	}
 }
 
 func TestUpdateIsOccupiedByCryptoAddress(t *testing.T) {
@@ -236,3 +331,99 @@ func TestDeleteAllCryptoAddressByUserIdAndCoin(t *testing.T) {
 	})
 
 }
+
+func TestRetireAllCryptoAddressesByUserIdAndCoin(t *testing.T) {
+	t.Run("Should Retire Addresses And Stop Reusing Them", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeBTC, IsOccupied: false, UserID: userId})
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeXMR, IsOccupied: false, UserID: userId})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			retired, err := q.RetireAllCryptoAddressesByUserIdAndCoin(ctx, db.RetireAllCryptoAddressesByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeBTC})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(retired))
+			assert.True(t, retired[0].IsRetired)
+
+			_, err = q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeBTC})
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+			_, err = q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeXMR})
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func TestReviveRetiredCryptoAddress(t *testing.T) {
+	gen := func(ctx context.Context, q *db.Queries, isOccupied bool) db.CryptoAddress {
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeBTC, IsOccupied: isOccupied, UserID: userId})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := q.RetireAllCryptoAddressesByUserIdAndCoin(ctx, db.RetireAllCryptoAddressesByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeBTC}); err != nil {
+			log.Fatal(err)
+		}
+
+		return addr
+	}
+
+	t.Run("Should Revive Free Retired Address", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			addr := gen(ctx, q, false)
+
+			revived, err := q.ReviveRetiredCryptoAddress(ctx, db.ReviveRetiredCryptoAddressParams{
+				IsOccupied:     true,
+				DerivationPath: pgtype.Text{String: "0/1", Valid: true},
+				UserID:         addr.UserID,
+				Coin:           addr.Coin,
+				Address:        addr.Address,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, addr.ID, revived.ID)
+			assert.False(t, revived.IsRetired)
+			assert.True(t, revived.IsOccupied)
+			assert.Equal(t, "0/1", revived.DerivationPath.String)
+			assert.Greater(t, revived.DerivationSeq, addr.DerivationSeq)
+		})
+	})
+
+	t.Run("Should Not Revive Retired Address Occupied By Pending Invoice", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			addr := gen(ctx, q, true)
+
+			_, err := q.ReviveRetiredCryptoAddress(ctx, db.ReviveRetiredCryptoAddressParams{IsOccupied: true, UserID: addr.UserID, Coin: addr.Coin, Address: addr.Address})
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+			retired, err := q.ExistsRetiredCryptoAddress(ctx, db.ExistsRetiredCryptoAddressParams{Address: addr.Address})
+			assert.NoError(t, err)
+			assert.True(t, retired)
+
+			retired, err = q.ExistsRetiredCryptoAddress(ctx, db.ExistsRetiredCryptoAddressParams{Address: uuid.NewString()})
+			assert.NoError(t, err)
+			assert.False(t, retired)
+		})
+	})
+}
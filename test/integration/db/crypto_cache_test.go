@@ -59,3 +59,30 @@ func TestUpdateCryptoCacheByCoin(t *testing.T) {
 		})
 	})
 }
+
+func TestCryptoBlockHashes(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		err := q.CreateCryptoBlockHashes(ctx, db.CreateCryptoBlockHashesParams{Coin: db.CoinTypeBTC, Heights: []int64{11, 10}, Hashes: []string{"a11", "a10"}})
+		assert.NoError(t, err)
+		err = q.CreateCryptoBlockHashes(ctx, db.CreateCryptoBlockHashesParams{Coin: db.CoinTypeXMR, Heights: []int64{10}, Hashes: []string{"x10"}})
+		assert.NoError(t, err)
+
+		hashes, err := q.FindCryptoBlockHashesByCoin(ctx, db.CoinTypeBTC)
+		assert.NoError(t, err)
+		assert.Equal(t, []db.CryptoBlockHash{{Coin: db.CoinTypeBTC, Height: 10, Hash: "a10"}, {Coin: db.CoinTypeBTC, Height: 11, Hash: "a11"}}, hashes)
+
+		err = q.DeleteCryptoBlockHashesByCoin(ctx, db.CoinTypeBTC)
+		assert.NoError(t, err)
+
+		hashes, err = q.FindCryptoBlockHashesByCoin(ctx, db.CoinTypeBTC)
+		assert.NoError(t, err)
+		assert.Len(t, hashes, 0)
+
+		hashes, err = q.FindCryptoBlockHashesByCoin(ctx, db.CoinTypeXMR)
+		assert.NoError(t, err)
+		assert.Len(t, hashes, 1)
+	})
+}
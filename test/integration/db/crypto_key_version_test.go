@@ -0,0 +1,115 @@
+package db_test
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/test"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCryptoKeyVersion(t *testing.T) {
+	t.Run("Should Retire Previous Version", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			v1, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeBTC, PublicKey: uuid.NewString()})
+			assert.NoError(t, err)
+			assert.Equal(t, int32(1), v1.Version)
+
+			addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeBTC, IsOccupied: true, UserID: userId})
+			assert.NoError(t, err)
+			assert.Equal(t, int32(1), addr.KeyVersion.Int32)
+
+			v2, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeBTC, PublicKey: uuid.NewString()})
+			assert.NoError(t, err)
+			assert.Equal(t, int32(2), v2.Version)
+
+			versions, err := q.FindCryptoKeyVersionsByUserIdAndCoin(ctx, db.FindCryptoKeyVersionsByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeBTC})
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(versions))
+			assert.False(t, versions[0].RetiredAt.Valid)
+			assert.True(t, versions[1].RetiredAt.Valid)
+		})
+	})
+
+	t.Run("Should Version Keys Per Coin", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if _, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeBTC, PublicKey: uuid.NewString()}); err != nil {
+				log.Fatal(err)
+			}
+
+			v, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeLTC, PublicKey: uuid.NewString()})
+			assert.NoError(t, err)
+			assert.Equal(t, int32(1), v.Version)
+		})
+	})
+}
+
+func TestFindXMRPrivViewKeyByCryptoAddress(t *testing.T) {
+	t.Run("Should Return View Key Of The Address Version", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if _, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeXMR, PublicKey: uuid.NewString(), PrivViewKey: pgtype.Text{String: "view1", Valid: true}}); err != nil {
+				log.Fatal(err)
+			}
+			addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeXMR, IsOccupied: true, UserID: userId})
+			if err != nil {
+				log.Fatal(err)
+			}
+			if _, err := q.CreateCryptoKeyVersion(ctx, db.CreateCryptoKeyVersionParams{UserID: userId, Coin: db.CoinTypeXMR, PublicKey: uuid.NewString(), PrivViewKey: pgtype.Text{String: "view2", Valid: true}}); err != nil {
+				log.Fatal(err)
+			}
+
+			privViewKey, err := q.FindXMRPrivViewKeyByCryptoAddress(ctx, addr.Address)
+			assert.NoError(t, err)
+			assert.Equal(t, "view1", privViewKey)
+		})
+	})
+
+	t.Run("Should Return ErrNoRows (address without version)", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeXMR, IsOccupied: true, UserID: userId})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.FindXMRPrivViewKeyByCryptoAddress(ctx, addr.Address)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package db_test
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/test"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateInvoiceEvent(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		expectedEvent := db.InvoiceEvent{
+			InvoiceID:     inv.ID,
+			OldStatus:     db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypePENDING, Valid: true},
+			NewStatus:     db.InvoiceStatusTypePENDINGMEMPOOL,
+			TxID:          pgtype.Text{String: "txId", Valid: true},
+			Amount:        inv.RequiredAmount,
+			Confirmations: pgtype.Int8{Int64: 0, Valid: true},
+		}
+
+		event, err := q.CreateInvoiceEvent(ctx, db.CreateInvoiceEventParams{
+			InvoiceID:     expectedEvent.InvoiceID,
+			OldStatus:     expectedEvent.OldStatus,
+			NewStatus:     expectedEvent.NewStatus,
+			TxID:          expectedEvent.TxID,
+			Amount:        expectedEvent.Amount,
+			Confirmations: expectedEvent.Confirmations,
+		})
+		assert.NoError(t, err)
+
+		expectedEvent.ID = event.ID
+		expectedEvent.CreatedAt = event.CreatedAt
+		assert.Equal(t, expectedEvent, event)
+		assert.True(t, event.CreatedAt.Valid)
+	})
+}
+
+func TestFindInvoiceEventsByInvoiceId(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+		otherInv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		transitions := []db.CreateInvoiceEventParams{
+			{InvoiceID: inv.ID, NewStatus: db.InvoiceStatusTypePENDING},
+			{InvoiceID: inv.ID, OldStatus: db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypePENDING, Valid: true}, NewStatus: db.InvoiceStatusTypePENDINGMEMPOOL},
+			{InvoiceID: inv.ID, OldStatus: db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypePENDINGMEMPOOL, Valid: true}, NewStatus: db.InvoiceStatusTypeCONFIRMED},
+		}
+		for i := 0; i < len(transitions); i++ {
+			if _, err := q.CreateInvoiceEvent(ctx, transitions[i]); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if _, err := q.CreateInvoiceEvent(ctx, db.CreateInvoiceEventParams{InvoiceID: otherInv.ID, NewStatus: db.InvoiceStatusTypePENDING}); err != nil {
+			log.Fatal(err)
+		}
+
+		events, err := q.FindInvoiceEventsByInvoiceId(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, len(transitions), len(events))
+		for i := 0; i < len(transitions); i++ {
+			assert.Equal(t, transitions[i].OldStatus, events[i].OldStatus)
+			assert.Equal(t, transitions[i].NewStatus, events[i].NewStatus)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/test"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateInvoicePayment(t *testing.T) {
+	t.Run("Should Create Payment", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			payment, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: inv.ID, TxID: "txid", Amount: numeric(50000000)})
+			assert.NoError(t, err)
+			assert.Equal(t, inv.ID, payment.InvoiceID)
+			assert.Equal(t, "txid", payment.TxID)
+			assert.Equal(t, "50000000", numericString(payment.Amount))
+			assert.False(t, payment.ConfirmedAt.Valid)
+		})
+	})
+
+	t.Run("Should Ignore Duplicate TxId", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if _, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: inv.ID, TxID: "txid", Amount: numeric(50000000)}); err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: inv.ID, TxID: "txid", Amount: numeric(50000000)})
+			assert.True(t, errors.Is(err, pgx.ErrNoRows))
+		})
+	})
+}
+
+func TestSumInvoicePaymentsByInvoiceId(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		total, err := q.SumInvoicePaymentsByInvoiceId(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "0", numericString(total))
+
+		amounts := []int64{25000000, 50000000}
+		for i := 0; i < len(amounts); i++ {
+			if _, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: inv.ID, TxID: string(rune('a' + i)), Amount: numeric(amounts[i])}); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		total, err = q.SumInvoicePaymentsByInvoiceId(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "75000000", numericString(total))
+	})
+}
+
+func TestConfirmInvoicePaymentById(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		payment, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: inv.ID, TxID: "txid", Amount: numeric(50000000)})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		confirmedPayment, err := q.ConfirmInvoicePaymentById(ctx, payment.ID)
+		assert.NoError(t, err)
+		assert.True(t, confirmedPayment.ConfirmedAt.Valid)
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []db.InvoicePayment{confirmedPayment}, payments)
+	})
+}
+
+func TestRollbackInvoicePayments(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		payment1, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: inv.ID, TxID: "txid1", Amount: numeric(50000000)})
+		if err != nil {
+			log.Fatal(err)
+		}
+		payment2, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: inv.ID, TxID: "txid2", Amount: numeric(50000000)})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := q.ConfirmInvoicePaymentById(ctx, payment2.ID); err != nil {
+			log.Fatal(err)
+		}
+
+		err = q.DeleteInvoicePaymentById(ctx, payment1.ID)
+		assert.NoError(t, err)
+
+		unconfirmedPayment, err := q.UnconfirmInvoicePaymentById(ctx, payment2.ID)
+		assert.NoError(t, err)
+		assert.False(t, unconfirmedPayment.ConfirmedAt.Valid)
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []db.InvoicePayment{unconfirmedPayment}, payments)
+	})
+}
@@ -0,0 +1,101 @@
+package db_test
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/test"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateInvoiceStreamEvent(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		first, err := q.CreateInvoiceStreamEvent(ctx, db.CreateInvoiceStreamEventParams{InvoiceID: inv.ID, Status: inv.Status})
+		assert.NoError(t, err)
+		second, err := q.CreateInvoiceStreamEvent(ctx, db.CreateInvoiceStreamEventParams{InvoiceID: inv.ID, Status: db.InvoiceStatusTypeEXPIRED})
+		assert.NoError(t, err)
+
+		assert.Less(t, first.Seq, second.Seq)
+	})
+}
+
+func TestFindInvoiceStreamEventsAfterSeq(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var events [3]db.InvoiceStreamEvent
+		for i := 0; i < len(events); i++ {
+			event, err := q.CreateInvoiceStreamEvent(ctx, db.CreateInvoiceStreamEventParams{InvoiceID: inv.ID, Status: inv.Status})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			events[i] = event
+		}
+
+		rows, err := q.FindInvoiceStreamEventsAfterSeq(ctx, db.FindInvoiceStreamEventsAfterSeqParams{Seq: events[0].Seq, Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(rows))
+		assert.Equal(t, events[1].Seq, rows[0].InvoiceStreamEvent.Seq)
+		assert.Equal(t, events[2].Seq, rows[1].InvoiceStreamEvent.Seq)
+		assert.Equal(t, inv.ID, rows[0].Invoice.ID)
+
+		rows, err = q.FindInvoiceStreamEventsAfterSeq(ctx, db.FindInvoiceStreamEventsAfterSeqParams{Seq: events[0].Seq, Limit: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(rows))
+	})
+}
+
+func TestFindLastInvoiceStreamEventSeq(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		assert.NoError(t, q.LockInvoiceStreamEvents(ctx))
+		event, err := q.CreateInvoiceStreamEvent(ctx, db.CreateInvoiceStreamEventParams{InvoiceID: inv.ID, Status: inv.Status})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		seq, err := q.FindLastInvoiceStreamEventSeq(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, event.Seq, seq)
+	})
+}
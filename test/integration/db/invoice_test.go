@@ -3,6 +3,7 @@ package db_test
 import (
 	"context"
 	"log"
+	"math"
 	"math/rand"
 	"testing"
 	"time"
@@ -25,7 +26,7 @@ func createRandTestInvoice(ctx context.Context, q *db.Queries, userId pgtype.UUI
 	return q.CreateInvoice(ctx, db.CreateInvoiceParams{
 		CryptoAddress:         uuid.NewString(),
 		Coin:                  dbCoinTypes[rand.Intn(len(dbCoinTypes))],
-		RequiredAmount:        rand.Float64(),
+		RequiredAmount:        numeric(rand.Int63n(math.MaxInt32)),
 		ConfirmationsRequired: int16(rand.Int()),
 		ExpiresAt:             expiresAt,
 		UserID:                userId,
@@ -95,7 +96,7 @@ func TestConfirmInvoiceById(t *testing.T) {
 	})
 }
 
-func TestExpireInvoiceById(t *testing.T) {
+func TestUpdateInvoiceConfirmationsById(t *testing.T) {
 	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
 		ctx := context.Background()
 		q := db.New(tx)
@@ -109,10 +110,159 @@ func TestExpireInvoiceById(t *testing.T) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		assert.Equal(t, int32(0), inv.Confirmations)
 
-		confirmedInv, err := q.ExpireInvoiceById(ctx, inv.ID)
+		updatedInv, err := q.UpdateInvoiceConfirmationsById(ctx, db.UpdateInvoiceConfirmationsByIdParams{ID: inv.ID, Confirmations: 2})
 		assert.NoError(t, err)
-		assert.Equal(t, db.InvoiceStatusTypeEXPIRED, confirmedInv.Status)
+		assert.Equal(t, int32(2), updatedInv.Confirmations)
+
+		// The count only grows
+		_, err = q.UpdateInvoiceConfirmationsById(ctx, db.UpdateInvoiceConfirmationsByIdParams{ID: inv.ID, Confirmations: 1})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
+
+func TestExpireInvoiceById(t *testing.T) {
+	t.Run("Should Expire Pending Invoice", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			confirmedInv, err := q.ExpireInvoiceById(ctx, inv.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, db.InvoiceStatusTypeEXPIRED, confirmedInv.Status)
+		})
+	})
+
+	t.Run("Should Not Expire Cancelled Invoice", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if _, err := q.CancelInvoiceById(ctx, inv.ID); err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.ExpireInvoiceById(ctx, inv.ID)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+}
+
+func TestCancelInvoiceById(t *testing.T) {
+	t.Run("Should Cancel Pending Invoice", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			cancelledInv, err := q.CancelInvoiceById(ctx, inv.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, db.InvoiceStatusTypeCANCELLED, cancelledInv.Status)
+		})
+	})
+
+	t.Run("Should Return ErrNoRows (non-pending invoice)", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if _, err := q.ConfirmInvoiceById(ctx, inv.ID); err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.CancelInvoiceById(ctx, inv.ID)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+}
+
+func TestCancelGroupSiblingInvoiceById(t *testing.T) {
+	t.Run("Should Cancel Partially Paid Invoice", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if _, err := q.PartiallyPayInvoiceById(ctx, db.PartiallyPayInvoiceByIdParams{ID: inv.ID, ActualAmount: numeric(1), TxID: pgtype.Text{String: "txid", Valid: true}}); err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.CancelInvoiceById(ctx, inv.ID)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+			cancelledInv, err := q.CancelGroupSiblingInvoiceById(ctx, inv.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, db.InvoiceStatusTypeCANCELLED, cancelledInv.Status)
+		})
+	})
+
+	t.Run("Should Return ErrNoRows (paid invoice)", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if _, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: inv.ID, ActualAmount: numeric(1), TxID: pgtype.Text{String: "txid", Valid: true}}); err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.CancelGroupSiblingInvoiceById(ctx, inv.ID)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
 	})
 }
 
@@ -131,28 +281,142 @@ func TestConfirmInvoiceStatusMempoolById(t *testing.T) {
 			log.Fatal(err)
 		}
 
-		expectedActualAmount := 1.2
+		expectedActualAmount := numeric(120000000)
 		expectedTxId := "txid"
 
-		var actualAmount pgtype.Float8
-		if err := actualAmount.Scan(expectedActualAmount); err != nil {
-			log.Fatal(err)
-		}
 		var txId pgtype.Text
 		if err := txId.Scan(expectedTxId); err != nil {
 			log.Fatal(err)
 		}
 
-		confirmedInv, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: inv.ID, ActualAmount: actualAmount, TxID: txId})
+		confirmedInv, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: inv.ID, ActualAmount: expectedActualAmount, TxID: txId})
 		assert.NoError(t, err)
 		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, confirmedInv.Status)
-		assert.Equal(t, expectedActualAmount, confirmedInv.ActualAmount.Float64)
+		assert.Equal(t, numericString(expectedActualAmount), numericString(confirmedInv.ActualAmount))
 		assert.Equal(t, expectedTxId, confirmedInv.TxID.String)
 	})
 }
 
-func TestFindAllPendingInvoices(t *testing.T) {
-	t.Run("Should Return 2 Invoices", func(t *testing.T) {
+func TestPartiallyPayInvoiceById(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		expectedActualAmount := numeric(20000000)
+		expectedTxId := "txid"
+
+		var txId pgtype.Text
+		if err := txId.Scan(expectedTxId); err != nil {
+			log.Fatal(err)
+		}
+
+		partiallyPaidInv, err := q.PartiallyPayInvoiceById(ctx, db.PartiallyPayInvoiceByIdParams{ID: inv.ID, ActualAmount: expectedActualAmount, TxID: txId})
+		assert.NoError(t, err)
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, partiallyPaidInv.Status)
+		assert.Equal(t, numericString(expectedActualAmount), numericString(partiallyPaidInv.ActualAmount))
+		assert.Equal(t, expectedTxId, partiallyPaidInv.TxID.String)
+
+		invoices, err := q.FindAllPendingInvoices(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(invoices))
+	})
+}
+
+func TestPayInvoiceAfterExpiryById(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := q.ExpireInvoiceById(ctx, inv.ID); err != nil {
+			log.Fatal(err)
+		}
+
+		actualAmount := numeric(70000000)
+		var txId pgtype.Text
+		if err := txId.Scan("txid"); err != nil {
+			log.Fatal(err)
+		}
+
+		paidInv, err := q.PayInvoiceAfterExpiryById(ctx, db.PayInvoiceAfterExpiryByIdParams{ID: inv.ID, ActualAmount: actualAmount, TxID: txId})
+		assert.NoError(t, err)
+		assert.Equal(t, db.InvoiceStatusTypePAIDAFTEREXPIRY, paidInv.Status)
+		assert.Equal(t, "70000000", numericString(paidInv.ActualAmount))
+		assert.Equal(t, "txid", paidInv.TxID.String)
+	})
+}
+
+func TestFindAllExpiredInvoicesOccupyingCryptoAddress(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+
+		var expiredInvoices [2]db.Invoice
+		for i := 0; i < len(expiredInvoices); i++ {
+			addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+				Address:    uuid.NewString(),
+				Coin:       db.CoinTypeXMR,
+				IsOccupied: i == 0,
+				UserID:     userId,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+				CryptoAddress:         addr.Address,
+				Coin:                  addr.Coin,
+				RequiredAmount:        numeric(1),
+				ConfirmationsRequired: 0,
+				ExpiresAt:             expiresAt,
+				UserID:                userId,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			expiredInvoices[i], err = q.ExpireInvoiceById(ctx, inv.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		invoices, err := q.FindAllExpiredInvoicesOccupyingCryptoAddress(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []db.Invoice{expiredInvoices[0]}, invoices)
+	})
+}
+
+func TestFindInvoiceById(t *testing.T) {
+	t.Run("Should Return Invoice", func(t *testing.T) {
 		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
 			ctx := context.Background()
 			q := db.New(tx)
@@ -162,6 +426,48 @@ func TestFindAllPendingInvoices(t *testing.T) {
 				log.Fatal(err)
 			}
 
+			expectedInv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := q.FindInvoiceById(ctx, expectedInv.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedInv, inv)
+		})
+	})
+
+	t.Run("Should Return ErrNoRows", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			var id pgtype.UUID
+			if err := id.Scan(uuid.NewString()); err != nil {
+				log.Fatal(err)
+			}
+
+			_, err := q.FindInvoiceById(ctx, id)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+}
+
+func TestFindInvoicesByFilter(t *testing.T) {
+	t.Run("Should Filter By UserId And Status", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+			otherUserId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
 			var expectedInvoices [3]db.Invoice
 			for i := 0; i < len(expectedInvoices); i++ {
 				inv, err := createRandTestInvoice(ctx, q, userId)
@@ -171,11 +477,87 @@ func TestFindAllPendingInvoices(t *testing.T) {
 
 				expectedInvoices[i] = inv
 			}
+			if _, err := createRandTestInvoice(ctx, q, otherUserId); err != nil {
+				log.Fatal(err)
+			}
+
+			if _, err := q.ExpireInvoiceById(ctx, expectedInvoices[0].ID); err != nil {
+				log.Fatal(err)
+			}
+
+			invoices, err := q.FindInvoicesByFilter(ctx, db.FindInvoicesByFilterParams{UserID: userId, Limit: 10})
+			assert.NoError(t, err)
+			assert.Equal(t, len(expectedInvoices), len(invoices))
+
+			invoices, err = q.FindInvoicesByFilter(ctx, db.FindInvoicesByFilterParams{
+				UserID: userId,
+				Status: db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypeEXPIRED, Valid: true},
+				Limit:  10,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(invoices))
+			assert.Equal(t, expectedInvoices[0].ID, invoices[0].ID)
+		})
+	})
+
+	t.Run("Should Filter By ExpiresAt Range And Paginate", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
 
-			var actualAmount pgtype.Float8
-			if err := actualAmount.Scan(1.5); err != nil {
+			for i := 0; i < 3; i++ {
+				if _, err := createRandTestInvoice(ctx, q, userId); err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			var expiresAtFrom pgtype.Timestamptz
+			if err := expiresAtFrom.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
 				log.Fatal(err)
 			}
+
+			invoices, err := q.FindInvoicesByFilter(ctx, db.FindInvoicesByFilterParams{UserID: userId, ExpiresAtFrom: expiresAtFrom, Limit: 10})
+			assert.NoError(t, err)
+			assert.Equal(t, 0, len(invoices))
+
+			invoices, err = q.FindInvoicesByFilter(ctx, db.FindInvoicesByFilterParams{UserID: userId, Limit: 2})
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(invoices))
+
+			invoices, err = q.FindInvoicesByFilter(ctx, db.FindInvoicesByFilterParams{UserID: userId, Limit: 2, Offset: 2})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(invoices))
+		})
+	})
+}
+
+func TestFindAllPendingInvoices(t *testing.T) {
+	t.Run("Should Return 2 Invoices", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			var expectedInvoices [3]db.Invoice
+			for i := 0; i < len(expectedInvoices); i++ {
+				inv, err := createRandTestInvoice(ctx, q, userId)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				expectedInvoices[i] = inv
+			}
+
+			actualAmount := numeric(150000000)
 			var txId pgtype.Text
 			if err := txId.Scan("txid"); err != nil {
 				log.Fatal(err)
@@ -217,10 +599,7 @@ func TestFindAllPendingInvoices(t *testing.T) {
 				expectedInvoices[i] = inv
 			}
 
-			var actualAmount pgtype.Float8
-			if err := actualAmount.Scan(1.5); err != nil {
-				log.Fatal(err)
-			}
+			actualAmount := numeric(150000000)
 			var txId pgtype.Text
 			if err := txId.Scan("txid"); err != nil {
 				log.Fatal(err)
@@ -323,3 +702,259 @@ func TestShiftExpiresAtForNonConfirmedInvoices(t *testing.T) {
 		}
 	})
 }
+
+func TestFindInvoiceByUserIdAndIdempotencyKey(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+		params := db.CreateInvoiceParams{
+			CryptoAddress:          uuid.NewString(),
+			Coin:                   db.CoinTypeXMR,
+			RequiredAmount:         numeric(1),
+			ExpiresAt:              expiresAt,
+			UserID:                 userId,
+			IdempotencyKey:         pgtype.Text{String: "order-42", Valid: true},
+			IdempotencyRequestHash: pgtype.Text{String: "hash", Valid: true},
+		}
+
+		inv, err := q.CreateInvoice(ctx, params)
+		assert.NoError(t, err)
+
+		found, err := q.FindInvoiceByUserIdAndIdempotencyKey(ctx, db.FindInvoiceByUserIdAndIdempotencyKeyParams{UserID: userId, IdempotencyKey: params.IdempotencyKey})
+		assert.NoError(t, err)
+		assert.Equal(t, inv, found)
+		assert.Equal(t, "hash", found.IdempotencyRequestHash.String)
+
+		_, err = q.FindInvoiceByUserIdAndIdempotencyKey(ctx, db.FindInvoiceByUserIdAndIdempotencyKeyParams{UserID: userId, IdempotencyKey: pgtype.Text{String: "order-43", Valid: true}})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		params.CryptoAddress = uuid.NewString()
+		_, err = q.CreateInvoice(ctx, params)
+
+		var pgErr *pgconn.PgError
+		assert.ErrorAs(t, err, &pgErr)
+		assert.Equal(t, "23505", pgErr.Code)
+		assert.Equal(t, "unique_user_id_idempotency_key", pgErr.ConstraintName)
+	})
+}
+
+func TestClearIdempotencyKeyById(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+		params := db.CreateInvoiceParams{
+			CryptoAddress:          uuid.NewString(),
+			Coin:                   db.CoinTypeXMR,
+			RequiredAmount:         numeric(1),
+			ExpiresAt:              expiresAt,
+			UserID:                 userId,
+			IdempotencyKey:         pgtype.Text{String: "order-42", Valid: true},
+			IdempotencyRequestHash: pgtype.Text{String: "hash", Valid: true},
+		}
+		inv, err := q.CreateInvoice(ctx, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		clearedInv, err := q.ClearIdempotencyKeyById(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.False(t, clearedInv.IdempotencyKey.Valid)
+		assert.False(t, clearedInv.IdempotencyRequestHash.Valid)
+
+		_, err = q.FindInvoiceByUserIdAndIdempotencyKey(ctx, db.FindInvoiceByUserIdAndIdempotencyKeyParams{UserID: userId, IdempotencyKey: params.IdempotencyKey})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		// The key is free to be used again
+		params.CryptoAddress = uuid.NewString()
+		_, err = q.CreateInvoice(ctx, params)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateInvoiceWithMerchantFields(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:   uuid.NewString(),
+			Coin:            db.CoinTypeXMR,
+			RequiredAmount:  numeric(1),
+			ExpiresAt:       expiresAt,
+			UserID:          userId,
+			Description:     pgtype.Text{String: "2x Coffee", Valid: true},
+			ExternalOrderID: pgtype.Text{String: "order-42", Valid: true},
+			Metadata:        []byte(`{"customerId": "7"}`),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "2x Coffee", inv.Description.String)
+		assert.JSONEq(t, `{"customerId": "7"}`, string(inv.Metadata))
+
+		// Without metadata the column defaults to an empty object
+		anotherInv, err := createRandTestInvoice(ctx, q, userId)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(anotherInv.Metadata))
+		assert.False(t, anotherInv.ExternalOrderID.Valid)
+
+		invoices, err := q.FindInvoicesByFilter(ctx, db.FindInvoicesByFilterParams{
+			UserID:          userId,
+			ExternalOrderID: pgtype.Text{String: "order-42", Valid: true},
+			Limit:           10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 1)
+		assert.Equal(t, inv.ID, invoices[0].ID)
+	})
+}
+
+func TestInvoiceGroups(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+		var groupId pgtype.UUID
+		if err := groupId.Scan(uuid.NewString()); err != nil {
+			log.Fatal(err)
+		}
+
+		group := make([]db.Invoice, 0, 3)
+		for _, coin := range []db.CoinType{db.CoinTypeXMR, db.CoinTypeBTC, db.CoinTypeUSDTERC20} {
+			inv, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+				CryptoAddress:  uuid.NewString(),
+				Coin:           coin,
+				RequiredAmount: numeric(1),
+				ExpiresAt:      expiresAt,
+				UserID:         userId,
+				GroupID:        groupId,
+			})
+			assert.NoError(t, err)
+			group = append(group, inv)
+		}
+		// Not a part of the group
+		_, err = createRandTestInvoice(ctx, q, userId)
+		assert.NoError(t, err)
+
+		invoices, err := q.FindInvoicesByGroupId(ctx, groupId)
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 3)
+
+		invoices, err = q.FindInvoicesByFilter(ctx, db.FindInvoicesByFilterParams{GroupID: groupId, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 3)
+
+		invoices, err = q.FindAllPendingInvoicesWithPaidGroupSibling(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 0)
+
+		_, err = q.PartiallyPayInvoiceById(ctx, db.PartiallyPayInvoiceByIdParams{ID: group[2].ID, ActualAmount: numeric(1), TxID: pgtype.Text{String: uuid.NewString(), Valid: true}})
+		assert.NoError(t, err)
+
+		_, err = q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: group[1].ID, ActualAmount: numeric(1), TxID: pgtype.Text{String: uuid.NewString(), Valid: true}})
+		assert.NoError(t, err)
+
+		invoices, err = q.FindAllPendingInvoicesWithPaidGroupSibling(ctx)
+		assert.NoError(t, err)
+		ids := make([]pgtype.UUID, 0, len(invoices))
+		for i := 0; i < len(invoices); i++ {
+			ids = append(ids, invoices[i].ID)
+		}
+		assert.ElementsMatch(t, []pgtype.UUID{group[0].ID, group[2].ID}, ids)
+	})
+}
+
+func TestRollbackInvoiceById(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+		inv, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:  uuid.NewString(),
+			Coin:           db.CoinTypeLTC,
+			RequiredAmount: numeric(1),
+			ExpiresAt:      expiresAt,
+			UserID:         userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: inv.ID, ActualAmount: numeric(1), TxID: pgtype.Text{String: uuid.NewString(), Valid: true}}); err != nil {
+			log.Fatal(err)
+		}
+		if _, err := q.ConfirmInvoiceById(ctx, inv.ID); err != nil {
+			log.Fatal(err)
+		}
+
+		var confirmedAtFrom pgtype.Timestamptz
+		if err := confirmedAtFrom.Scan(time.Now().UTC().Add(-time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		candidates, err := q.FindReorgCandidateInvoicesByCoins(ctx, db.FindReorgCandidateInvoicesByCoinsParams{Coins: []string{string(db.CoinTypeLTC)}, ConfirmedAtFrom: confirmedAtFrom})
+		assert.NoError(t, err)
+		assert.Len(t, candidates, 1)
+		assert.Equal(t, inv.ID, candidates[0].ID)
+
+		var minExpiresAt pgtype.Timestamptz
+		if err := minExpiresAt.Scan(time.Now().UTC().Add(time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		rolledBackInv, err := q.RollbackInvoiceById(ctx, db.RollbackInvoiceByIdParams{ID: inv.ID, Status: db.InvoiceStatusTypePENDING, MinExpiresAt: minExpiresAt})
+		assert.NoError(t, err)
+		assert.Equal(t, db.InvoiceStatusTypePENDING, rolledBackInv.Status)
+		assert.False(t, rolledBackInv.ConfirmedAt.Valid)
+		assert.False(t, rolledBackInv.ActualAmount.Valid)
+		assert.False(t, rolledBackInv.TxID.Valid)
+		assert.True(t, rolledBackInv.ExpiresAt.Time.After(expiresAt.Time))
+
+		candidates, err = q.FindReorgCandidateInvoicesByCoins(ctx, db.FindReorgCandidateInvoicesByCoinsParams{Coins: []string{string(db.CoinTypeLTC)}, ConfirmedAtFrom: confirmedAtFrom})
+		assert.NoError(t, err)
+		assert.Len(t, candidates, 0)
+	})
+}
@@ -3,11 +3,14 @@ package db_test
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"os"
 	"testing"
 
 	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
 	"github.com/chekist32/goipay/test"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -16,6 +19,15 @@ var (
 	dbCoinTypes [5]db.CoinType = [5]db.CoinType{db.CoinTypeXMR, db.CoinTypeBTC, db.CoinTypeLTC, db.CoinTypeETH, db.CoinTypeTON}
 )
 
+func numeric(units int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(units), Valid: true}
+}
+
+// numericString normalizes the numeric, since the scale of the one read back from the db may differ from the written one.
+func numericString(n pgtype.Numeric) string {
+	return util.PgNumericToBigInt(n).String()
+}
+
 func TestMain(m *testing.M) {
 	dbConn, _, close := test.SpinUpPostgresContainerAndGetPgxpool(fmt.Sprintf("%v/../../../sql/migrations", os.Getenv("PWD")))
 	dbConnPool = dbConn
@@ -0,0 +1,157 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/test"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestWebhookDelivery(ctx context.Context, q *db.Queries) db.WebhookDelivery {
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	inv, err := createRandTestInvoice(ctx, q, userId)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	delivery, err := q.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{UserID: userId, InvoiceID: inv.ID, Payload: []byte(`{"event": "invoice.updated"}`)})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return delivery
+}
+
+func TestUpsertWebhookEndpoint(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		endpoint, err := q.UpsertWebhookEndpoint(ctx, db.UpsertWebhookEndpointParams{UserID: userId, Url: "https://example.com/1", Secret: "secret1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/1", endpoint.Url)
+
+		endpoint, err = q.UpsertWebhookEndpoint(ctx, db.UpsertWebhookEndpointParams{UserID: userId, Url: "https://example.com/2", Secret: "secret2"})
+		assert.NoError(t, err)
+
+		found, err := q.FindWebhookEndpointByUserId(ctx, userId)
+		assert.NoError(t, err)
+		assert.Equal(t, endpoint, found)
+		assert.Equal(t, "https://example.com/2", found.Url)
+		assert.Equal(t, "secret2", found.Secret)
+
+		// An update of the url alone keeps the secret the receiver verifies the signatures with.
+		endpoint, err = q.UpsertWebhookEndpoint(ctx, db.UpsertWebhookEndpointParams{UserID: userId, Url: "https://example.com/3", Secret: "secret3", KeepSecret: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/3", endpoint.Url)
+		assert.Equal(t, "secret2", endpoint.Secret)
+
+		_, err = q.DeleteWebhookEndpointByUserId(ctx, userId)
+		assert.NoError(t, err)
+
+		_, err = q.FindWebhookEndpointByUserId(ctx, userId)
+		assert.True(t, errors.Is(err, pgx.ErrNoRows))
+	})
+}
+
+func TestCreateWebhookDelivery(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		delivery := createTestWebhookDelivery(ctx, q)
+		assert.Equal(t, db.WebhookDeliveryStatusTypePENDING, delivery.Status)
+		assert.Equal(t, int32(0), delivery.Attempts)
+		assert.False(t, delivery.DeliveredAt.Valid)
+		assert.JSONEq(t, `{"event": "invoice.updated"}`, string(delivery.Payload))
+
+		found, err := q.FindWebhookDeliveryById(ctx, delivery.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, delivery, found)
+	})
+}
+
+func TestClaimDueWebhookDeliveries(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		delivery := createTestWebhookDelivery(ctx, q)
+		leaseUntil := pgtype.Timestamptz{Time: time.Now().UTC().Add(time.Hour), Valid: true}
+
+		claimed, err := q.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{LeaseUntil: leaseUntil, Limit: 1000})
+		assert.NoError(t, err)
+
+		found := false
+		for i := 0; i < len(claimed); i++ {
+			if claimed[i].ID == delivery.ID {
+				found = true
+				assert.True(t, claimed[i].NextAttemptAt.Time.After(time.Now()))
+			}
+		}
+		assert.True(t, found)
+
+		// The leased delivery is no longer due.
+		claimed, err = q.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{LeaseUntil: leaseUntil, Limit: 1000})
+		assert.NoError(t, err)
+		for i := 0; i < len(claimed); i++ {
+			assert.NotEqual(t, delivery.ID, claimed[i].ID)
+		}
+	})
+}
+
+func TestWebhookDeliveryLifecycle(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		delivery := createTestWebhookDelivery(ctx, q)
+
+		failed, err := q.FailWebhookDeliveryById(ctx, db.FailWebhookDeliveryByIdParams{
+			ID:            delivery.ID,
+			Status:        db.WebhookDeliveryStatusTypeDEAD,
+			LastError:     pgtype.Text{String: "unexpected status code: 500", Valid: true},
+			NextAttemptAt: pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, db.WebhookDeliveryStatusTypeDEAD, failed.Status)
+		assert.Equal(t, int32(1), failed.Attempts)
+		assert.Equal(t, "unexpected status code: 500", failed.LastError.String)
+
+		deadDeliveries, err := q.FindWebhookDeliveriesByFilter(ctx, db.FindWebhookDeliveriesByFilterParams{
+			UserID: delivery.UserID,
+			Status: db.NullWebhookDeliveryStatusType{WebhookDeliveryStatusType: db.WebhookDeliveryStatusTypeDEAD, Valid: true},
+			Limit:  10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, deadDeliveries, 1)
+
+		redelivered, err := q.RedeliverWebhookDeliveryById(ctx, delivery.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, db.WebhookDeliveryStatusTypePENDING, redelivered.Status)
+		assert.Equal(t, int32(0), redelivered.Attempts)
+
+		delivered, err := q.MarkWebhookDeliveryDeliveredById(ctx, delivery.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, db.WebhookDeliveryStatusTypeDELIVERED, delivered.Status)
+		assert.Equal(t, int32(1), delivered.Attempts)
+		assert.False(t, delivered.LastError.Valid)
+		assert.True(t, delivered.DeliveredAt.Valid)
+	})
+}